@@ -94,3 +94,23 @@ func (a *ArenaClient) Evaluate() *EvaluateClient {
 func (a *ArenaClient) Model() *ModelClient {
 	return NewModelClient(a.namespace, a.arenaConfiger)
 }
+
+// IdleJob returns the client of finding idle jobs
+func (a *ArenaClient) IdleJob() *IdleJobClient {
+	return NewIdleJobClient(a.namespace, a.arenaConfiger)
+}
+
+// Usage returns the client of resource usage reports
+func (a *ArenaClient) Usage() *UsageClient {
+	return NewUsageClient(a.namespace, a.arenaConfiger)
+}
+
+// Exporter returns the client of the prometheus exporter of arena jobs and nodes
+func (a *ArenaClient) Exporter() *ExporterClient {
+	return NewExporterClient(a.namespace, a.arenaConfiger)
+}
+
+// ModelRegistry returns the registered models client
+func (a *ArenaClient) ModelRegistry() *ModelRegistryClient {
+	return NewModelRegistryClient(a.namespace, a.arenaConfiger)
+}
@@ -29,6 +29,15 @@ func (c *CronClient) SubmitCronTrainingJob(job *apiscron.Job) error {
 	case types.CronTFTrainingJob:
 		args := job.Args().(*types.CronTFJobArgs)
 		return cron.SubmitCronTFJob(c.namespace, args)
+	case types.CronPyTorchTrainingJob:
+		args := job.Args().(*types.CronPyTorchJobArgs)
+		return cron.SubmitCronPyTorchJob(c.namespace, args)
+	case types.CronMPITrainingJob:
+		args := job.Args().(*types.CronMPIJobArgs)
+		return cron.SubmitCronMPIJob(c.namespace, args)
+	case types.CronModelBenchmarkJob:
+		args := job.Args().(*types.CronModelBenchmarkArgs)
+		return cron.SubmitCronModelBenchmarkJob(c.namespace, args)
 	}
 	return nil
 }
@@ -101,3 +110,34 @@ func (c *CronClient) Delete(names ...string) error {
 
 	return nil
 }
+
+// Update updates the schedule options or the workload template of the cron in place
+func (c *CronClient) Update(args *types.UpdateCronArgs) error {
+	return cron.UpdateCron(c.namespace, args)
+}
+
+// Next returns the next fire times of the cron
+func (c *CronClient) Next(name string, count int, timezone string) (*types.CronScheduleInfo, error) {
+	return cron.GetCronNextSchedules(name, c.namespace, count, timezone)
+}
+
+// NextAndPrint prints the next fire times of the cron
+func (c *CronClient) NextAndPrint(name string, count int, timezone string, format string) error {
+	outputFormat := utils.TransferPrintFormat(format)
+	if outputFormat == types.UnknownFormat {
+		return fmt.Errorf("Unknown output format,only support:[wide|json|yaml]")
+	}
+
+	scheduleInfo, err := cron.GetCronNextSchedules(name, c.namespace, count, timezone)
+	if err != nil {
+		return err
+	}
+
+	cron.DisplayCronNextSchedules(scheduleInfo, outputFormat)
+	return nil
+}
+
+// Trigger creates a job from the template of cron immediately
+func (c *CronClient) Trigger(name string) error {
+	return cron.TriggerCron(name, c.namespace)
+}
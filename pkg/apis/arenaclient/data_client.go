@@ -1,7 +1,11 @@
 package arenaclient
 
 import (
+	"fmt"
+
 	"github.com/kubeflow/arena/pkg/apis/config"
+	"github.com/kubeflow/arena/pkg/apis/types"
+	"github.com/kubeflow/arena/pkg/apis/utils"
 	"github.com/kubeflow/arena/pkg/datahouse"
 )
 
@@ -30,3 +34,38 @@ func (d *DataClient) Namespace(namespace string) *DataClient {
 func (d *DataClient) ListAndPrintDataVolumes(namespace string, allNamespaces bool) error {
 	return datahouse.DisplayDataVolumes(namespace, allNamespaces)
 }
+
+// Create creates a data volume backed by nfs, host path or storage class
+func (d *DataClient) Create(args *types.DataVolumeArgs) error {
+	args.Namespace = d.namespace
+	return datahouse.CreateDataVolume(args)
+}
+
+// Describe returns the detail of data volume and the jobs which are mounting it
+func (d *DataClient) Describe(name string) (*types.DataVolumeInfo, error) {
+	return datahouse.GetDataVolume(d.namespace, name)
+}
+
+// DescribeAndPrint prints the detail of data volume and the jobs which are mounting it
+func (d *DataClient) DescribeAndPrint(name string, format string) error {
+	outputFormat := utils.TransferPrintFormat(format)
+	if outputFormat == types.UnknownFormat {
+		return fmt.Errorf("Unknown output format,only support:[wide|json|yaml]")
+	}
+	info, err := datahouse.GetDataVolume(d.namespace, name)
+	if err != nil {
+		return err
+	}
+	datahouse.DisplayDataVolume(info, outputFormat)
+	return nil
+}
+
+// Delete deletes the data volumes which are not in use
+func (d *DataClient) Delete(names ...string) error {
+	for _, name := range names {
+		if err := datahouse.DeleteDataVolume(d.namespace, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
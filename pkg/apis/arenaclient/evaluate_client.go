@@ -2,13 +2,13 @@ package arenaclient
 
 import (
 	"fmt"
+	"github.com/google/uuid"
 	"github.com/kubeflow/arena/pkg/apis/config"
 	apievaluate "github.com/kubeflow/arena/pkg/apis/evaluate"
 	"github.com/kubeflow/arena/pkg/apis/types"
 	"github.com/kubeflow/arena/pkg/apis/utils"
 	"github.com/kubeflow/arena/pkg/evaluate"
 	log "github.com/sirupsen/logrus"
-	"github.com/google/uuid"
 )
 
 type EvaluateClient struct {
@@ -97,6 +97,54 @@ func (c *EvaluateClient) ListAndPrint(allNamespaces bool, format string) error {
 	return nil
 }
 
+// ListSortedByMetric lists the evaluate jobs sorted by the metric, the higher value is the first one unless ascending is true
+func (c *EvaluateClient) ListSortedByMetric(allNamespaces bool, metric string, ascending bool) ([]*types.EvaluateJobInfo, error) {
+	jobs, err := evaluate.ListEvaluateJobs(c.namespace, allNamespaces)
+	if err != nil {
+		return nil, err
+	}
+	if metric != "" {
+		evaluate.SortEvaluateJobsByMetric(jobs, metric, ascending)
+	}
+	return jobs, nil
+}
+
+// ListSortedByMetricAndPrint lists the evaluate jobs sorted by the metric and prints them
+func (c *EvaluateClient) ListSortedByMetricAndPrint(allNamespaces bool, metric string, ascending bool, format string) error {
+	outputFormat := utils.TransferPrintFormat(format)
+	if outputFormat == types.UnknownFormat {
+		return fmt.Errorf("Unknown output format,only support:[wide|json|yaml]")
+	}
+
+	jobs, err := c.ListSortedByMetric(allNamespaces, metric, ascending)
+	if err != nil {
+		return err
+	}
+	evaluate.DisplayAllEvaluateJobs(jobs, allNamespaces, outputFormat)
+	return nil
+}
+
+// Compare returns the evaluate jobs which have published metrics, the jobs are
+// the given names or all evaluate jobs of the model
+func (c *EvaluateClient) Compare(names []string, modelName string) ([]*types.EvaluateJobInfo, error) {
+	return evaluate.CompareEvaluateJobs(names, modelName, c.namespace)
+}
+
+// CompareAndPrint prints the metrics of evaluate jobs side by side
+func (c *EvaluateClient) CompareAndPrint(names []string, modelName, sortBy string, ascending bool, format string) error {
+	outputFormat := utils.TransferPrintFormat(format)
+	if outputFormat == types.UnknownFormat {
+		return fmt.Errorf("Unknown output format,only support:[wide|json|yaml]")
+	}
+
+	jobs, err := evaluate.CompareEvaluateJobs(names, modelName, c.namespace)
+	if err != nil {
+		return err
+	}
+	evaluate.DisplayEvaluateJobComparison(jobs, sortBy, ascending, outputFormat)
+	return nil
+}
+
 func (c *EvaluateClient) Delete(names ...string) error {
 	for _, name := range names {
 		err := evaluate.DeleteEvaluateJob(name, c.namespace)
@@ -107,4 +155,3 @@ func (c *EvaluateClient) Delete(names ...string) error {
 
 	return nil
 }
-
@@ -0,0 +1,34 @@
+package arenaclient
+
+import (
+	"fmt"
+
+	"github.com/kubeflow/arena/pkg/apis/config"
+	"github.com/kubeflow/arena/pkg/exporter"
+)
+
+type ExporterClient struct {
+	namespace string
+	configer  *config.ArenaConfiger
+}
+
+// NewExporterClient creates a ExporterClient
+func NewExporterClient(namespace string, configer *config.ArenaConfiger) *ExporterClient {
+	return &ExporterClient{
+		namespace: namespace,
+		configer:  configer,
+	}
+}
+
+// Run serves the metrics of arena jobs and nodes in prometheus format, it never returns unless
+// the http server fails. The arena client should be created with daemon mode, so that the
+// resources are listed from the informer cache
+func (e *ExporterClient) Run(address, metricsPath string) error {
+	if address == "" {
+		return fmt.Errorf("the listen address should not be empty")
+	}
+	if metricsPath == "" {
+		return fmt.Errorf("the metrics path should not be empty")
+	}
+	return exporter.RunExporter(address, metricsPath)
+}
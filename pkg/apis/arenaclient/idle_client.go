@@ -0,0 +1,81 @@
+package arenaclient
+
+import (
+	"fmt"
+
+	"github.com/kubeflow/arena/pkg/apis/config"
+	"github.com/kubeflow/arena/pkg/apis/types"
+	"github.com/kubeflow/arena/pkg/apis/utils"
+	"github.com/kubeflow/arena/pkg/topidle"
+	log "github.com/sirupsen/logrus"
+)
+
+type IdleJobClient struct {
+	namespace string
+	configer  *config.ArenaConfiger
+}
+
+// NewIdleJobClient creates a IdleJobClient
+func NewIdleJobClient(namespace string, configer *config.ArenaConfiger) *IdleJobClient {
+	return &IdleJobClient{
+		namespace: namespace,
+		configer:  configer,
+	}
+}
+
+// Namespace sets the namespace,this operation does not change the default namespace
+func (i *IdleJobClient) Namespace(namespace string) *IdleJobClient {
+	copyIdleJobClient := &IdleJobClient{
+		namespace: namespace,
+		configer:  i.configer,
+	}
+	return copyIdleJobClient
+}
+
+// List returns the training and serving jobs which hold gpus but leave them idle,
+// the action of args is taken on them
+func (i *IdleJobClient) List(args *types.IdleJobArgs) ([]*types.IdleJobInfo, error) {
+	if err := validateIdleJobArgs(args); err != nil {
+		return nil, err
+	}
+	args.Namespace = i.namespace
+	return topidle.ListIdleJobs(args)
+}
+
+// ListAndPrint prints the idle jobs
+func (i *IdleJobClient) ListAndPrint(args *types.IdleJobArgs, format string) error {
+	outputFormat := utils.TransferPrintFormat(format)
+	if outputFormat == types.UnknownFormat {
+		return fmt.Errorf("Unknown output format,only support:[wide|json|yaml]")
+	}
+	jobs, err := i.List(args)
+	if err != nil {
+		return err
+	}
+	topidle.DisplayIdleJobs(jobs, args, outputFormat)
+	return nil
+}
+
+// Resume resumes the job which is suspended by the suspend action
+func (i *IdleJobClient) Resume(name string) error {
+	if err := topidle.ResumeIdleJob(i.namespace, name); err != nil {
+		return err
+	}
+	log.Infof("the job %v is resumed", name)
+	return nil
+}
+
+func validateIdleJobArgs(args *types.IdleJobArgs) error {
+	if args.Threshold < 0 || args.Threshold > 100 {
+		return fmt.Errorf("the threshold should be in range [0,100]")
+	}
+	if args.Duration <= 0 {
+		return fmt.Errorf("the idle duration should be greater than 0")
+	}
+	switch args.Action {
+	case types.NoneIdleJobAction, types.AnnotateIdleJobAction, types.SuspendIdleJobAction:
+	default:
+		return fmt.Errorf("unknown action %v,only support:[%v|%v]", args.Action, types.AnnotateIdleJobAction, types.SuspendIdleJobAction)
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package arenaclient
 
 import (
+	"fmt"
 	"github.com/kubeflow/arena/pkg/apis/config"
 	apismodel "github.com/kubeflow/arena/pkg/apis/model"
 	"github.com/kubeflow/arena/pkg/apis/types"
@@ -101,3 +102,85 @@ func (m *ModelClient) Delete(jobType types.ModelJobType, jobNames ...string) err
 	}
 	return nil
 }
+
+// Compare puts the results metrics of model jobs side by side
+func (m *ModelClient) Compare(jobNames ...string) (*types.ModelJobComparison, error) {
+	return model.CompareModelJobs(m.namespace, jobNames)
+}
+
+func (m *ModelClient) CompareAndPrint(format string, jobNames ...string) error {
+	outputFormat := utils.TransferPrintFormat(format)
+	if outputFormat == types.UnknownFormat {
+		return fmt.Errorf("Unknown output format,only support:[wide|json|yaml]")
+	}
+	comparison, err := model.CompareModelJobs(m.namespace, jobNames)
+	if err != nil {
+		return err
+	}
+
+	model.DisplayModelJobComparison(comparison, outputFormat)
+	return nil
+}
+
+// Deploy runs the model deploy pipeline in the foreground, it returns after the serving job is
+// created or any stage is failed
+func (m *ModelClient) Deploy(args *types.ModelDeployArgs) (*types.ModelDeployInfo, error) {
+	args.Namespace = m.namespace
+	if args.OptimizeArgs != nil {
+		args.OptimizeArgs.Namespace = m.namespace
+	}
+	if args.BenchmarkArgs != nil {
+		args.BenchmarkArgs.Namespace = m.namespace
+	}
+	if args.TritonArgs != nil {
+		args.TritonArgs.Namespace = m.namespace
+	}
+	return model.DeployModel(args)
+}
+
+// GetDeploy returns the status of model deploy pipeline
+func (m *ModelClient) GetDeploy(name string) (*types.ModelDeployInfo, error) {
+	return model.GetModelDeploy(m.namespace, name)
+}
+
+func (m *ModelClient) GetDeployAndPrint(name string, format string) error {
+	outputFormat := utils.TransferPrintFormat(format)
+	if outputFormat == types.UnknownFormat {
+		return fmt.Errorf("Unknown output format,only support:[wide|json|yaml]")
+	}
+	info, err := model.GetModelDeploy(m.namespace, name)
+	if err != nil {
+		return err
+	}
+
+	model.DisplayModelDeploy(info, outputFormat)
+	return nil
+}
+
+// Push exports the model from a local path or a pvc to the object storage or a pvc
+func (m *ModelClient) Push(args *types.ModelTransferArgs) (*types.ModelTransferResult, error) {
+	args.Type = types.ModelPushTransfer
+	args.Namespace = m.namespace
+	return model.TransferModel(args)
+}
+
+// Pull imports the model from the object storage or a pvc to a local path or a pvc
+func (m *ModelClient) Pull(args *types.ModelTransferArgs) (*types.ModelTransferResult, error) {
+	args.Type = types.ModelPullTransfer
+	args.Namespace = m.namespace
+	return model.TransferModel(args)
+}
+
+func (m *ModelClient) TransferAndPrint(args *types.ModelTransferArgs, format string) error {
+	outputFormat := utils.TransferPrintFormat(format)
+	if outputFormat == types.UnknownFormat {
+		return fmt.Errorf("Unknown output format,only support:[wide|json|yaml]")
+	}
+	args.Namespace = m.namespace
+	result, err := model.TransferModel(args)
+	if err != nil {
+		return err
+	}
+	model.DisplayModelTransferResult(result, outputFormat)
+	return nil
+}
@@ -0,0 +1,91 @@
+package arenaclient
+
+import (
+	"fmt"
+
+	"github.com/kubeflow/arena/pkg/apis/config"
+	"github.com/kubeflow/arena/pkg/apis/types"
+	"github.com/kubeflow/arena/pkg/apis/utils"
+	"github.com/kubeflow/arena/pkg/model/registry"
+	"github.com/kubeflow/arena/pkg/training"
+)
+
+type ModelRegistryClient struct {
+	namespace string
+	configer  *config.ArenaConfiger
+}
+
+// NewModelRegistryClient creates a ModelRegistryClient
+func NewModelRegistryClient(namespace string, configer *config.ArenaConfiger) *ModelRegistryClient {
+	return &ModelRegistryClient{
+		namespace: namespace,
+		configer:  configer,
+	}
+}
+
+// Namespace sets the namespace,this operation does not change the default namespace
+func (m *ModelRegistryClient) Namespace(namespace string) *ModelRegistryClient {
+	copyModelRegistryClient := &ModelRegistryClient{
+		namespace: namespace,
+		configer:  m.configer,
+	}
+	return copyModelRegistryClient
+}
+
+// Create registers a new version of model, the uid of source training job is recorded as the lineage
+func (m *ModelRegistryClient) Create(args *types.RegisterModelArgs) (*types.RegisteredModelInfo, error) {
+	args.Namespace = m.namespace
+	if args.SourceJob != "" {
+		jobType := utils.TransferTrainingJobType(args.SourceJobType)
+		job, err := training.SearchTrainingJob(args.SourceJob, m.namespace, jobType)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find the source training job %s: %v", args.SourceJob, err)
+		}
+		args.SourceJobType = string(job.Trainer())
+		args.SourceJobUID = job.Uid()
+	}
+	return registry.RegisterModel(args)
+}
+
+// List lists the registered model versions
+func (m *ModelRegistryClient) List(allNamespaces bool, name string, stage types.ModelStage) ([]*types.RegisteredModelInfo, error) {
+	return registry.ListModels(m.namespace, allNamespaces, name, stage)
+}
+
+// ListAndPrint lists and prints the registered model versions
+func (m *ModelRegistryClient) ListAndPrint(allNamespaces bool, name string, stage types.ModelStage, format string) error {
+	outputFormat := utils.TransferPrintFormat(format)
+	if outputFormat == types.UnknownFormat {
+		return fmt.Errorf("Unknown output format,only support:[wide|json|yaml]")
+	}
+	models, err := registry.ListModels(m.namespace, allNamespaces, name, stage)
+	if err != nil {
+		return err
+	}
+	registry.DisplayModels(models, allNamespaces, outputFormat)
+	return nil
+}
+
+// Get gets the version of model, the version can also be a stage or latest
+func (m *ModelRegistryClient) Get(name, version string) (*types.RegisteredModelInfo, error) {
+	return registry.GetModel(m.namespace, name, version)
+}
+
+// GetAndPrint prints the version of model
+func (m *ModelRegistryClient) GetAndPrint(name, version string, format string) error {
+	outputFormat := utils.TransferPrintFormat(format)
+	if outputFormat == types.UnknownFormat {
+		return fmt.Errorf("Unknown output format,only support:[wide|json|yaml]")
+	}
+	model, err := registry.GetModel(m.namespace, name, version)
+	if err != nil {
+		return err
+	}
+	registry.DisplayModel(model, outputFormat)
+	return nil
+}
+
+// Promote moves the version of model to the stage
+func (m *ModelRegistryClient) Promote(name, version string, stage types.ModelStage) (*types.RegisteredModelInfo, error) {
+	return registry.PromoteModel(m.namespace, name, version, stage)
+}
@@ -39,31 +39,131 @@ func (t *NodeClient) Details(nodeNames []string, nodeType types.NodeType, showMe
 	return topnode.ListNodeDetails(nodeNames, nodeType, showMetric)
 }
 
-//  ListAndPrintNodes is used to display nodes informations
+// ListAndPrintNodes is used to display nodes informations
 func (t *NodeClient) ListAndPrintNodes(nodeNames []string, nodeType types.NodeType, format types.FormatStyle, details bool, notStop bool, showMetric bool) error {
+	return t.TopAndPrintNodes(&types.TopNodeArgs{
+		NodeNames:   nodeNames,
+		NodeType:    nodeType,
+		ShowDetails: details,
+		ShowMetric:  showMetric,
+		Refresh:     notStop,
+	}, format)
+}
+
+// TopAndPrintNodes displays the nodes selected by args, the nodes can be filtered by labels, sorted
+// and redrawn in place every interval
+func (t *NodeClient) TopAndPrintNodes(args *types.TopNodeArgs, format types.FormatStyle) error {
+	if err := validateTopNodeArgs(args, format); err != nil {
+		return err
+	}
+	// sorting by gpu utilization requires the gpu metrics
+	if args.SortBy == types.NodeSortByGPUUtil {
+		args.ShowMetric = true
+	}
+	if args.Watch {
+		return topnode.WatchNodes(args, format)
+	}
+	if !args.ShowDetails || !args.Refresh {
+		return topnode.DisplayNodes(args, format)
+	}
+	if len(args.NodeNames) != 1 {
+		return fmt.Errorf("must specify only one node name when '-r' is enabled")
+	}
+	for {
+		err := topnode.DisplayNodes(args, format)
+		if err != nil {
+			log.Errorf("failed to display node details,reason: %v", err)
+		}
+		t := time.Now()
+		line := "------------------------- %v -------------------------------------"
+		fmt.Printf(line+"\n", t.Format("2006-01-02 15:04:05"))
+		time.Sleep(2 * time.Second)
+	}
+}
+
+// Health returns the health of nodes, the gpu xid errors, ecc errors and thermal throttling in the
+// time window are checked if prometheus has been installed
+func (t *NodeClient) Health(nodeNames []string, window time.Duration) (*types.NodeHealthReport, error) {
+	if window <= 0 {
+		window = types.DefaultNodeHealthWindow
+	}
+	nodes, err := topnode.BuildNodes(nodeNames, types.AllKnownNode, false)
+	if err != nil {
+		return nil, err
+	}
+	return topnode.BuildNodeHealthReport(nodes, window), nil
+}
+
+// Fit simulates placing the workers of request on the nodes
+func (t *NodeClient) Fit(args *types.NodeFitArgs) (*types.NodeFitReport, error) {
+	if err := validateNodeFitArgs(args); err != nil {
+		return nil, err
+	}
+	return topnode.FitNodes(args)
+}
+
+// FitAndPrint displays the nodes which the workers of request can be placed on,
+// and the fragmentation of node pools
+func (t *NodeClient) FitAndPrint(args *types.NodeFitArgs, format types.FormatStyle) error {
 	if format == types.UnknownFormat {
 		return fmt.Errorf("Unknown output format,only support:[wide|json|yaml]")
 	}
-	if nodeType == types.UnknownNode {
+	report, err := t.Fit(args)
+	if err != nil {
+		return err
+	}
+	topnode.DisplayNodeFitReport(report, format)
+	return nil
+}
+
+func validateNodeFitArgs(args *types.NodeFitArgs) error {
+	if args.GPUs < 0 || args.GPUMemory < 0 || args.CPU < 0 || args.Memory < 0 {
+		return fmt.Errorf("the requested resources should not be negative")
+	}
+	if args.GPUs == 0 && args.GPUMemory == 0 && args.CPU == 0 && args.Memory == 0 {
+		return fmt.Errorf("at least one of gpus, gpu-memory, cpu and memory should be requested")
+	}
+	if args.Workers <= 0 {
+		return fmt.Errorf("the workers should be greater than 0")
+	}
+	return nil
+}
+
+func validateTopNodeArgs(args *types.TopNodeArgs, format types.FormatStyle) error {
+	if format == types.UnknownFormat {
+		return fmt.Errorf("Unknown output format,only support:[wide|json|yaml]")
+	}
+	if args.NodeType == types.UnknownNode {
 		return fmt.Errorf("unknown node type,only supports:[%v]", strings.Join(utils.GetSupportedNodeTypes(), "|"))
 	}
-	if details {
-		if !notStop {
-			return topnode.DisplayNodeDetails(nodeNames, nodeType, format, showMetric)
+	if args.SortBy != "" {
+		supported := []string{}
+		found := false
+		for _, sortBy := range types.NodeSortBySlice {
+			supported = append(supported, string(sortBy))
+			if sortBy == args.SortBy {
+				found = true
+			}
 		}
-		if len(nodeNames) != 1 {
-			return fmt.Errorf("must specify only one node name when '-r' is enabled")
+		if !found {
+			return fmt.Errorf("unknown sort field %v,only supports:[%v]", args.SortBy, strings.Join(supported, "|"))
 		}
-		for {
-			err := topnode.DisplayNodeDetails(nodeNames, nodeType, format, showMetric)
-			if err != nil {
-				log.Errorf("failed to display node details,reason: %v", err)
-			}
-			t := time.Now()
-			line := "------------------------- %v -------------------------------------"
-			fmt.Printf(line+"\n", t.Format("2006-01-02 15:04:05"))
-			time.Sleep(2 * time.Second)
+	}
+	if args.ShowHealth {
+		if args.ShowDetails {
+			return fmt.Errorf("'--health' can not be used with '-d'")
+		}
+		if args.HealthWindow <= 0 {
+			args.HealthWindow = types.DefaultNodeHealthWindow
+		}
+	}
+	if args.Watch {
+		if args.Refresh {
+			return fmt.Errorf("'--watch' can not be used with '-r'")
+		}
+		if args.Interval <= 0 {
+			return fmt.Errorf("the interval of '--watch' should be greater than 0")
 		}
 	}
-	return topnode.DisplayNodeSummary(nodeNames, nodeType, format, showMetric)
+	return nil
 }
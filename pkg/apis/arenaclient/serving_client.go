@@ -10,6 +10,7 @@ import (
 	"github.com/kubeflow/arena/pkg/apis/utils"
 	"github.com/kubeflow/arena/pkg/podexec"
 	"github.com/kubeflow/arena/pkg/serving"
+	"github.com/kubeflow/arena/pkg/training"
 )
 
 // ServingJobClient provides some operators for managing serving jobs.
@@ -59,10 +60,31 @@ func (t *ServingJobClient) Submit(job *apiserving.Job) error {
 	case types.TritonServingJob:
 		args := job.Args().(*types.TritonServingArgs)
 		return serving.SubmitTritonServingJob(args.Namespace, args)
+	case types.TorchServeServingJob:
+		args := job.Args().(*types.TorchServeArgs)
+		return serving.SubmitTorchServeJob(args.Namespace, args)
+	case types.ONNXRuntimeServingJob:
+		args := job.Args().(*types.ONNXRuntimeServingArgs)
+		return serving.SubmitONNXRuntimeServingJob(args.Namespace, args)
 	}
 	return nil
 }
 
+// SubmitFromJob submits a serving job which serves the model produced by the succeeded training job,
+// the uid of training job is recorded in the annotations of serving job as the lineage
+func (t *ServingJobClient) SubmitFromJob(args *types.ServingFromJobArgs) error {
+	args.Namespace = t.namespace
+	source, err := training.GetTrainingJobSource(args.JobName, t.namespace, args.JobType)
+	if err != nil {
+		return fmt.Errorf("failed to find the training job %s: %v", args.JobName, err)
+	}
+	job, err := apiserving.NewServingJobFromTrainingJob(args, source)
+	if err != nil {
+		return err
+	}
+	return t.Submit(job)
+}
+
 // Get returns a serving job information
 func (t *ServingJobClient) Get(jobName, version string, jobType types.ServingJobType) (*types.ServingJobInfo, error) {
 	job, err := serving.SearchServingJob(t.namespace, jobName, version, jobType)
@@ -171,6 +193,12 @@ func (t *ServingJobClient) Update(job *apiserving.Job) error {
 	case types.CustomServingJob:
 		args := job.Args().(*types.UpdateCustomServingArgs)
 		return serving.UpdateCustomServing(args)
+	case types.TorchServeServingJob:
+		args := job.Args().(*types.UpdateTorchServeArgs)
+		return serving.UpdateTorchServe(args)
+	case types.ONNXRuntimeServingJob:
+		args := job.Args().(*types.UpdateONNXRuntimeServingArgs)
+		return serving.UpdateONNXRuntimeServing(args)
 	case types.KServeJob:
 		args := job.Args().(*types.UpdateKServeArgs)
 		return serving.UpdateKServe(args)
@@ -178,6 +206,75 @@ func (t *ServingJobClient) Update(job *apiserving.Job) error {
 	return nil
 }
 
+// History returns the revisions of a serving job
+func (t *ServingJobClient) History(jobName, version string, jobType types.ServingJobType) ([]*types.ServingRevision, error) {
+	return serving.GetServingJobHistory(t.namespace, jobName, version, jobType)
+}
+
+// HistoryAndPrint prints the revisions of a serving job
+func (t *ServingJobClient) HistoryAndPrint(jobName, version string, jobType types.ServingJobType, format string) error {
+	if utils.TransferPrintFormat(format) == types.UnknownFormat {
+		return fmt.Errorf("Unknown output format,only support:[wide|json|yaml]")
+	}
+	revisions, err := serving.GetServingJobHistory(t.namespace, jobName, version, jobType)
+	if err != nil {
+		return err
+	}
+	serving.PrintServingJobHistory(revisions, utils.TransferPrintFormat(format))
+	return nil
+}
+
+// Rollback restores a serving job with the spec of target revision,
+// it rollbacks to the previous revision if toRevision is 0
+func (t *ServingJobClient) Rollback(jobName, version string, jobType types.ServingJobType, toRevision int) error {
+	return serving.RollbackServingJob(t.namespace, jobName, version, jobType, toRevision)
+}
+
+// Predict sends a predict request to a serving job and returns the response
+func (t *ServingJobClient) Predict(args *types.ServingPredictArgs) (*types.ServingPredictResult, error) {
+	args.Namespace = t.namespace
+	return serving.PredictServingJob(args)
+}
+
+// PredictAndPrint sends a predict request to a serving job and prints the response
+func (t *ServingJobClient) PredictAndPrint(args *types.ServingPredictArgs, format string) error {
+	if utils.TransferPrintFormat(format) == types.UnknownFormat {
+		return fmt.Errorf("Unknown output format,only support:[wide|json|yaml]")
+	}
+	args.Namespace = t.namespace
+	result, err := serving.PredictServingJob(args)
+	if err != nil {
+		return err
+	}
+	serving.PrintPredictResult(result, utils.TransferPrintFormat(format))
+	return nil
+}
+
+// LoadTest sends requests to a serving job with the given qps and concurrency and returns the report
+func (t *ServingJobClient) LoadTest(args *types.ServingLoadTestArgs) (*types.ServingLoadTestResult, error) {
+	args.Namespace = t.namespace
+	return serving.LoadTestServingJob(args)
+}
+
+// LoadTestAndPrint load tests a serving job and prints the report
+func (t *ServingJobClient) LoadTestAndPrint(args *types.ServingLoadTestArgs, format string) error {
+	if utils.TransferPrintFormat(format) == types.UnknownFormat {
+		return fmt.Errorf("Unknown output format,only support:[wide|json|yaml]")
+	}
+	args.Namespace = t.namespace
+	result, err := serving.LoadTestServingJob(args)
+	if err != nil {
+		return err
+	}
+	serving.PrintLoadTestResult(result, utils.TransferPrintFormat(format))
+	return nil
+}
+
+// Top displays the resource usage and request metrics of serving jobs
+func (t *ServingJobClient) Top(jobName, version string, allNamespaces bool, jobType types.ServingJobType, instanceName string, notStop bool, format types.FormatStyle) error {
+	return serving.TopServingJobs(t.namespace, allNamespaces, jobName, version, jobType, instanceName, notStop, format)
+}
+
 func (t *ServingJobClient) TrafficRouterSplit(args *types.TrafficRouterSplitArgs) error {
 	return serving.RunTrafficRouterSplit(args.Namespace, args)
 }
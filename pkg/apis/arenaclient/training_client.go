@@ -121,6 +121,49 @@ func (t *TrainingJobClient) GetAndPrint(jobName string, jobType types.TrainingJo
 	return nil
 }
 
+// GetWithGPUHistoryAndPrint prints training job information, the gpu utilization and memory
+// of instances over the whole run are included
+func (t *TrainingJobClient) GetWithGPUHistoryAndPrint(jobName string, jobType types.TrainingJobType, format string, showEvent bool, showGPU bool) error {
+	if utils.TransferPrintFormat(format) == types.UnknownFormat {
+		return fmt.Errorf("Unknown output format,only support:[wide|json|yaml]")
+	}
+	job, err := training.SearchTrainingJob(jobName, t.namespace, jobType)
+	if err != nil {
+		if err == types.ErrTrainingJobNotFound {
+			return fmt.Errorf(errJobNotFoundMessage, jobName, t.namespace)
+		}
+		return err
+	}
+	training.PrintTrainingJobWithGPUHistory(job, format, showEvent, showGPU)
+	return nil
+}
+
+// GPUHistory returns the gpu utilization and memory of job instances in the time window which ends at now,
+// the whole run of job is used if since is 0
+func (t *TrainingJobClient) GPUHistory(jobName string, jobType types.TrainingJobType, since time.Duration) (*types.TrainingJobGPUHistory, error) {
+	job, err := training.SearchTrainingJob(jobName, t.namespace, jobType)
+	if err != nil {
+		if err == types.ErrTrainingJobNotFound {
+			return nil, fmt.Errorf(errJobNotFoundMessage, jobName, t.namespace)
+		}
+		return nil, err
+	}
+	return training.GetTrainingJobGPUHistory(job, since)
+}
+
+// GPUHistoryAndPrint prints the gpu history of training job
+func (t *TrainingJobClient) GPUHistoryAndPrint(jobName string, jobType types.TrainingJobType, since time.Duration, format string) error {
+	if utils.TransferPrintFormat(format) == types.UnknownFormat {
+		return fmt.Errorf("Unknown output format,only support:[wide|json|yaml]")
+	}
+	history, err := t.GPUHistory(jobName, jobType, since)
+	if err != nil {
+		return err
+	}
+	training.DisplayTrainingJobGPUHistory(history, utils.TransferPrintFormat(format))
+	return nil
+}
+
 // List returns all training jobs
 func (t *TrainingJobClient) List(allNamespaces bool, trainingType types.TrainingJobType, showPrometheusMetric bool) ([]*types.TrainingJobInfo, error) {
 	jobs, err := training.ListTrainingJobs(t.namespace, allNamespaces, trainingType)
@@ -0,0 +1,73 @@
+package arenaclient
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kubeflow/arena/pkg/apis/config"
+	"github.com/kubeflow/arena/pkg/apis/types"
+	"github.com/kubeflow/arena/pkg/apis/utils"
+	"github.com/kubeflow/arena/pkg/usage"
+)
+
+type UsageClient struct {
+	namespace string
+	configer  *config.ArenaConfiger
+}
+
+// NewUsageClient creates a UsageClient
+func NewUsageClient(namespace string, configer *config.ArenaConfiger) *UsageClient {
+	return &UsageClient{
+		namespace: namespace,
+		configer:  configer,
+	}
+}
+
+// Namespace sets the namespace,this operation does not change the default namespace
+func (u *UsageClient) Namespace(namespace string) *UsageClient {
+	copyUsageClient := &UsageClient{
+		namespace: namespace,
+		configer:  u.configer,
+	}
+	return copyUsageClient
+}
+
+// Report returns the gpu hours, cpu core hours and memory GiB hours of jobs in the time range
+func (u *UsageClient) Report(args *types.UsageReportArgs) (*types.UsageReport, error) {
+	if !args.To.After(args.From) {
+		return nil, fmt.Errorf("the end of time range should be after the start")
+	}
+	switch args.GroupBy {
+	case types.UsageGroupByNamespace, types.UsageGroupByUser, types.UsageGroupByJobType:
+	default:
+		return nil, fmt.Errorf("unknown group %v,only support:[%v|%v|%v]", args.GroupBy, types.UsageGroupByNamespace, types.UsageGroupByUser, types.UsageGroupByJobType)
+	}
+	args.Namespace = u.namespace
+	return usage.GetUsageReport(args)
+}
+
+// ReportAndPrint prints the usage report
+func (u *UsageClient) ReportAndPrint(args *types.UsageReportArgs, format string) error {
+	outputFormat := types.CSVFormat
+	if format != string(types.CSVFormat) {
+		outputFormat = utils.TransferPrintFormat(format)
+	}
+	if outputFormat == types.UnknownFormat {
+		return fmt.Errorf("Unknown output format,only support:[wide|json|yaml|csv]")
+	}
+	report, err := u.Report(args)
+	if err != nil {
+		return err
+	}
+	usage.DisplayUsageReport(report, outputFormat)
+	return nil
+}
+
+// RunRecorder records the usage of jobs every interval, it never returns
+func (u *UsageClient) RunRecorder(interval time.Duration) error {
+	if interval <= 0 {
+		return fmt.Errorf("the interval should be greater than 0")
+	}
+	usage.RunUsageRecorder(interval)
+	return nil
+}
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"github.com/kubeflow/arena/pkg/apis/types"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+)
+
+// GetAccelerators returns the registered accelerators, the builtin accelerators are returned
+// if the arena configer has not been initialized
+func GetAccelerators() []types.Accelerator {
+	if arenaClient == nil {
+		return types.BuiltinAccelerators
+	}
+	return arenaClient.GetAccelerators()
+}
+
+// GetAcceleratorByResourceName returns the registered accelerator of the resource
+func GetAcceleratorByResourceName(resourceName string) (types.Accelerator, bool) {
+	for _, accelerator := range GetAccelerators() {
+		if accelerator.ResourceName == resourceName {
+			return accelerator, true
+		}
+	}
+	return types.Accelerator{}, false
+}
+
+// getAcceleratorsFromConfigmap returns the accelerators defined in the global configmap, the nvidia gpu
+// is always registered and can be overridden. The builtin accelerators are returned if none is defined
+func getAcceleratorsFromConfigmap(data map[string]string) []types.Accelerator {
+	content, ok := data[types.AcceleratorsConfigKey]
+	if !ok {
+		return types.BuiltinAccelerators
+	}
+	customAccelerators := []types.Accelerator{}
+	if err := yaml.Unmarshal([]byte(content), &customAccelerators); err != nil {
+		log.Warnf("failed to parse the accelerators in configmap %v,use the builtin accelerators,reason: %v", GlobalConfigmapName, err)
+		return types.BuiltinAccelerators
+	}
+	accelerators := []types.Accelerator{types.NvidiaGPUAccelerator}
+	for _, accelerator := range customAccelerators {
+		if accelerator.ResourceName == "" {
+			log.Warnf("skip the accelerator %v without resourceName", accelerator.DisplayName)
+			continue
+		}
+		if accelerator.DisplayName == "" {
+			accelerator.DisplayName = accelerator.ResourceName
+		}
+		if accelerator.ResourceName == types.NvidiaGPUResourceName {
+			accelerators[0] = accelerator
+			continue
+		}
+		accelerators = append(accelerators, accelerator)
+	}
+	return accelerators
+}
@@ -121,10 +121,12 @@ type ArenaConfiger struct {
 	namespace              string
 	arenaNamespace         string
 	configs                map[string]string
+	globalConfigs          map[string]string
 	isDaemonMode           bool
 	clusterInstalledCRDs   []string
 	isolateUserInNamespace bool
 	tokenRetriever         *tokenRetriever
+	accelerators           []types.Accelerator
 }
 
 func newArenaConfiger(args types.ArenaClientArgs) (*ArenaConfiger, error) {
@@ -152,7 +154,6 @@ func newArenaConfiger(args types.ArenaClientArgs) (*ArenaConfiger, error) {
 		}
 	*/
 
-	
 	namespace := updateNamespace(args.Namespace, arenaConfigs, clientConfig)
 	log.Debugf("current namespace is %v", namespace)
 
@@ -172,6 +173,7 @@ func newArenaConfiger(args types.ArenaClientArgs) (*ArenaConfiger, error) {
 	log.Debugf("the user id is %v", userId)
 	data := getGlobalConfigFromConfigmap(args.ArenaNamespace, clientSet)
 	adminUsers := getAdminUserFromConfigmap(data)
+	accelerators := getAcceleratorsFromConfigmap(data)
 	i, err := isolateUserInNamespace(namespace, clientSet)
 	if err != nil {
 		return nil, err
@@ -189,6 +191,7 @@ func newArenaConfiger(args types.ArenaClientArgs) (*ArenaConfiger, error) {
 		namespace:              args.Namespace,
 		arenaNamespace:         args.ArenaNamespace,
 		configs:                arenaConfigs,
+		globalConfigs:          data,
 		isDaemonMode:           args.IsDaemonMode,
 		clusterInstalledCRDs:   []string{},
 		user:                   User{name: *userName, id: userId, group: group, account: account},
@@ -196,6 +199,7 @@ func newArenaConfiger(args types.ArenaClientArgs) (*ArenaConfiger, error) {
 		adminUsers:             adminUsers,
 		isolateUserInNamespace: i,
 		tokenRetriever:         tr,
+		accelerators:           accelerators,
 	}, nil
 
 }
@@ -269,6 +273,16 @@ func (a *ArenaConfiger) GetConfigsFromConfigFile() map[string]string {
 	return a.configs
 }
 
+// GetGlobalConfigs returns the configs read from the global configmap arena-config
+func (a *ArenaConfiger) GetGlobalConfigs() map[string]string {
+	return a.globalConfigs
+}
+
+// GetAccelerators returns the accelerators whose resources are counted as gpus
+func (a *ArenaConfiger) GetAccelerators() []types.Accelerator {
+	return a.accelerators
+}
+
 func (a *ArenaConfiger) IsDaemonMode() bool {
 	return a.isDaemonMode
 }
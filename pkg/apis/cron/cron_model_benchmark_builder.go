@@ -0,0 +1,220 @@
+package cron
+
+import (
+	"github.com/kubeflow/arena/pkg/apis/model"
+	"github.com/kubeflow/arena/pkg/apis/types"
+	"github.com/kubeflow/arena/pkg/argsbuilder"
+	"strings"
+)
+
+type CronModelBenchmarkBuilder struct {
+	args      *types.CronModelBenchmarkArgs
+	argValues map[string]interface{}
+	argsbuilder.ArgsBuilder
+	*model.ModelBenchmarkArgsBuilder
+}
+
+func NewCronModelBenchmarkBuilder() *CronModelBenchmarkBuilder {
+	args := &types.CronModelBenchmarkArgs{
+		ModelBenchmarkArgs: types.ModelBenchmarkArgs{
+			CommonModelArgs: types.CommonModelArgs{
+				Image:     argsbuilder.DefaultModelJobImage,
+				Namespace: "default",
+			},
+		},
+	}
+	return &CronModelBenchmarkBuilder{
+		args:                      args,
+		argValues:                 map[string]interface{}{},
+		ArgsBuilder:               argsbuilder.NewCronModelBenchmarkArgsBuilder(args),
+		ModelBenchmarkArgsBuilder: model.NewModelBenchmarkArgsBuilderWithArgs(&args.ModelBenchmarkArgs),
+	}
+}
+
+func (c *CronModelBenchmarkBuilder) Name(name string) *CronModelBenchmarkBuilder {
+	c.ModelBenchmarkArgsBuilder.Name(name)
+	return c
+}
+
+func (c *CronModelBenchmarkBuilder) Namespace(namespace string) *CronModelBenchmarkBuilder {
+	c.ModelBenchmarkArgsBuilder.Namespace(namespace)
+	return c
+}
+
+func (c *CronModelBenchmarkBuilder) Schedule(schedule string) *CronModelBenchmarkBuilder {
+	if schedule != "" {
+		c.args.Schedule = schedule
+	}
+	return c
+}
+
+func (c *CronModelBenchmarkBuilder) ConcurrencyPolicy(concurrencyPolicy string) *CronModelBenchmarkBuilder {
+	if concurrencyPolicy != "" {
+		c.args.ConcurrencyPolicy = concurrencyPolicy
+	}
+	return c
+}
+
+func (c *CronModelBenchmarkBuilder) Deadline(deadline string) *CronModelBenchmarkBuilder {
+	if deadline != "" {
+		c.args.Deadline = deadline
+	}
+	return c
+}
+
+func (c *CronModelBenchmarkBuilder) HistoryLimit(historyLimit int) *CronModelBenchmarkBuilder {
+	if historyLimit > 0 {
+		c.args.HistoryLimit = historyLimit
+	}
+	return c
+}
+
+func (c *CronModelBenchmarkBuilder) Image(image string) *CronModelBenchmarkBuilder {
+	c.ModelBenchmarkArgsBuilder.Image(image)
+	return c
+}
+
+func (c *CronModelBenchmarkBuilder) ImagePullPolicy(policy string) *CronModelBenchmarkBuilder {
+	c.ModelBenchmarkArgsBuilder.ImagePullPolicy(policy)
+	return c
+}
+
+func (c *CronModelBenchmarkBuilder) ImagePullSecrets(secrets []string) *CronModelBenchmarkBuilder {
+	c.ModelBenchmarkArgsBuilder.ImagePullSecrets(secrets)
+	return c
+}
+
+func (c *CronModelBenchmarkBuilder) GPUCount(count int) *CronModelBenchmarkBuilder {
+	c.ModelBenchmarkArgsBuilder.GPUCount(count)
+	return c
+}
+
+func (c *CronModelBenchmarkBuilder) GPUMemory(memory int) *CronModelBenchmarkBuilder {
+	c.ModelBenchmarkArgsBuilder.GPUMemory(memory)
+	return c
+}
+
+func (c *CronModelBenchmarkBuilder) GPUCore(core int) *CronModelBenchmarkBuilder {
+	c.ModelBenchmarkArgsBuilder.GPUCore(core)
+	return c
+}
+
+func (c *CronModelBenchmarkBuilder) CPU(cpu string) *CronModelBenchmarkBuilder {
+	c.ModelBenchmarkArgsBuilder.CPU(cpu)
+	return c
+}
+
+func (c *CronModelBenchmarkBuilder) Memory(memory string) *CronModelBenchmarkBuilder {
+	c.ModelBenchmarkArgsBuilder.Memory(memory)
+	return c
+}
+
+func (c *CronModelBenchmarkBuilder) Envs(envs map[string]string) *CronModelBenchmarkBuilder {
+	c.ModelBenchmarkArgsBuilder.Envs(envs)
+	return c
+}
+
+func (c *CronModelBenchmarkBuilder) Tolerations(tolerations []string) *CronModelBenchmarkBuilder {
+	c.ModelBenchmarkArgsBuilder.Tolerations(tolerations)
+	return c
+}
+
+func (c *CronModelBenchmarkBuilder) NodeSelectors(selectors map[string]string) *CronModelBenchmarkBuilder {
+	c.ModelBenchmarkArgsBuilder.NodeSelectors(selectors)
+	return c
+}
+
+func (c *CronModelBenchmarkBuilder) Annotations(annotations map[string]string) *CronModelBenchmarkBuilder {
+	c.ModelBenchmarkArgsBuilder.Annotations(annotations)
+	return c
+}
+
+func (c *CronModelBenchmarkBuilder) Labels(labels map[string]string) *CronModelBenchmarkBuilder {
+	c.ModelBenchmarkArgsBuilder.Labels(labels)
+	return c
+}
+
+func (c *CronModelBenchmarkBuilder) Datas(volumes map[string]string) *CronModelBenchmarkBuilder {
+	c.ModelBenchmarkArgsBuilder.Datas(volumes)
+	return c
+}
+
+func (c *CronModelBenchmarkBuilder) DataDirs(volumes map[string]string) *CronModelBenchmarkBuilder {
+	c.ModelBenchmarkArgsBuilder.DataDirs(volumes)
+	return c
+}
+
+func (c *CronModelBenchmarkBuilder) ModelConfigFile(filePath string) *CronModelBenchmarkBuilder {
+	c.ModelBenchmarkArgsBuilder.ModelConfigFile(filePath)
+	return c
+}
+
+func (c *CronModelBenchmarkBuilder) ModelName(name string) *CronModelBenchmarkBuilder {
+	c.ModelBenchmarkArgsBuilder.ModelName(name)
+	return c
+}
+
+func (c *CronModelBenchmarkBuilder) ModelPath(path string) *CronModelBenchmarkBuilder {
+	c.ModelBenchmarkArgsBuilder.ModelPath(path)
+	return c
+}
+
+func (c *CronModelBenchmarkBuilder) Inputs(inputs string) *CronModelBenchmarkBuilder {
+	c.ModelBenchmarkArgsBuilder.Inputs(inputs)
+	return c
+}
+
+func (c *CronModelBenchmarkBuilder) Outputs(outputs string) *CronModelBenchmarkBuilder {
+	c.ModelBenchmarkArgsBuilder.Outputs(outputs)
+	return c
+}
+
+func (c *CronModelBenchmarkBuilder) Concurrency(concurrency int) *CronModelBenchmarkBuilder {
+	c.ModelBenchmarkArgsBuilder.Concurrency(concurrency)
+	return c
+}
+
+func (c *CronModelBenchmarkBuilder) Requests(requests int) *CronModelBenchmarkBuilder {
+	c.ModelBenchmarkArgsBuilder.Requests(requests)
+	return c
+}
+
+func (c *CronModelBenchmarkBuilder) Duration(duration int) *CronModelBenchmarkBuilder {
+	c.ModelBenchmarkArgsBuilder.Duration(duration)
+	return c
+}
+
+func (c *CronModelBenchmarkBuilder) ReportPath(reportPath string) *CronModelBenchmarkBuilder {
+	c.ModelBenchmarkArgsBuilder.ReportPath(reportPath)
+	return c
+}
+
+func (c *CronModelBenchmarkBuilder) Shell(shell string) *CronModelBenchmarkBuilder {
+	if shell != "" {
+		c.args.Shell = shell
+	}
+	return c
+}
+
+func (c *CronModelBenchmarkBuilder) Command(args []string) *CronModelBenchmarkBuilder {
+	c.args.Command = strings.Join(args, " ")
+	return c
+}
+
+func (c *CronModelBenchmarkBuilder) Build() (*Job, error) {
+	for key, value := range c.argValues {
+		c.AddArgValue(key, value)
+	}
+
+	for key, value := range c.ModelBenchmarkArgsBuilder.GetArgValues() {
+		c.AddArgValue(key, value)
+	}
+
+	if err := c.PreBuild(); err != nil {
+		return nil, err
+	}
+	if err := c.ArgsBuilder.Build(); err != nil {
+		return nil, err
+	}
+	return NewJob(c.args.Name, types.CronModelBenchmarkJob, c.args), nil
+}
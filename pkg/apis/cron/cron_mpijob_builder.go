@@ -0,0 +1,224 @@
+package cron
+
+import (
+	"github.com/kubeflow/arena/pkg/apis/training"
+	"github.com/kubeflow/arena/pkg/apis/types"
+	"github.com/kubeflow/arena/pkg/argsbuilder"
+	"strings"
+)
+
+type CronMPIJobBuilder struct {
+	args      *types.CronMPIJobArgs
+	argValues map[string]interface{}
+	argsbuilder.ArgsBuilder
+	*training.MPIJobBuilder
+}
+
+func NewCronMPIJobBuilder() *CronMPIJobBuilder {
+	args := &types.CronMPIJobArgs{
+		SubmitMPIJobArgs: types.SubmitMPIJobArgs{
+			CleanPodPolicy:        "All",
+			CommonSubmitArgs:      training.DefaultCommonSubmitArgs,
+			SubmitTensorboardArgs: training.DefaultSubmitTensorboardArgs,
+		},
+	}
+	return &CronMPIJobBuilder{
+		args:          args,
+		argValues:     map[string]interface{}{},
+		ArgsBuilder:   argsbuilder.NewCronMPIJobArgsBuilder(args),
+		MPIJobBuilder: training.NewMPIJobBuilderWithArgs(&args.SubmitMPIJobArgs),
+	}
+}
+
+func (c *CronMPIJobBuilder) Name(name string) *CronMPIJobBuilder {
+	c.MPIJobBuilder.Name(name)
+	return c
+}
+
+func (c *CronMPIJobBuilder) Schedule(schedule string) *CronMPIJobBuilder {
+	if schedule != "" {
+		c.args.Schedule = schedule
+	}
+	return c
+}
+
+func (c *CronMPIJobBuilder) ConcurrencyPolicy(concurrencyPolicy string) *CronMPIJobBuilder {
+	if concurrencyPolicy != "" {
+		c.args.ConcurrencyPolicy = concurrencyPolicy
+	}
+	return c
+}
+
+func (c *CronMPIJobBuilder) Deadline(deadline string) *CronMPIJobBuilder {
+	if deadline != "" {
+		c.args.Deadline = deadline
+	}
+	return c
+}
+
+func (c *CronMPIJobBuilder) HistoryLimit(historyLimit int) *CronMPIJobBuilder {
+	if historyLimit > 0 {
+		c.args.HistoryLimit = historyLimit
+	}
+	return c
+}
+
+func (c *CronMPIJobBuilder) WorkingDir(dir string) *CronMPIJobBuilder {
+	c.MPIJobBuilder.WorkingDir(dir)
+	return c
+}
+
+func (c *CronMPIJobBuilder) Envs(envs map[string]string) *CronMPIJobBuilder {
+	c.MPIJobBuilder.Envs(envs)
+	return c
+}
+
+func (c *CronMPIJobBuilder) GPUCount(count int) *CronMPIJobBuilder {
+	c.MPIJobBuilder.GPUCount(count)
+	return c
+}
+
+func (c *CronMPIJobBuilder) Image(image string) *CronMPIJobBuilder {
+	c.MPIJobBuilder.Image(image)
+	return c
+}
+
+func (c *CronMPIJobBuilder) Tolerations(tolerations []string) *CronMPIJobBuilder {
+	c.MPIJobBuilder.Tolerations(tolerations)
+	return c
+}
+
+func (c *CronMPIJobBuilder) ConfigFiles(files map[string]string) *CronMPIJobBuilder {
+	c.MPIJobBuilder.ConfigFiles(files)
+	return c
+}
+
+func (c *CronMPIJobBuilder) NodeSelectors(selectors map[string]string) *CronMPIJobBuilder {
+	c.MPIJobBuilder.NodeSelectors(selectors)
+	return c
+}
+
+func (c *CronMPIJobBuilder) Annotations(annotations map[string]string) *CronMPIJobBuilder {
+	c.MPIJobBuilder.Annotations(annotations)
+	return c
+}
+
+func (c *CronMPIJobBuilder) Labels(labels map[string]string) *CronMPIJobBuilder {
+	c.MPIJobBuilder.Labels(labels)
+	return c
+}
+
+func (c *CronMPIJobBuilder) Datas(volumes map[string]string) *CronMPIJobBuilder {
+	c.MPIJobBuilder.Datas(volumes)
+	return c
+}
+
+func (c *CronMPIJobBuilder) DataDirs(volumes map[string]string) *CronMPIJobBuilder {
+	c.MPIJobBuilder.DataDirs(volumes)
+	return c
+}
+
+func (c *CronMPIJobBuilder) LogDir(dir string) *CronMPIJobBuilder {
+	c.MPIJobBuilder.LogDir(dir)
+	return c
+}
+
+func (c *CronMPIJobBuilder) Priority(priority string) *CronMPIJobBuilder {
+	c.MPIJobBuilder.Priority(priority)
+	return c
+}
+
+func (c *CronMPIJobBuilder) EnableRDMA() *CronMPIJobBuilder {
+	c.MPIJobBuilder.EnableRDMA()
+	return c
+}
+
+func (c *CronMPIJobBuilder) SyncImage(image string) *CronMPIJobBuilder {
+	c.MPIJobBuilder.SyncImage(image)
+	return c
+}
+
+func (c *CronMPIJobBuilder) SyncMode(mode string) *CronMPIJobBuilder {
+	c.MPIJobBuilder.SyncMode(mode)
+	return c
+}
+
+func (c *CronMPIJobBuilder) SyncSource(source string) *CronMPIJobBuilder {
+	c.MPIJobBuilder.SyncSource(source)
+	return c
+}
+
+func (c *CronMPIJobBuilder) EnableTensorboard() *CronMPIJobBuilder {
+	c.MPIJobBuilder.EnableTensorboard()
+	return c
+}
+
+func (c *CronMPIJobBuilder) TensorboardImage(image string) *CronMPIJobBuilder {
+	c.MPIJobBuilder.TensorboardImage(image)
+	return c
+}
+
+func (c *CronMPIJobBuilder) ImagePullSecrets(secrets []string) *CronMPIJobBuilder {
+	c.MPIJobBuilder.ImagePullSecrets(secrets)
+	return c
+}
+
+func (c *CronMPIJobBuilder) WorkerCount(count int) *CronMPIJobBuilder {
+	c.MPIJobBuilder.WorkerCount(count)
+	return c
+}
+
+func (c *CronMPIJobBuilder) CPU(cpu string) *CronMPIJobBuilder {
+	c.MPIJobBuilder.CPU(cpu)
+	return c
+}
+
+func (c *CronMPIJobBuilder) Memory(memory string) *CronMPIJobBuilder {
+	c.MPIJobBuilder.Memory(memory)
+	return c
+}
+
+func (c *CronMPIJobBuilder) EnableGPUTopology() *CronMPIJobBuilder {
+	c.MPIJobBuilder.EnableGPUTopology()
+	return c
+}
+
+func (c *CronMPIJobBuilder) EnableMountPVCOnLauncher() *CronMPIJobBuilder {
+	c.MPIJobBuilder.EnableMountPVCOnLauncher()
+	return c
+}
+
+func (c *CronMPIJobBuilder) CleanPodPolicy(policy string) *CronMPIJobBuilder {
+	c.MPIJobBuilder.CleanPodPolicy(policy)
+	return c
+}
+
+func (c *CronMPIJobBuilder) Shell(shell string) *CronMPIJobBuilder {
+	if shell != "" {
+		c.args.Shell = shell
+	}
+	return c
+}
+
+func (c *CronMPIJobBuilder) Command(args []string) *CronMPIJobBuilder {
+	c.args.Command = strings.Join(args, " ")
+	return c
+}
+
+func (c *CronMPIJobBuilder) Build() (*Job, error) {
+	for key, value := range c.argValues {
+		c.AddArgValue(key, value)
+	}
+
+	for key, value := range c.MPIJobBuilder.GetArgValues() {
+		c.AddArgValue(key, value)
+	}
+
+	if err := c.PreBuild(); err != nil {
+		return nil, err
+	}
+	if err := c.ArgsBuilder.Build(); err != nil {
+		return nil, err
+	}
+	return NewJob(c.args.Name, types.CronMPITrainingJob, c.args), nil
+}
@@ -0,0 +1,224 @@
+package cron
+
+import (
+	"github.com/kubeflow/arena/pkg/apis/training"
+	"github.com/kubeflow/arena/pkg/apis/types"
+	"github.com/kubeflow/arena/pkg/argsbuilder"
+	"strings"
+)
+
+type CronPyTorchJobBuilder struct {
+	args      *types.CronPyTorchJobArgs
+	argValues map[string]interface{}
+	argsbuilder.ArgsBuilder
+	*training.PytorchJobBuilder
+}
+
+func NewCronPyTorchJobBuilder() *CronPyTorchJobBuilder {
+	args := &types.CronPyTorchJobArgs{
+		SubmitPyTorchJobArgs: types.SubmitPyTorchJobArgs{
+			CleanPodPolicy:        "Running",
+			CommonSubmitArgs:      training.DefaultCommonSubmitArgs,
+			SubmitTensorboardArgs: training.DefaultSubmitTensorboardArgs,
+		},
+	}
+	return &CronPyTorchJobBuilder{
+		args:              args,
+		argValues:         map[string]interface{}{},
+		ArgsBuilder:       argsbuilder.NewCronPyTorchJobArgsBuilder(args),
+		PytorchJobBuilder: training.NewPytorchJobBuilderWithArgs(&args.SubmitPyTorchJobArgs),
+	}
+}
+
+func (c *CronPyTorchJobBuilder) Name(name string) *CronPyTorchJobBuilder {
+	c.PytorchJobBuilder.Name(name)
+	return c
+}
+
+func (c *CronPyTorchJobBuilder) Schedule(schedule string) *CronPyTorchJobBuilder {
+	if schedule != "" {
+		c.args.Schedule = schedule
+	}
+	return c
+}
+
+func (c *CronPyTorchJobBuilder) ConcurrencyPolicy(concurrencyPolicy string) *CronPyTorchJobBuilder {
+	if concurrencyPolicy != "" {
+		c.args.ConcurrencyPolicy = concurrencyPolicy
+	}
+	return c
+}
+
+func (c *CronPyTorchJobBuilder) Deadline(deadline string) *CronPyTorchJobBuilder {
+	if deadline != "" {
+		c.args.Deadline = deadline
+	}
+	return c
+}
+
+func (c *CronPyTorchJobBuilder) HistoryLimit(historyLimit int) *CronPyTorchJobBuilder {
+	if historyLimit > 0 {
+		c.args.HistoryLimit = historyLimit
+	}
+	return c
+}
+
+func (c *CronPyTorchJobBuilder) WorkingDir(dir string) *CronPyTorchJobBuilder {
+	c.PytorchJobBuilder.WorkingDir(dir)
+	return c
+}
+
+func (c *CronPyTorchJobBuilder) Envs(envs map[string]string) *CronPyTorchJobBuilder {
+	c.PytorchJobBuilder.Envs(envs)
+	return c
+}
+
+func (c *CronPyTorchJobBuilder) GPUCount(count int) *CronPyTorchJobBuilder {
+	c.PytorchJobBuilder.GPUCount(count)
+	return c
+}
+
+func (c *CronPyTorchJobBuilder) Image(image string) *CronPyTorchJobBuilder {
+	c.PytorchJobBuilder.Image(image)
+	return c
+}
+
+func (c *CronPyTorchJobBuilder) Tolerations(tolerations []string) *CronPyTorchJobBuilder {
+	c.PytorchJobBuilder.Tolerations(tolerations)
+	return c
+}
+
+func (c *CronPyTorchJobBuilder) ConfigFiles(files map[string]string) *CronPyTorchJobBuilder {
+	c.PytorchJobBuilder.ConfigFiles(files)
+	return c
+}
+
+func (c *CronPyTorchJobBuilder) NodeSelectors(selectors map[string]string) *CronPyTorchJobBuilder {
+	c.PytorchJobBuilder.NodeSelectors(selectors)
+	return c
+}
+
+func (c *CronPyTorchJobBuilder) Annotations(annotations map[string]string) *CronPyTorchJobBuilder {
+	c.PytorchJobBuilder.Annotations(annotations)
+	return c
+}
+
+func (c *CronPyTorchJobBuilder) Labels(labels map[string]string) *CronPyTorchJobBuilder {
+	c.PytorchJobBuilder.Labels(labels)
+	return c
+}
+
+func (c *CronPyTorchJobBuilder) Datas(volumes map[string]string) *CronPyTorchJobBuilder {
+	c.PytorchJobBuilder.Datas(volumes)
+	return c
+}
+
+func (c *CronPyTorchJobBuilder) DataDirs(volumes map[string]string) *CronPyTorchJobBuilder {
+	c.PytorchJobBuilder.DataDirs(volumes)
+	return c
+}
+
+func (c *CronPyTorchJobBuilder) LogDir(dir string) *CronPyTorchJobBuilder {
+	c.PytorchJobBuilder.LogDir(dir)
+	return c
+}
+
+func (c *CronPyTorchJobBuilder) Priority(priority string) *CronPyTorchJobBuilder {
+	c.PytorchJobBuilder.Priority(priority)
+	return c
+}
+
+func (c *CronPyTorchJobBuilder) EnableRDMA() *CronPyTorchJobBuilder {
+	c.PytorchJobBuilder.EnableRDMA()
+	return c
+}
+
+func (c *CronPyTorchJobBuilder) SyncImage(image string) *CronPyTorchJobBuilder {
+	c.PytorchJobBuilder.SyncImage(image)
+	return c
+}
+
+func (c *CronPyTorchJobBuilder) SyncMode(mode string) *CronPyTorchJobBuilder {
+	c.PytorchJobBuilder.SyncMode(mode)
+	return c
+}
+
+func (c *CronPyTorchJobBuilder) SyncSource(source string) *CronPyTorchJobBuilder {
+	c.PytorchJobBuilder.SyncSource(source)
+	return c
+}
+
+func (c *CronPyTorchJobBuilder) EnableTensorboard() *CronPyTorchJobBuilder {
+	c.PytorchJobBuilder.EnableTensorboard()
+	return c
+}
+
+func (c *CronPyTorchJobBuilder) TensorboardImage(image string) *CronPyTorchJobBuilder {
+	c.PytorchJobBuilder.TensorboardImage(image)
+	return c
+}
+
+func (c *CronPyTorchJobBuilder) ImagePullSecrets(secrets []string) *CronPyTorchJobBuilder {
+	c.PytorchJobBuilder.ImagePullSecrets(secrets)
+	return c
+}
+
+func (c *CronPyTorchJobBuilder) CleanPodPolicy(policy string) *CronPyTorchJobBuilder {
+	c.PytorchJobBuilder.CleanPodPolicy(policy)
+	return c
+}
+
+func (c *CronPyTorchJobBuilder) WorkerCount(count int) *CronPyTorchJobBuilder {
+	c.PytorchJobBuilder.WorkerCount(count)
+	return c
+}
+
+func (c *CronPyTorchJobBuilder) CPU(cpu string) *CronPyTorchJobBuilder {
+	c.PytorchJobBuilder.CPU(cpu)
+	return c
+}
+
+func (c *CronPyTorchJobBuilder) Memory(memory string) *CronPyTorchJobBuilder {
+	c.PytorchJobBuilder.Memory(memory)
+	return c
+}
+
+func (c *CronPyTorchJobBuilder) ActiveDeadlineSeconds(act int64) *CronPyTorchJobBuilder {
+	c.PytorchJobBuilder.ActiveDeadlineSeconds(act)
+	return c
+}
+
+func (c *CronPyTorchJobBuilder) TTLSecondsAfterFinished(ttl int32) *CronPyTorchJobBuilder {
+	c.PytorchJobBuilder.TTLSecondsAfterFinished(ttl)
+	return c
+}
+
+func (c *CronPyTorchJobBuilder) Shell(shell string) *CronPyTorchJobBuilder {
+	if shell != "" {
+		c.args.Shell = shell
+	}
+	return c
+}
+
+func (c *CronPyTorchJobBuilder) Command(args []string) *CronPyTorchJobBuilder {
+	c.args.Command = strings.Join(args, " ")
+	return c
+}
+
+func (c *CronPyTorchJobBuilder) Build() (*Job, error) {
+	for key, value := range c.argValues {
+		c.AddArgValue(key, value)
+	}
+
+	for key, value := range c.PytorchJobBuilder.GetArgValues() {
+		c.AddArgValue(key, value)
+	}
+
+	if err := c.PreBuild(); err != nil {
+		return nil, err
+	}
+	if err := c.ArgsBuilder.Build(); err != nil {
+		return nil, err
+	}
+	return NewJob(c.args.Name, types.CronPyTorchTrainingJob, c.args), nil
+}
@@ -20,6 +20,11 @@ func NewModelBenchmarkArgsBuilder() *ModelBenchmarkArgsBuilder {
 			Namespace: "default",
 		},
 	}
+	return NewModelBenchmarkArgsBuilderWithArgs(args)
+}
+
+// NewModelBenchmarkArgsBuilderWithArgs creates the builder on the given args, like the args of the model benchmark job in a cron
+func NewModelBenchmarkArgsBuilderWithArgs(args *types.ModelBenchmarkArgs) *ModelBenchmarkArgsBuilder {
 	return &ModelBenchmarkArgsBuilder{
 		args:        args,
 		argValues:   map[string]interface{}{},
@@ -27,6 +32,10 @@ func NewModelBenchmarkArgsBuilder() *ModelBenchmarkArgsBuilder {
 	}
 }
 
+func (m *ModelBenchmarkArgsBuilder) GetArgValues() map[string]interface{} {
+	return m.argValues
+}
+
 // Name is used to set job name,match option --name
 func (m *ModelBenchmarkArgsBuilder) Name(name string) *ModelBenchmarkArgsBuilder {
 	if name != "" {
@@ -0,0 +1,241 @@
+package model
+
+import (
+	"fmt"
+
+	"github.com/kubeflow/arena/pkg/apis/serving"
+	"github.com/kubeflow/arena/pkg/apis/types"
+	"github.com/kubeflow/arena/pkg/apis/utils"
+	"github.com/kubeflow/arena/pkg/argsbuilder"
+	"github.com/spf13/cobra"
+)
+
+// ModelDeployBuilder builds the args of model deploy pipeline, the optimize stage
+// accepts all the options of model optimize job
+type ModelDeployBuilder struct {
+	args            *types.ModelDeployArgs
+	optimizeBuilder *ModelOptimizeJobBuilder
+	concurrency     int
+	requests        int
+	duration        int
+	serveType       string
+	servingImage    string
+	servingGPUs     int
+	servingVersion  string
+	modelRepository string
+}
+
+func NewModelDeployBuilder() *ModelDeployBuilder {
+	return &ModelDeployBuilder{
+		args: &types.ModelDeployArgs{
+			Namespace: "default",
+			Timeout:   3600,
+		},
+		optimizeBuilder: NewModelOptimizeJobBuilder(),
+		concurrency:     1,
+		duration:        60,
+		servingImage:    argsbuilder.DefaultTritonServingImage,
+	}
+}
+
+// AddCommandFlags adds the options of optimize job and the pipeline
+func (m *ModelDeployBuilder) AddCommandFlags(command *cobra.Command) {
+	m.optimizeBuilder.AddCommandFlags(command)
+
+	command.Flags().BoolVar(&m.args.Benchmark, "benchmark", false, "benchmark the optimized model before serving it")
+	command.Flags().Float64Var(&m.args.MinThroughput, "min-throughput", 0, "the min requests per second of the benchmark to serve the optimized model, 0 means no requirement")
+	command.Flags().StringVar(&m.args.OptimizedModelPath, "optimized-model-path", "", "the path of optimized model to benchmark, required by --benchmark")
+	command.Flags().IntVar(&m.concurrency, "concurrency", m.concurrency, "number of benchmark concurrently")
+	command.Flags().IntVar(&m.requests, "requests", m.requests, "number of benchmark requests to run")
+	command.Flags().IntVar(&m.duration, "duration", m.duration, "benchmark duration in seconds")
+	command.Flags().StringVar(&m.serveType, "serve-type", "", "serve the optimized model with the serving type, only support triton, the model is not served if it is empty")
+	command.Flags().StringVar(&m.servingImage, "serving-image", m.servingImage, "the image of serving job")
+	command.Flags().IntVar(&m.servingGPUs, "serving-gpus", 0, "the GPU count of serving job, default is the GPU count of optimize job")
+	command.Flags().StringVar(&m.servingVersion, "serving-version", "", "the version of serving job")
+	command.Flags().StringVar(&m.modelRepository, "model-repository", "", "the triton model repository, default is the export path")
+	command.Flags().IntVar(&m.args.Timeout, "timeout", m.args.Timeout, "the max seconds to wait for each job")
+}
+
+// Optimize returns the builder of optimize stage, it is used to set the options of optimize job
+func (m *ModelDeployBuilder) Optimize() *ModelOptimizeJobBuilder {
+	return m.optimizeBuilder
+}
+
+// Name is used to set the pipeline name, the jobs are named <name>-optimize, <name>-benchmark and <name>
+func (m *ModelDeployBuilder) Name(name string) *ModelDeployBuilder {
+	if name != "" {
+		m.args.Name = name
+	}
+	return m
+}
+
+// Namespace is used to set the namespace of the pipeline and its jobs
+func (m *ModelDeployBuilder) Namespace(namespace string) *ModelDeployBuilder {
+	if namespace != "" {
+		m.args.Namespace = namespace
+		m.optimizeBuilder.Namespace(namespace)
+	}
+	return m
+}
+
+// Benchmark is used to enable the benchmark stage,match option --benchmark
+func (m *ModelDeployBuilder) Benchmark(enabled bool) *ModelDeployBuilder {
+	m.args.Benchmark = enabled
+	return m
+}
+
+// MinThroughput is used to set the min requests per second to serve the model,match option --min-throughput
+func (m *ModelDeployBuilder) MinThroughput(throughput float64) *ModelDeployBuilder {
+	if throughput > 0 {
+		m.args.MinThroughput = throughput
+	}
+	return m
+}
+
+// OptimizedModelPath is used to set the path of optimized model,match option --optimized-model-path
+func (m *ModelDeployBuilder) OptimizedModelPath(path string) *ModelDeployBuilder {
+	if path != "" {
+		m.args.OptimizedModelPath = path
+	}
+	return m
+}
+
+// Concurrency is used to set the benchmark concurrency,match option --concurrency
+func (m *ModelDeployBuilder) Concurrency(concurrency int) *ModelDeployBuilder {
+	if concurrency > 0 {
+		m.concurrency = concurrency
+	}
+	return m
+}
+
+// Requests is used to set the benchmark requests,match option --requests
+func (m *ModelDeployBuilder) Requests(requests int) *ModelDeployBuilder {
+	if requests > 0 {
+		m.requests = requests
+	}
+	return m
+}
+
+// Duration is used to set the benchmark duration in seconds,match option --duration
+func (m *ModelDeployBuilder) Duration(duration int) *ModelDeployBuilder {
+	if duration > 0 {
+		m.duration = duration
+	}
+	return m
+}
+
+// ServeType is used to set the serving type of optimized model,match option --serve-type
+func (m *ModelDeployBuilder) ServeType(serveType string) *ModelDeployBuilder {
+	if serveType != "" {
+		m.serveType = serveType
+	}
+	return m
+}
+
+// ServingImage is used to set the image of serving job,match option --serving-image
+func (m *ModelDeployBuilder) ServingImage(image string) *ModelDeployBuilder {
+	if image != "" {
+		m.servingImage = image
+	}
+	return m
+}
+
+// ServingGPUs is used to set the GPU count of serving job,match option --serving-gpus
+func (m *ModelDeployBuilder) ServingGPUs(count int) *ModelDeployBuilder {
+	if count > 0 {
+		m.servingGPUs = count
+	}
+	return m
+}
+
+// ServingVersion is used to set the version of serving job,match option --serving-version
+func (m *ModelDeployBuilder) ServingVersion(version string) *ModelDeployBuilder {
+	if version != "" {
+		m.servingVersion = version
+	}
+	return m
+}
+
+// ModelRepository is used to set the triton model repository,match option --model-repository
+func (m *ModelDeployBuilder) ModelRepository(repository string) *ModelDeployBuilder {
+	if repository != "" {
+		m.modelRepository = repository
+	}
+	return m
+}
+
+// Timeout is used to set the max seconds to wait for each job,match option --timeout
+func (m *ModelDeployBuilder) Timeout(timeout int) *ModelDeployBuilder {
+	if timeout > 0 {
+		m.args.Timeout = timeout
+	}
+	return m
+}
+
+// Build is used to build the args of model deploy pipeline
+func (m *ModelDeployBuilder) Build() (*types.ModelDeployArgs, error) {
+	if m.args.Name == "" {
+		m.args.Name = m.optimizeBuilder.args.Name
+	}
+	if m.args.Name == "" {
+		return nil, fmt.Errorf("--name must be specified")
+	}
+	if m.args.Timeout <= 0 {
+		return nil, fmt.Errorf("--timeout must be greater than 0")
+	}
+	m.optimizeBuilder.args.Name = fmt.Sprintf("%s-optimize", m.args.Name)
+	job, err := m.optimizeBuilder.Build()
+	if err != nil {
+		return nil, err
+	}
+	optimizeArgs := job.Args().(*types.ModelOptimizeArgs)
+	m.args.OptimizeArgs = optimizeArgs
+
+	if m.args.Benchmark {
+		if m.args.OptimizedModelPath == "" {
+			return nil, fmt.Errorf("--optimized-model-path must be specified when --benchmark is enabled")
+		}
+		commonArgs := optimizeArgs.CommonModelArgs
+		commonArgs.Name = fmt.Sprintf("%s-benchmark", m.args.Name)
+		commonArgs.Type = types.ModelBenchmarkJob
+		commonArgs.Command = ""
+		m.args.BenchmarkArgs = &types.ModelBenchmarkArgs{
+			Concurrency:     m.concurrency,
+			Requests:        m.requests,
+			Duration:        m.duration,
+			ReportPath:      optimizeArgs.ExportPath,
+			CommonModelArgs: commonArgs,
+		}
+	} else if m.args.MinThroughput > 0 {
+		return nil, fmt.Errorf("--min-throughput requires --benchmark")
+	}
+
+	if m.serveType != "" {
+		m.args.ServeType = utils.TransferServingJobType(m.serveType)
+		if m.args.ServeType != types.TritonServingJob {
+			return nil, fmt.Errorf("the serve type %s is not supported, only support:[triton]", m.serveType)
+		}
+		gpus := m.servingGPUs
+		if gpus == 0 {
+			gpus = optimizeArgs.GPUCount
+		}
+		repository := m.modelRepository
+		if repository == "" {
+			repository = optimizeArgs.ExportPath
+		}
+		servingJob, err := serving.NewTritonServingJobBuilder().
+			Name(m.args.Name).
+			Namespace(m.args.Namespace).
+			Image(m.servingImage).
+			GPUCount(gpus).
+			Version(m.servingVersion).
+			Datas(optimizeArgs.DataSet).
+			ModelRepository(repository).
+			Build()
+		if err != nil {
+			return nil, fmt.Errorf("failed to build the serving job: %v", err)
+		}
+		m.args.TritonArgs = servingJob.Args().(*types.TritonServingArgs)
+	}
+	return m.args, nil
+}
@@ -0,0 +1,131 @@
+package serving
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/kubeflow/arena/pkg/apis/types"
+)
+
+// NewServingJobFromTrainingJob builds a serving job which serves the model produced by the succeeded training job,
+// the pvc mounts, image pull secrets and node selectors of training job are reused by the serving job
+func NewServingJobFromTrainingJob(args *types.ServingFromJobArgs, source *types.TrainingJobSource) (*Job, error) {
+	if source.Status != string(types.TrainingJobSucceeded) {
+		return nil, fmt.Errorf("the status of training job %s is %s, only the succeeded job can be served", source.Name, source.Status)
+	}
+	pvcName, err := selectModelPVC(args.ModelPVC, source)
+	if err != nil {
+		return nil, err
+	}
+	subPath := strings.TrimPrefix(path.Clean("/"+args.ModelSubpath), "/")
+	modelPath := path.Join(source.DataSet[pvcName], subPath)
+
+	name := args.Name
+	if name == "" {
+		name = source.Name
+	}
+	annotations := map[string]string{
+		types.ServingSourceJobAnnotation:     source.Name,
+		types.ServingSourceJobTypeAnnotation: string(source.Type),
+		types.ServingSourceJobUIDAnnotation:  source.UID,
+	}
+	switch args.Type {
+	case types.TritonServingJob:
+		return NewTritonServingJobBuilder().
+			Name(name).
+			Namespace(args.Namespace).
+			Version(args.Version).
+			Image(args.Image).
+			GPUCount(args.GPUCount).
+			Replicas(args.Replicas).
+			Datas(source.DataSet).
+			ImagePullSecrets(source.ImagePullSecrets).
+			NodeSelectors(source.NodeSelectors).
+			Annotations(annotations).
+			ModelRepository(modelPath).
+			Build()
+	case types.TFServingJob:
+		modelName := args.ModelName
+		if modelName == "" {
+			modelName = source.Name
+		}
+		return NewTFServingJobBuilder().
+			Name(name).
+			Namespace(args.Namespace).
+			Version(args.Version).
+			Image(args.Image).
+			GPUCount(args.GPUCount).
+			Replicas(args.Replicas).
+			Datas(source.DataSet).
+			ImagePullSecrets(source.ImagePullSecrets).
+			NodeSelectors(source.NodeSelectors).
+			Annotations(annotations).
+			ModelName(modelName).
+			ModelPath(modelPath).
+			Build()
+	case types.KServeJob:
+		modelFormat, err := parseModelFormat(args.ModelFormat)
+		if err != nil {
+			return nil, err
+		}
+		if modelFormat == nil && args.Image == "" {
+			return nil, fmt.Errorf("model format and image can not be empty at the same time")
+		}
+		// kserve downloads the model by the storage initializer, so the pvc is referred by the storage uri
+		return NewKServeJobBuilder().
+			Name(name).
+			Namespace(args.Namespace).
+			Version(args.Version).
+			Image(args.Image).
+			GPUCount(args.GPUCount).
+			Replicas(args.Replicas).
+			ImagePullSecrets(source.ImagePullSecrets).
+			NodeSelectors(source.NodeSelectors).
+			Annotations(annotations).
+			ModelFormat(modelFormat).
+			StorageUri(types.PVCStorageScheme + path.Join(pvcName, subPath)).
+			Build()
+	}
+	return nil, fmt.Errorf("the serving type %s is not supported, only support [triton|tf|kserve]", args.Type)
+}
+
+// selectModelPVC returns the pvc which stores the model, it must be one of the pvcs mounted by the training job
+func selectModelPVC(pvcName string, source *types.TrainingJobSource) (string, error) {
+	if pvcName != "" {
+		if _, ok := source.DataSet[pvcName]; !ok {
+			return "", fmt.Errorf("the pvc %s is not mounted by training job %s", pvcName, source.Name)
+		}
+		return pvcName, nil
+	}
+	switch len(source.DataSet) {
+	case 0:
+		return "", fmt.Errorf("training job %s does not mount any pvc by --data, the model can not be found", source.Name)
+	case 1:
+		for name := range source.DataSet {
+			return name, nil
+		}
+	}
+	pvcs := []string{}
+	for name := range source.DataSet {
+		pvcs = append(pvcs, name)
+	}
+	sort.Strings(pvcs)
+	return "", fmt.Errorf("training job %s mounts multiple pvcs [%s], please specify the one which stores the model", source.Name, strings.Join(pvcs, ","))
+}
+
+// parseModelFormat parses the model format like name or name:version
+func parseModelFormat(value string) (*types.ModelFormat, error) {
+	if value == "" {
+		return nil, nil
+	}
+	items := strings.Split(value, ":")
+	switch len(items) {
+	case 1:
+		return &types.ModelFormat{Name: items[0]}, nil
+	case 2:
+		return &types.ModelFormat{Name: items[0], Version: &items[1]}, nil
+	}
+	return nil, fmt.Errorf("model format is invalid: %s", value)
+}
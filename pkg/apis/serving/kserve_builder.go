@@ -162,6 +162,14 @@ func (b *KServeJobBuilder) Tolerations(tolerations []string) *KServeJobBuilder {
 	return b
 }
 
+// ImagePullSecrets is used to set image pull secrests,match option --image-pull-secret
+func (b *KServeJobBuilder) ImagePullSecrets(secrets []string) *KServeJobBuilder {
+	if secrets != nil {
+		b.argValues["image-pull-secret"] = &secrets
+	}
+	return b
+}
+
 // NodeSelectors is used to set node selectors for scheduling job,match option --selector
 func (b *KServeJobBuilder) NodeSelectors(selectors map[string]string) *KServeJobBuilder {
 	if selectors != nil && len(selectors) != 0 {
@@ -0,0 +1,328 @@
+package serving
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kubeflow/arena/pkg/apis/types"
+	"github.com/kubeflow/arena/pkg/argsbuilder"
+)
+
+type ONNXRuntimeServingJobBuilder struct {
+	args      *types.ONNXRuntimeServingArgs
+	argValues map[string]interface{}
+	argsbuilder.ArgsBuilder
+}
+
+func NewONNXRuntimeServingJobBuilder() *ONNXRuntimeServingJobBuilder {
+	args := &types.ONNXRuntimeServingArgs{
+		HttpPort: 8001,
+		GrpcPort: 50051,
+		CommonServingArgs: types.CommonServingArgs{
+			Image:           argsbuilder.DefaultONNXRuntimeServingImage,
+			ImagePullPolicy: "IfNotPresent",
+			Replicas:        1,
+			Namespace:       "default",
+			Shell:           "sh",
+		},
+	}
+	return &ONNXRuntimeServingJobBuilder{
+		args:        args,
+		argValues:   map[string]interface{}{},
+		ArgsBuilder: argsbuilder.NewONNXRuntimeServingArgsBuilder(args),
+	}
+}
+
+// Name is used to set job name,match option --name
+func (b *ONNXRuntimeServingJobBuilder) Name(name string) *ONNXRuntimeServingJobBuilder {
+	if name != "" {
+		b.args.Name = name
+	}
+	return b
+}
+
+// Namespace is used to set job namespace,match option --namespace
+func (b *ONNXRuntimeServingJobBuilder) Namespace(namespace string) *ONNXRuntimeServingJobBuilder {
+	if namespace != "" {
+		b.args.Namespace = namespace
+	}
+	return b
+}
+
+// Shell is used to set bash or sh
+func (b *ONNXRuntimeServingJobBuilder) Shell(shell string) *ONNXRuntimeServingJobBuilder {
+	if shell != "" {
+		b.args.Shell = shell
+	}
+	return b
+}
+
+// Command is used to set job command
+func (b *ONNXRuntimeServingJobBuilder) Command(args []string) *ONNXRuntimeServingJobBuilder {
+	b.args.Command = strings.Join(args, " ")
+	return b
+}
+
+// GPUCount is used to set count of gpu for the job,match the option --gpus
+func (b *ONNXRuntimeServingJobBuilder) GPUCount(count int) *ONNXRuntimeServingJobBuilder {
+	if count > 0 {
+		b.args.GPUCount = count
+	}
+	return b
+}
+
+// GPUMemory is used to set gpu memory for the job,match the option --gpumemory
+func (b *ONNXRuntimeServingJobBuilder) GPUMemory(memory int) *ONNXRuntimeServingJobBuilder {
+	if memory > 0 {
+		b.args.GPUMemory = memory
+	}
+	return b
+}
+
+// GPUCore is used to set gpu core for the job,match the option --gpucore
+func (b *ONNXRuntimeServingJobBuilder) GPUCore(core int) *ONNXRuntimeServingJobBuilder {
+	if core > 0 {
+		b.args.GPUCore = core
+	}
+	return b
+}
+
+// Image is used to set job image,match the option --image
+func (b *ONNXRuntimeServingJobBuilder) Image(image string) *ONNXRuntimeServingJobBuilder {
+	if image != "" {
+		b.args.Image = image
+	}
+	return b
+}
+
+// ImagePullPolicy is used to set image pull policy,match the option --image-pull-policy
+func (b *ONNXRuntimeServingJobBuilder) ImagePullPolicy(policy string) *ONNXRuntimeServingJobBuilder {
+	if policy != "" {
+		b.args.ImagePullPolicy = policy
+	}
+	return b
+}
+
+// CPU assign cpu limits,match the option --cpu
+func (b *ONNXRuntimeServingJobBuilder) CPU(cpu string) *ONNXRuntimeServingJobBuilder {
+	if cpu != "" {
+		b.args.Cpu = cpu
+	}
+	return b
+}
+
+// Memory assign memory limits,match option --memory
+func (b *ONNXRuntimeServingJobBuilder) Memory(memory string) *ONNXRuntimeServingJobBuilder {
+	if memory != "" {
+		b.args.Memory = memory
+	}
+	return b
+}
+
+// Envs is used to set env of job containers,match option --env
+func (b *ONNXRuntimeServingJobBuilder) Envs(envs map[string]string) *ONNXRuntimeServingJobBuilder {
+	if envs != nil && len(envs) != 0 {
+		envSlice := []string{}
+		for key, value := range envs {
+			envSlice = append(envSlice, fmt.Sprintf("%v=%v", key, value))
+		}
+		b.argValues["env"] = &envSlice
+	}
+	return b
+}
+
+// Replicas is used to set serving job replicas,match the option --replicas
+func (b *ONNXRuntimeServingJobBuilder) Replicas(count int) *ONNXRuntimeServingJobBuilder {
+	if count > 0 {
+		b.args.Replicas = count
+	}
+	return b
+}
+
+// EnableIstio is used to enable istio,match the option --enable-istio
+func (b *ONNXRuntimeServingJobBuilder) EnableIstio() *ONNXRuntimeServingJobBuilder {
+	b.args.EnableIstio = true
+	return b
+}
+
+// ExposeService is used to expose service,match the option --expose-service
+func (b *ONNXRuntimeServingJobBuilder) ExposeService() *ONNXRuntimeServingJobBuilder {
+	b.args.ExposeService = true
+	return b
+}
+
+// Version is used to set serving job version,match the option --version
+func (b *ONNXRuntimeServingJobBuilder) Version(version string) *ONNXRuntimeServingJobBuilder {
+	if version != "" {
+		b.args.Version = version
+	}
+	return b
+}
+
+// Tolerations is used to set tolerations for tolerate nodes,match option --toleration
+func (b *ONNXRuntimeServingJobBuilder) Tolerations(tolerations []string) *ONNXRuntimeServingJobBuilder {
+	b.argValues["toleration"] = &tolerations
+	return b
+}
+
+// NodeSelectors is used to set node selectors for scheduling job,match option --selector
+func (b *ONNXRuntimeServingJobBuilder) NodeSelectors(selectors map[string]string) *ONNXRuntimeServingJobBuilder {
+	if selectors != nil && len(selectors) != 0 {
+		selectorsSlice := []string{}
+		for key, value := range selectors {
+			selectorsSlice = append(selectorsSlice, fmt.Sprintf("%v=%v", key, value))
+		}
+		b.argValues["selector"] = &selectorsSlice
+	}
+	return b
+}
+
+// Annotations is used to add annotations for job pods,match option --annotation
+func (b *ONNXRuntimeServingJobBuilder) Annotations(annotations map[string]string) *ONNXRuntimeServingJobBuilder {
+	if annotations != nil && len(annotations) != 0 {
+		s := []string{}
+		for key, value := range annotations {
+			s = append(s, fmt.Sprintf("%v=%v", key, value))
+		}
+		b.argValues["annotation"] = &s
+	}
+	return b
+}
+
+// Labels is used to add labels for job
+func (b *ONNXRuntimeServingJobBuilder) Labels(labels map[string]string) *ONNXRuntimeServingJobBuilder {
+	if labels != nil && len(labels) != 0 {
+		s := []string{}
+		for key, value := range labels {
+			s = append(s, fmt.Sprintf("%v=%v", key, value))
+		}
+		b.argValues["label"] = &s
+	}
+	return b
+}
+
+// Datas is used to mount k8s pvc to job pods,match option --data
+func (b *ONNXRuntimeServingJobBuilder) Datas(volumes map[string]string) *ONNXRuntimeServingJobBuilder {
+	if volumes != nil && len(volumes) != 0 {
+		s := []string{}
+		for key, value := range volumes {
+			s = append(s, fmt.Sprintf("%v:%v", key, value))
+		}
+		b.argValues["data"] = &s
+	}
+	return b
+}
+
+// DataSubPathExprs is used to mount k8s pvc subpath to job pods,match option data-subpath-expr
+func (b *ONNXRuntimeServingJobBuilder) DataSubPathExprs(exprs map[string]string) *ONNXRuntimeServingJobBuilder {
+	if exprs != nil && len(exprs) != 0 {
+		s := []string{}
+		for key, value := range exprs {
+			s = append(s, fmt.Sprintf("%v:%v", key, value))
+		}
+		b.argValues["data-subpath-expr"] = &s
+	}
+	return b
+}
+
+// TempDirs specify the deployment empty dir
+func (b *ONNXRuntimeServingJobBuilder) TempDirs(volumes map[string]string) *ONNXRuntimeServingJobBuilder {
+	if volumes != nil && len(volumes) != 0 {
+		s := []string{}
+		for key, value := range volumes {
+			s = append(s, fmt.Sprintf("%v:%v", key, value))
+		}
+		b.argValues["temp-dir"] = &s
+	}
+	return b
+}
+
+// EmptyDirSubPathExprs specify the datasource subpath to mount to the pod by expression
+func (b *ONNXRuntimeServingJobBuilder) EmptyDirSubPathExprs(exprs map[string]string) *ONNXRuntimeServingJobBuilder {
+	if exprs != nil && len(exprs) != 0 {
+		s := []string{}
+		for key, value := range exprs {
+			s = append(s, fmt.Sprintf("%v:%v", key, value))
+		}
+		b.argValues["temp-dir-subpath-expr"] = &s
+	}
+	return b
+}
+
+// DataDirs is used to mount host files to job containers,match option --data-dir
+func (b *ONNXRuntimeServingJobBuilder) DataDirs(volumes map[string]string) *ONNXRuntimeServingJobBuilder {
+	if volumes != nil && len(volumes) != 0 {
+		s := []string{}
+		for key, value := range volumes {
+			s = append(s, fmt.Sprintf("%v:%v", key, value))
+		}
+		b.argValues["data-dir"] = &s
+	}
+	return b
+}
+
+// HttpPort is used to set http port,match the option --http-port
+func (b *ONNXRuntimeServingJobBuilder) HttpPort(port int) *ONNXRuntimeServingJobBuilder {
+	if port > 0 {
+		b.args.HttpPort = port
+	}
+	return b
+}
+
+// GrpcPort is used to set grpc port,match the option --grpc-port
+func (b *ONNXRuntimeServingJobBuilder) GrpcPort(port int) *ONNXRuntimeServingJobBuilder {
+	if port > 0 {
+		b.args.GrpcPort = port
+	}
+	return b
+}
+
+// HttpThreads is used to set the number of http threads,match the option --http-threads
+func (b *ONNXRuntimeServingJobBuilder) HttpThreads(threads int) *ONNXRuntimeServingJobBuilder {
+	if threads > 0 {
+		b.args.HttpThreads = threads
+	}
+	return b
+}
+
+// ModelPath is used to set the onnx model file,match the option --model-path
+func (b *ONNXRuntimeServingJobBuilder) ModelPath(modelPath string) *ONNXRuntimeServingJobBuilder {
+	if modelPath != "" {
+		b.args.ModelPath = modelPath
+	}
+	return b
+}
+
+// ExtendCommand is used to set the command attached to the server's command,match the option --extend-command
+func (b *ONNXRuntimeServingJobBuilder) ExtendCommand(command string) *ONNXRuntimeServingJobBuilder {
+	if command != "" {
+		b.args.ExtendCommand = command
+	}
+	return b
+}
+
+// ConfigFiles is used to mapping config files form local to job containers,match option --config-file
+func (b *ONNXRuntimeServingJobBuilder) ConfigFiles(files map[string]string) *ONNXRuntimeServingJobBuilder {
+	if files != nil && len(files) != 0 {
+		filesSlice := []string{}
+		for localPath, containerPath := range files {
+			filesSlice = append(filesSlice, fmt.Sprintf("%v:%v", localPath, containerPath))
+		}
+		b.argValues["config-file"] = &filesSlice
+	}
+	return b
+}
+
+// Build is used to build the job
+func (b *ONNXRuntimeServingJobBuilder) Build() (*Job, error) {
+	for key, value := range b.argValues {
+		b.AddArgValue(key, value)
+	}
+	if err := b.PreBuild(); err != nil {
+		return nil, err
+	}
+	if err := b.ArgsBuilder.Build(); err != nil {
+		return nil, err
+	}
+	return NewJob(b.args.Name, types.ONNXRuntimeServingJob, b.args), nil
+}
@@ -181,6 +181,14 @@ func (b *TFServingJobBuilder) Tolerations(tolerations []string) *TFServingJobBui
 	return b
 }
 
+// ImagePullSecrets is used to set image pull secrests,match option --image-pull-secret
+func (b *TFServingJobBuilder) ImagePullSecrets(secrets []string) *TFServingJobBuilder {
+	if secrets != nil {
+		b.argValues["image-pull-secret"] = &secrets
+	}
+	return b
+}
+
 // NodeSelectors is used to set node selectors for scheduling job,match option --selector
 func (b *TFServingJobBuilder) NodeSelectors(selectors map[string]string) *TFServingJobBuilder {
 	if selectors != nil && len(selectors) != 0 {
@@ -0,0 +1,346 @@
+package serving
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kubeflow/arena/pkg/apis/types"
+	"github.com/kubeflow/arena/pkg/argsbuilder"
+)
+
+type TorchServeJobBuilder struct {
+	args      *types.TorchServeArgs
+	argValues map[string]interface{}
+	argsbuilder.ArgsBuilder
+}
+
+func NewTorchServeJobBuilder() *TorchServeJobBuilder {
+	args := &types.TorchServeArgs{
+		InferencePort:  8080,
+		ManagementPort: 8081,
+		MetricsPort:    8082,
+		GrpcPort:       7070,
+		CommonServingArgs: types.CommonServingArgs{
+			Image:           argsbuilder.DefaultTorchServeImage,
+			ImagePullPolicy: "IfNotPresent",
+			Replicas:        1,
+			Namespace:       "default",
+			Shell:           "sh",
+		},
+	}
+	return &TorchServeJobBuilder{
+		args:        args,
+		argValues:   map[string]interface{}{},
+		ArgsBuilder: argsbuilder.NewTorchServeArgsBuilder(args),
+	}
+}
+
+// Name is used to set job name,match option --name
+func (b *TorchServeJobBuilder) Name(name string) *TorchServeJobBuilder {
+	if name != "" {
+		b.args.Name = name
+	}
+	return b
+}
+
+// Namespace is used to set job namespace,match option --namespace
+func (b *TorchServeJobBuilder) Namespace(namespace string) *TorchServeJobBuilder {
+	if namespace != "" {
+		b.args.Namespace = namespace
+	}
+	return b
+}
+
+// Shell is used to set bash or sh
+func (b *TorchServeJobBuilder) Shell(shell string) *TorchServeJobBuilder {
+	if shell != "" {
+		b.args.Shell = shell
+	}
+	return b
+}
+
+// Command is used to set job command
+func (b *TorchServeJobBuilder) Command(args []string) *TorchServeJobBuilder {
+	b.args.Command = strings.Join(args, " ")
+	return b
+}
+
+// GPUCount is used to set count of gpu for the job,match the option --gpus
+func (b *TorchServeJobBuilder) GPUCount(count int) *TorchServeJobBuilder {
+	if count > 0 {
+		b.args.GPUCount = count
+	}
+	return b
+}
+
+// GPUMemory is used to set gpu memory for the job,match the option --gpumemory
+func (b *TorchServeJobBuilder) GPUMemory(memory int) *TorchServeJobBuilder {
+	if memory > 0 {
+		b.args.GPUMemory = memory
+	}
+	return b
+}
+
+// GPUCore is used to set gpu core for the job,match the option --gpucore
+func (b *TorchServeJobBuilder) GPUCore(core int) *TorchServeJobBuilder {
+	if core > 0 {
+		b.args.GPUCore = core
+	}
+	return b
+}
+
+// Image is used to set job image,match the option --image
+func (b *TorchServeJobBuilder) Image(image string) *TorchServeJobBuilder {
+	if image != "" {
+		b.args.Image = image
+	}
+	return b
+}
+
+// ImagePullPolicy is used to set image pull policy,match the option --image-pull-policy
+func (b *TorchServeJobBuilder) ImagePullPolicy(policy string) *TorchServeJobBuilder {
+	if policy != "" {
+		b.args.ImagePullPolicy = policy
+	}
+	return b
+}
+
+// CPU assign cpu limits,match the option --cpu
+func (b *TorchServeJobBuilder) CPU(cpu string) *TorchServeJobBuilder {
+	if cpu != "" {
+		b.args.Cpu = cpu
+	}
+	return b
+}
+
+// Memory assign memory limits,match option --memory
+func (b *TorchServeJobBuilder) Memory(memory string) *TorchServeJobBuilder {
+	if memory != "" {
+		b.args.Memory = memory
+	}
+	return b
+}
+
+// Envs is used to set env of job containers,match option --env
+func (b *TorchServeJobBuilder) Envs(envs map[string]string) *TorchServeJobBuilder {
+	if envs != nil && len(envs) != 0 {
+		envSlice := []string{}
+		for key, value := range envs {
+			envSlice = append(envSlice, fmt.Sprintf("%v=%v", key, value))
+		}
+		b.argValues["env"] = &envSlice
+	}
+	return b
+}
+
+// Replicas is used to set serving job replicas,match the option --replicas
+func (b *TorchServeJobBuilder) Replicas(count int) *TorchServeJobBuilder {
+	if count > 0 {
+		b.args.Replicas = count
+	}
+	return b
+}
+
+// EnableIstio is used to enable istio,match the option --enable-istio
+func (b *TorchServeJobBuilder) EnableIstio() *TorchServeJobBuilder {
+	b.args.EnableIstio = true
+	return b
+}
+
+// ExposeService is used to expose service,match the option --expose-service
+func (b *TorchServeJobBuilder) ExposeService() *TorchServeJobBuilder {
+	b.args.ExposeService = true
+	return b
+}
+
+// Version is used to set serving job version,match the option --version
+func (b *TorchServeJobBuilder) Version(version string) *TorchServeJobBuilder {
+	if version != "" {
+		b.args.Version = version
+	}
+	return b
+}
+
+// Tolerations is used to set tolerations for tolerate nodes,match option --toleration
+func (b *TorchServeJobBuilder) Tolerations(tolerations []string) *TorchServeJobBuilder {
+	b.argValues["toleration"] = &tolerations
+	return b
+}
+
+// NodeSelectors is used to set node selectors for scheduling job,match option --selector
+func (b *TorchServeJobBuilder) NodeSelectors(selectors map[string]string) *TorchServeJobBuilder {
+	if selectors != nil && len(selectors) != 0 {
+		selectorsSlice := []string{}
+		for key, value := range selectors {
+			selectorsSlice = append(selectorsSlice, fmt.Sprintf("%v=%v", key, value))
+		}
+		b.argValues["selector"] = &selectorsSlice
+	}
+	return b
+}
+
+// Annotations is used to add annotations for job pods,match option --annotation
+func (b *TorchServeJobBuilder) Annotations(annotations map[string]string) *TorchServeJobBuilder {
+	if annotations != nil && len(annotations) != 0 {
+		s := []string{}
+		for key, value := range annotations {
+			s = append(s, fmt.Sprintf("%v=%v", key, value))
+		}
+		b.argValues["annotation"] = &s
+	}
+	return b
+}
+
+// Labels is used to add labels for job
+func (b *TorchServeJobBuilder) Labels(labels map[string]string) *TorchServeJobBuilder {
+	if labels != nil && len(labels) != 0 {
+		s := []string{}
+		for key, value := range labels {
+			s = append(s, fmt.Sprintf("%v=%v", key, value))
+		}
+		b.argValues["label"] = &s
+	}
+	return b
+}
+
+// Datas is used to mount k8s pvc to job pods,match option --data
+func (b *TorchServeJobBuilder) Datas(volumes map[string]string) *TorchServeJobBuilder {
+	if volumes != nil && len(volumes) != 0 {
+		s := []string{}
+		for key, value := range volumes {
+			s = append(s, fmt.Sprintf("%v:%v", key, value))
+		}
+		b.argValues["data"] = &s
+	}
+	return b
+}
+
+// DataSubPathExprs is used to mount k8s pvc subpath to job pods,match option data-subpath-expr
+func (b *TorchServeJobBuilder) DataSubPathExprs(exprs map[string]string) *TorchServeJobBuilder {
+	if exprs != nil && len(exprs) != 0 {
+		s := []string{}
+		for key, value := range exprs {
+			s = append(s, fmt.Sprintf("%v:%v", key, value))
+		}
+		b.argValues["data-subpath-expr"] = &s
+	}
+	return b
+}
+
+// TempDirs specify the deployment empty dir
+func (b *TorchServeJobBuilder) TempDirs(volumes map[string]string) *TorchServeJobBuilder {
+	if volumes != nil && len(volumes) != 0 {
+		s := []string{}
+		for key, value := range volumes {
+			s = append(s, fmt.Sprintf("%v:%v", key, value))
+		}
+		b.argValues["temp-dir"] = &s
+	}
+	return b
+}
+
+// EmptyDirSubPathExprs specify the datasource subpath to mount to the pod by expression
+func (b *TorchServeJobBuilder) EmptyDirSubPathExprs(exprs map[string]string) *TorchServeJobBuilder {
+	if exprs != nil && len(exprs) != 0 {
+		s := []string{}
+		for key, value := range exprs {
+			s = append(s, fmt.Sprintf("%v:%v", key, value))
+		}
+		b.argValues["temp-dir-subpath-expr"] = &s
+	}
+	return b
+}
+
+// DataDirs is used to mount host files to job containers,match option --data-dir
+func (b *TorchServeJobBuilder) DataDirs(volumes map[string]string) *TorchServeJobBuilder {
+	if volumes != nil && len(volumes) != 0 {
+		s := []string{}
+		for key, value := range volumes {
+			s = append(s, fmt.Sprintf("%v:%v", key, value))
+		}
+		b.argValues["data-dir"] = &s
+	}
+	return b
+}
+
+// InferencePort is used to set inference api port,match the option --inference-port
+func (b *TorchServeJobBuilder) InferencePort(port int) *TorchServeJobBuilder {
+	if port > 0 {
+		b.args.InferencePort = port
+	}
+	return b
+}
+
+// ManagementPort is used to set management api port,match the option --management-port
+func (b *TorchServeJobBuilder) ManagementPort(port int) *TorchServeJobBuilder {
+	if port > 0 {
+		b.args.ManagementPort = port
+	}
+	return b
+}
+
+// MetricsPort is used to set metrics api port,match the option --metrics-port
+func (b *TorchServeJobBuilder) MetricsPort(port int) *TorchServeJobBuilder {
+	if port > 0 {
+		b.args.MetricsPort = port
+	}
+	return b
+}
+
+// GrpcPort is used to set grpc inference api port,match the option --grpc-port
+func (b *TorchServeJobBuilder) GrpcPort(port int) *TorchServeJobBuilder {
+	if port > 0 {
+		b.args.GrpcPort = port
+	}
+	return b
+}
+
+// ModelStore is used to set model store,match the option --model-store
+func (b *TorchServeJobBuilder) ModelStore(modelStore string) *TorchServeJobBuilder {
+	if modelStore != "" {
+		b.args.ModelStore = modelStore
+	}
+	return b
+}
+
+// Models is used to set models to load,match the option --model
+func (b *TorchServeJobBuilder) Models(models []string) *TorchServeJobBuilder {
+	if len(models) != 0 {
+		b.argValues["model"] = &models
+	}
+	return b
+}
+
+// ExtendCommand is used to set the command attached to the server's command,match the option --extend-command
+func (b *TorchServeJobBuilder) ExtendCommand(command string) *TorchServeJobBuilder {
+	if command != "" {
+		b.args.ExtendCommand = command
+	}
+	return b
+}
+
+// ConfigFiles is used to mapping config files form local to job containers,match option --config-file
+func (b *TorchServeJobBuilder) ConfigFiles(files map[string]string) *TorchServeJobBuilder {
+	if files != nil && len(files) != 0 {
+		filesSlice := []string{}
+		for localPath, containerPath := range files {
+			filesSlice = append(filesSlice, fmt.Sprintf("%v:%v", localPath, containerPath))
+		}
+		b.argValues["config-file"] = &filesSlice
+	}
+	return b
+}
+
+// Build is used to build the job
+func (b *TorchServeJobBuilder) Build() (*Job, error) {
+	for key, value := range b.argValues {
+		b.AddArgValue(key, value)
+	}
+	if err := b.PreBuild(); err != nil {
+		return nil, err
+	}
+	if err := b.ArgsBuilder.Build(); err != nil {
+		return nil, err
+	}
+	return NewJob(b.args.Name, types.TorchServeServingJob, b.args), nil
+}
@@ -166,6 +166,14 @@ func (b *TritonServingJobBuilder) Tolerations(tolerations []string) *TritonServi
 	return b
 }
 
+// ImagePullSecrets is used to set image pull secrests,match option --image-pull-secret
+func (b *TritonServingJobBuilder) ImagePullSecrets(secrets []string) *TritonServingJobBuilder {
+	if secrets != nil {
+		b.argValues["image-pull-secret"] = &secrets
+	}
+	return b
+}
+
 // NodeSelectors is used to set node selectors for scheduling job,match option --selector
 func (b *TritonServingJobBuilder) NodeSelectors(selectors map[string]string) *TritonServingJobBuilder {
 	if selectors != nil && len(selectors) != 0 {
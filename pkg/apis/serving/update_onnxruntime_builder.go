@@ -0,0 +1,142 @@
+package serving
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kubeflow/arena/pkg/apis/types"
+	"github.com/kubeflow/arena/pkg/argsbuilder"
+)
+
+type UpdateONNXRuntimeServingJobBuilder struct {
+	args      *types.UpdateONNXRuntimeServingArgs
+	argValues map[string]interface{}
+	argsbuilder.ArgsBuilder
+}
+
+func NewUpdateONNXRuntimeServingJobBuilder() *UpdateONNXRuntimeServingJobBuilder {
+	args := &types.UpdateONNXRuntimeServingArgs{
+		CommonUpdateServingArgs: types.CommonUpdateServingArgs{
+			Image:     argsbuilder.DefaultONNXRuntimeServingImage,
+			Replicas:  1,
+			Namespace: "default",
+		},
+	}
+	return &UpdateONNXRuntimeServingJobBuilder{
+		args:        args,
+		argValues:   map[string]interface{}{},
+		ArgsBuilder: argsbuilder.NewUpdateONNXRuntimeServingArgsBuilder(args),
+	}
+}
+
+// Name is used to set job name,match option --name
+func (b *UpdateONNXRuntimeServingJobBuilder) Name(name string) *UpdateONNXRuntimeServingJobBuilder {
+	if name != "" {
+		b.args.Name = name
+	}
+	return b
+}
+
+// Namespace is used to set job namespace,match option --namespace
+func (b *UpdateONNXRuntimeServingJobBuilder) Namespace(namespace string) *UpdateONNXRuntimeServingJobBuilder {
+	if namespace != "" {
+		b.args.Namespace = namespace
+	}
+	return b
+}
+
+// Shell is used to set bash or sh
+func (b *UpdateONNXRuntimeServingJobBuilder) Shell(shell string) *UpdateONNXRuntimeServingJobBuilder {
+	if shell != "" {
+		b.args.Shell = shell
+	}
+	return b
+}
+
+// Command is used to set job command
+func (b *UpdateONNXRuntimeServingJobBuilder) Command(args []string) *UpdateONNXRuntimeServingJobBuilder {
+	b.args.Command = strings.Join(args, " ")
+	return b
+}
+
+// Image is used to set job image,match the option --image
+func (b *UpdateONNXRuntimeServingJobBuilder) Image(image string) *UpdateONNXRuntimeServingJobBuilder {
+	if image != "" {
+		b.args.Image = image
+	}
+	return b
+}
+
+// Envs is used to set env of job containers,match option --env
+func (b *UpdateONNXRuntimeServingJobBuilder) Envs(envs map[string]string) *UpdateONNXRuntimeServingJobBuilder {
+	if envs != nil && len(envs) != 0 {
+		envSlice := []string{}
+		for key, value := range envs {
+			envSlice = append(envSlice, fmt.Sprintf("%v=%v", key, value))
+		}
+		b.argValues["env"] = &envSlice
+	}
+	return b
+}
+
+// Annotations is used to add annotations for job pods,match option --annotation
+func (b *UpdateONNXRuntimeServingJobBuilder) Annotations(annotations map[string]string) *UpdateONNXRuntimeServingJobBuilder {
+	if annotations != nil && len(annotations) != 0 {
+		s := []string{}
+		for key, value := range annotations {
+			s = append(s, fmt.Sprintf("%v=%v", key, value))
+		}
+		b.argValues["annotation"] = &s
+	}
+	return b
+}
+
+// Labels is used to add labels for job
+func (b *UpdateONNXRuntimeServingJobBuilder) Labels(labels map[string]string) *UpdateONNXRuntimeServingJobBuilder {
+	if labels != nil && len(labels) != 0 {
+		s := []string{}
+		for key, value := range labels {
+			s = append(s, fmt.Sprintf("%v=%v", key, value))
+		}
+		b.argValues["label"] = &s
+	}
+	return b
+}
+
+// Replicas is used to set serving job replicas,match the option --replicas
+func (b *UpdateONNXRuntimeServingJobBuilder) Replicas(count int) *UpdateONNXRuntimeServingJobBuilder {
+	if count > 0 {
+		b.args.Replicas = count
+	}
+	return b
+}
+
+// Version is used to set serving job version,match the option --version
+func (b *UpdateONNXRuntimeServingJobBuilder) Version(version string) *UpdateONNXRuntimeServingJobBuilder {
+	if version != "" {
+		b.args.Version = version
+	}
+	return b
+}
+
+// ModelPath is used to set the onnx model file,match the option --model-path
+func (b *UpdateONNXRuntimeServingJobBuilder) ModelPath(modelPath string) *UpdateONNXRuntimeServingJobBuilder {
+	if modelPath != "" {
+		b.args.ModelPath = modelPath
+	}
+	return b
+}
+
+// Build is used to build the job
+func (b *UpdateONNXRuntimeServingJobBuilder) Build() (*Job, error) {
+	for key, value := range b.argValues {
+		b.AddArgValue(key, value)
+	}
+	if err := b.PreBuild(); err != nil {
+		return nil, err
+	}
+	if err := b.ArgsBuilder.Build(); err != nil {
+		return nil, err
+	}
+	return NewJob(b.args.Name, types.ONNXRuntimeServingJob, b.args), nil
+}
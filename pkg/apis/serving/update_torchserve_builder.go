@@ -0,0 +1,150 @@
+package serving
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kubeflow/arena/pkg/apis/types"
+	"github.com/kubeflow/arena/pkg/argsbuilder"
+)
+
+type UpdateTorchServeJobBuilder struct {
+	args      *types.UpdateTorchServeArgs
+	argValues map[string]interface{}
+	argsbuilder.ArgsBuilder
+}
+
+func NewUpdateTorchServeJobBuilder() *UpdateTorchServeJobBuilder {
+	args := &types.UpdateTorchServeArgs{
+		CommonUpdateServingArgs: types.CommonUpdateServingArgs{
+			Image:     argsbuilder.DefaultTorchServeImage,
+			Replicas:  1,
+			Namespace: "default",
+		},
+	}
+	return &UpdateTorchServeJobBuilder{
+		args:        args,
+		argValues:   map[string]interface{}{},
+		ArgsBuilder: argsbuilder.NewUpdateTorchServeArgsBuilder(args),
+	}
+}
+
+// Name is used to set job name,match option --name
+func (b *UpdateTorchServeJobBuilder) Name(name string) *UpdateTorchServeJobBuilder {
+	if name != "" {
+		b.args.Name = name
+	}
+	return b
+}
+
+// Namespace is used to set job namespace,match option --namespace
+func (b *UpdateTorchServeJobBuilder) Namespace(namespace string) *UpdateTorchServeJobBuilder {
+	if namespace != "" {
+		b.args.Namespace = namespace
+	}
+	return b
+}
+
+// Shell is used to set bash or sh
+func (b *UpdateTorchServeJobBuilder) Shell(shell string) *UpdateTorchServeJobBuilder {
+	if shell != "" {
+		b.args.Shell = shell
+	}
+	return b
+}
+
+// Command is used to set job command
+func (b *UpdateTorchServeJobBuilder) Command(args []string) *UpdateTorchServeJobBuilder {
+	b.args.Command = strings.Join(args, " ")
+	return b
+}
+
+// Image is used to set job image,match the option --image
+func (b *UpdateTorchServeJobBuilder) Image(image string) *UpdateTorchServeJobBuilder {
+	if image != "" {
+		b.args.Image = image
+	}
+	return b
+}
+
+// Envs is used to set env of job containers,match option --env
+func (b *UpdateTorchServeJobBuilder) Envs(envs map[string]string) *UpdateTorchServeJobBuilder {
+	if envs != nil && len(envs) != 0 {
+		envSlice := []string{}
+		for key, value := range envs {
+			envSlice = append(envSlice, fmt.Sprintf("%v=%v", key, value))
+		}
+		b.argValues["env"] = &envSlice
+	}
+	return b
+}
+
+// Annotations is used to add annotations for job pods,match option --annotation
+func (b *UpdateTorchServeJobBuilder) Annotations(annotations map[string]string) *UpdateTorchServeJobBuilder {
+	if annotations != nil && len(annotations) != 0 {
+		s := []string{}
+		for key, value := range annotations {
+			s = append(s, fmt.Sprintf("%v=%v", key, value))
+		}
+		b.argValues["annotation"] = &s
+	}
+	return b
+}
+
+// Labels is used to add labels for job
+func (b *UpdateTorchServeJobBuilder) Labels(labels map[string]string) *UpdateTorchServeJobBuilder {
+	if labels != nil && len(labels) != 0 {
+		s := []string{}
+		for key, value := range labels {
+			s = append(s, fmt.Sprintf("%v=%v", key, value))
+		}
+		b.argValues["label"] = &s
+	}
+	return b
+}
+
+// Replicas is used to set serving job replicas,match the option --replicas
+func (b *UpdateTorchServeJobBuilder) Replicas(count int) *UpdateTorchServeJobBuilder {
+	if count > 0 {
+		b.args.Replicas = count
+	}
+	return b
+}
+
+// Version is used to set serving job version,match the option --version
+func (b *UpdateTorchServeJobBuilder) Version(version string) *UpdateTorchServeJobBuilder {
+	if version != "" {
+		b.args.Version = version
+	}
+	return b
+}
+
+// ModelStore is used to set model store,match the option --model-store
+func (b *UpdateTorchServeJobBuilder) ModelStore(modelStore string) *UpdateTorchServeJobBuilder {
+	if modelStore != "" {
+		b.args.ModelStore = modelStore
+	}
+	return b
+}
+
+// Models is used to set models to load,match the option --model
+func (b *UpdateTorchServeJobBuilder) Models(models []string) *UpdateTorchServeJobBuilder {
+	if len(models) != 0 {
+		b.args.Models = models
+	}
+	return b
+}
+
+// Build is used to build the job
+func (b *UpdateTorchServeJobBuilder) Build() (*Job, error) {
+	for key, value := range b.argValues {
+		b.AddArgValue(key, value)
+	}
+	if err := b.PreBuild(); err != nil {
+		return nil, err
+	}
+	if err := b.ArgsBuilder.Build(); err != nil {
+		return nil, err
+	}
+	return NewJob(b.args.Name, types.TorchServeServingJob, b.args), nil
+}
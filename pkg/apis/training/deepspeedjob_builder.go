@@ -91,6 +91,22 @@ func (b *DeepSpeedJobBuilder) GPUCount(count int) *DeepSpeedJobBuilder {
 	return b
 }
 
+// GPUMIGProfile is used to request the gpu instances of the mig profile instead of the whole gpus,match the option --gpu-mig-profile
+func (b *DeepSpeedJobBuilder) GPUMIGProfile(profile string) *DeepSpeedJobBuilder {
+	if profile != "" {
+		b.args.GPUMIGProfile = profile
+	}
+	return b
+}
+
+// Accelerator is used to set the resource name of registered accelerator which the gpus are requested from,match the option --accelerator
+func (b *DeepSpeedJobBuilder) Accelerator(resourceName string) *DeepSpeedJobBuilder {
+	if resourceName != "" {
+		b.args.AcceleratorResource = resourceName
+	}
+	return b
+}
+
 // Image is used to set job image,match the option --image
 func (b *DeepSpeedJobBuilder) Image(image string) *DeepSpeedJobBuilder {
 	if image != "" {
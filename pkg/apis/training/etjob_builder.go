@@ -79,6 +79,22 @@ func (b *ETJobBuilder) GPUCount(count int) *ETJobBuilder {
 	return b
 }
 
+// GPUMIGProfile is used to request the gpu instances of the mig profile instead of the whole gpus,match the option --gpu-mig-profile
+func (b *ETJobBuilder) GPUMIGProfile(profile string) *ETJobBuilder {
+	if profile != "" {
+		b.args.GPUMIGProfile = profile
+	}
+	return b
+}
+
+// Accelerator is used to set the resource name of registered accelerator which the gpus are requested from,match the option --accelerator
+func (b *ETJobBuilder) Accelerator(resourceName string) *ETJobBuilder {
+	if resourceName != "" {
+		b.args.AcceleratorResource = resourceName
+	}
+	return b
+}
+
 // Image is used to set job image,match the option --image
 func (b *ETJobBuilder) Image(image string) *ETJobBuilder {
 	if image != "" {
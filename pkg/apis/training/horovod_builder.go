@@ -77,6 +77,22 @@ func (b *HorovodJobBuilder) GPUCount(count int) *HorovodJobBuilder {
 	return b
 }
 
+// GPUMIGProfile is used to request the gpu instances of the mig profile instead of the whole gpus,match the option --gpu-mig-profile
+func (b *HorovodJobBuilder) GPUMIGProfile(profile string) *HorovodJobBuilder {
+	if profile != "" {
+		b.args.GPUMIGProfile = profile
+	}
+	return b
+}
+
+// Accelerator is used to set the resource name of registered accelerator which the gpus are requested from,match the option --accelerator
+func (b *HorovodJobBuilder) Accelerator(resourceName string) *HorovodJobBuilder {
+	if resourceName != "" {
+		b.args.AcceleratorResource = resourceName
+	}
+	return b
+}
+
 // Image is used to set job image,match the option --image
 func (b *HorovodJobBuilder) Image(image string) *HorovodJobBuilder {
 	if image != "" {
@@ -20,6 +20,11 @@ func NewMPIJobBuilder() *MPIJobBuilder {
 		CommonSubmitArgs:      DefaultCommonSubmitArgs,
 		SubmitTensorboardArgs: DefaultSubmitTensorboardArgs,
 	}
+	return NewMPIJobBuilderWithArgs(args)
+}
+
+// NewMPIJobBuilderWithArgs creates the builder on the given args, like the args of the mpijob in a cron
+func NewMPIJobBuilderWithArgs(args *types.SubmitMPIJobArgs) *MPIJobBuilder {
 	return &MPIJobBuilder{
 		args:        args,
 		argValues:   map[string]interface{}{},
@@ -27,6 +32,10 @@ func NewMPIJobBuilder() *MPIJobBuilder {
 	}
 }
 
+func (b *MPIJobBuilder) GetArgValues() map[string]interface{} {
+	return b.argValues
+}
+
 // Name is used to set job name,match option --name
 func (b *MPIJobBuilder) Name(name string) *MPIJobBuilder {
 	if name != "" {
@@ -78,6 +87,22 @@ func (b *MPIJobBuilder) GPUCount(count int) *MPIJobBuilder {
 	return b
 }
 
+// GPUMIGProfile is used to request the gpu instances of the mig profile instead of the whole gpus,match the option --gpu-mig-profile
+func (b *MPIJobBuilder) GPUMIGProfile(profile string) *MPIJobBuilder {
+	if profile != "" {
+		b.args.GPUMIGProfile = profile
+	}
+	return b
+}
+
+// Accelerator is used to set the resource name of registered accelerator which the gpus are requested from,match the option --accelerator
+func (b *MPIJobBuilder) Accelerator(resourceName string) *MPIJobBuilder {
+	if resourceName != "" {
+		b.args.AcceleratorResource = resourceName
+	}
+	return b
+}
+
 // Image is used to set job image,match the option --image
 func (b *MPIJobBuilder) Image(image string) *MPIJobBuilder {
 	if image != "" {
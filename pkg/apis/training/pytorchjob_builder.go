@@ -20,6 +20,11 @@ func NewPytorchJobBuilder() *PytorchJobBuilder {
 		CommonSubmitArgs:      DefaultCommonSubmitArgs,
 		SubmitTensorboardArgs: DefaultSubmitTensorboardArgs,
 	}
+	return NewPytorchJobBuilderWithArgs(args)
+}
+
+// NewPytorchJobBuilderWithArgs creates the builder on the given args, like the args of the pytorchjob in a cron
+func NewPytorchJobBuilderWithArgs(args *types.SubmitPyTorchJobArgs) *PytorchJobBuilder {
 	return &PytorchJobBuilder{
 		args:        args,
 		argValues:   map[string]interface{}{},
@@ -27,6 +32,10 @@ func NewPytorchJobBuilder() *PytorchJobBuilder {
 	}
 }
 
+func (b *PytorchJobBuilder) GetArgValues() map[string]interface{} {
+	return b.argValues
+}
+
 // Name is used to set job name,match option --name
 func (b *PytorchJobBuilder) Name(name string) *PytorchJobBuilder {
 	if name != "" {
@@ -78,6 +87,22 @@ func (b *PytorchJobBuilder) GPUCount(count int) *PytorchJobBuilder {
 	return b
 }
 
+// GPUMIGProfile is used to request the gpu instances of the mig profile instead of the whole gpus,match the option --gpu-mig-profile
+func (b *PytorchJobBuilder) GPUMIGProfile(profile string) *PytorchJobBuilder {
+	if profile != "" {
+		b.args.GPUMIGProfile = profile
+	}
+	return b
+}
+
+// Accelerator is used to set the resource name of registered accelerator which the gpus are requested from,match the option --accelerator
+func (b *PytorchJobBuilder) Accelerator(resourceName string) *PytorchJobBuilder {
+	if resourceName != "" {
+		b.args.AcceleratorResource = resourceName
+	}
+	return b
+}
+
 // Image is used to set job image,match the option --image
 func (b *PytorchJobBuilder) Image(image string) *PytorchJobBuilder {
 	if image != "" {
@@ -79,6 +79,22 @@ func (b *TFJobBuilder) GPUCount(count int) *TFJobBuilder {
 	return b
 }
 
+// GPUMIGProfile is used to request the gpu instances of the mig profile instead of the whole gpus,match the option --gpu-mig-profile
+func (b *TFJobBuilder) GPUMIGProfile(profile string) *TFJobBuilder {
+	if profile != "" {
+		b.args.GPUMIGProfile = profile
+	}
+	return b
+}
+
+// Accelerator is used to set the resource name of registered accelerator which the gpus are requested from,match the option --accelerator
+func (b *TFJobBuilder) Accelerator(resourceName string) *TFJobBuilder {
+	if resourceName != "" {
+		b.args.AcceleratorResource = resourceName
+	}
+	return b
+}
+
 func (b *TFJobBuilder) Image(image string) *TFJobBuilder {
 	if image != "" {
 		b.args.Image = image
@@ -0,0 +1,48 @@
+package types
+
+// AcceleratorsConfigKey is the key of global configmap arena-config to define the accelerators in yaml,
+// the resources of them are counted as gpus, like:
+//
+//   - resourceName: amd.com/gpu
+//     displayName: AMD GPU
+//     metricsProfile: amd
+const AcceleratorsConfigKey = "accelerators"
+
+// Accelerator is a kind of device which is advertised as an extended resource by its device plugin
+type Accelerator struct {
+	// ResourceName is the extended resource name, like amd.com/gpu
+	ResourceName string `json:"resourceName" yaml:"resourceName"`
+	// DisplayName is the name displayed in the node and job views, like AMD GPU
+	DisplayName string `json:"displayName" yaml:"displayName"`
+	// MetricsProfile is the name of gpu metrics profile which maps the metrics of the accelerator
+	// exporter, the selected gpu metrics profile is used if it is empty
+	MetricsProfile string `json:"metricsProfile,omitempty" yaml:"metricsProfile,omitempty"`
+}
+
+// NvidiaGPUAccelerator is the builtin accelerator, it is always registered
+var NvidiaGPUAccelerator = Accelerator{
+	ResourceName: NvidiaGPUResourceName,
+	DisplayName:  "NVIDIA GPU",
+}
+
+// BuiltinAccelerators are the well known accelerators, they are registered if the accelerators
+// are not defined in the global configmap arena-config
+var BuiltinAccelerators = []Accelerator{
+	NvidiaGPUAccelerator,
+	{
+		ResourceName: "amd.com/gpu",
+		DisplayName:  "AMD GPU",
+	},
+	{
+		ResourceName: "habana.ai/gaudi",
+		DisplayName:  "Habana Gaudi",
+	},
+	{
+		ResourceName: "aws.amazon.com/neuron",
+		DisplayName:  "AWS Neuron",
+	},
+	{
+		ResourceName: "huawei.com/Ascend910",
+		DisplayName:  "Huawei Ascend 910",
+	},
+}
@@ -26,6 +26,16 @@ const (
 	GPUTopologyNodeLabels      = "ack.node.gpu.schedule=topology"
 )
 
+const (
+	// MIGResourceNamePrefix is the prefix of resources advertised by the nvidia device plugin
+	// with mig strategy mixed, like nvidia.com/mig-1g.10gb
+	MIGResourceNamePrefix = "nvidia.com/mig-"
+	// MIGGPUCountLabel is the count of physical gpus labeled by gpu feature discovery
+	MIGGPUCountLabel = "nvidia.com/gpu.count"
+	// MIGGPUProductLabel is the gpu product labeled by gpu feature discovery
+	MIGGPUProductLabel = "nvidia.com/gpu.product"
+)
+
 const (
 	MultiTenantIsolationLabel = "arena.kubeflow.org/isolate-user"
 	UserNameIdLabel           = "arena.kubeflow.org/uid"
@@ -6,6 +6,12 @@ type CronType string
 const (
 	// CronTFTrainingJob defines the cron tfjob
 	CronTFTrainingJob CronType = "tfjob"
+	// CronPyTorchTrainingJob defines the cron pytorchjob
+	CronPyTorchTrainingJob CronType = "pytorchjob"
+	// CronMPITrainingJob defines the cron mpijob
+	CronMPITrainingJob CronType = "mpijob"
+	// CronModelBenchmarkJob defines the cron model benchmark job
+	CronModelBenchmarkJob CronType = "model-benchmark"
 )
 
 // ConcurrencyPolicy describes how the job will be handled.
@@ -135,3 +141,61 @@ type CronTFJobArgs struct {
 	CommonCronArgs  `yaml:"cron"`
 	SubmitTFJobArgs `yaml:"tfjob"`
 }
+
+type CronPyTorchJobArgs struct {
+	CommonCronArgs       `yaml:"cron"`
+	SubmitPyTorchJobArgs `yaml:"pytorchjob"`
+}
+
+type CronMPIJobArgs struct {
+	CommonCronArgs   `yaml:"cron"`
+	SubmitMPIJobArgs `yaml:"mpijob"`
+}
+
+type CronModelBenchmarkArgs struct {
+	CommonCronArgs     `yaml:"cron"`
+	ModelBenchmarkArgs `yaml:"benchmark"`
+}
+
+// UpdateCronArgs defines the fields of a cron which can be updated in place,
+// the empty fields are left unchanged
+type UpdateCronArgs struct {
+	Name string `yaml:"name"`
+
+	// The schedule in Cron format, see https://en.wikipedia.org/wiki/Cron.
+	Schedule string `yaml:"schedule"` // --schedule
+
+	// Specifies how to treat concurrent executions of a Job, one of Allow|Forbid|Replace
+	ConcurrencyPolicy string `yaml:"concurrencyPolicy"` // --concurrency-policy
+
+	// Deadline is the timestamp that a cron job can keep scheduling util then.
+	Deadline string `yaml:"deadline"` // --deadline
+
+	// The number of finished job history to retain, 0 means not changed
+	HistoryLimit int `yaml:"historyLimit"` // --history-limit
+
+	// Image replaces the image of all containers in the workload template
+	Image string `yaml:"image"` // --image
+
+	// Envs are set to all containers in the workload template
+	Envs map[string]string `yaml:"envs"` // --env
+}
+
+// CronScheduleInfo stores the next fire times of a cron
+type CronScheduleInfo struct {
+	Name string `json:"name" yaml:"name"`
+
+	Namespace string `json:"namespace" yaml:"namespace"`
+
+	Schedule string `json:"schedule" yaml:"schedule"`
+
+	// Timezone is the timezone which is used to compute the fire times
+	Timezone string `json:"timezone" yaml:"timezone"`
+
+	Suspend bool `json:"suspend" yaml:"suspend"`
+
+	Deadline string `json:"deadline" yaml:"deadline"`
+
+	// NextScheduleTimes are the next fire times in RFC3339 format
+	NextScheduleTimes []string `json:"nextScheduleTimes" yaml:"nextScheduleTimes"`
+}
@@ -0,0 +1,66 @@
+package types
+
+// DataVolumeSourceType defines where the data of volume is stored
+type DataVolumeSourceType string
+
+const (
+	// NFSDataVolume is the volume backed by a nfs export
+	NFSDataVolume DataVolumeSourceType = "nfs"
+	// HostPathDataVolume is the volume backed by a directory of node
+	HostPathDataVolume DataVolumeSourceType = "hostpath"
+	// StorageClassDataVolume is the volume provisioned by a storage class
+	StorageClassDataVolume DataVolumeSourceType = "storageclass"
+)
+
+const (
+	// DataVolumeLabel is the label of pv and pvc created by arena, its value is the name of data volume
+	DataVolumeLabel = "arena.kubeflow.org/data-volume"
+)
+
+// DataVolumeArgs defines the args of creating a data volume
+type DataVolumeArgs struct {
+	Name      string `json:"name" yaml:"name"`
+	Namespace string `json:"namespace" yaml:"namespace"`
+	// NFS is the nfs export like server:/path
+	NFS string `json:"nfs" yaml:"nfs"`
+	// HostPath is the directory of node
+	HostPath string `json:"hostPath" yaml:"hostPath"`
+	// StorageClass is the storage class which provisions the volume dynamically
+	StorageClass string `json:"storageClass" yaml:"storageClass"`
+	// Size is the capacity of volume,like 100Gi
+	Size string `json:"size" yaml:"size"`
+	// AccessMode is the access mode of volume,like ReadWriteMany
+	AccessMode string `json:"accessMode" yaml:"accessMode"`
+	// Description is recorded in the annotation of pvc
+	Description string `json:"description" yaml:"description"`
+}
+
+// DataVolumeInfo is the detail of a data volume
+type DataVolumeInfo struct {
+	Name         string               `json:"name" yaml:"name"`
+	Namespace    string               `json:"namespace" yaml:"namespace"`
+	Status       string               `json:"status" yaml:"status"`
+	SourceType   DataVolumeSourceType `json:"sourceType,omitempty" yaml:"sourceType,omitempty"`
+	Source       string               `json:"source,omitempty" yaml:"source,omitempty"`
+	StorageClass string               `json:"storageClass,omitempty" yaml:"storageClass,omitempty"`
+	VolumeName   string               `json:"volumeName,omitempty" yaml:"volumeName,omitempty"`
+	Capacity     string               `json:"capacity" yaml:"capacity"`
+	AccessModes  string               `json:"accessModes" yaml:"accessModes"`
+	Description  string               `json:"description,omitempty" yaml:"description,omitempty"`
+	Owner        string               `json:"owner,omitempty" yaml:"owner,omitempty"`
+	// CreatedByArena is true if the volume is created by arena data create
+	CreatedByArena    bool   `json:"createdByArena" yaml:"createdByArena"`
+	CreationTimestamp string `json:"creationTimestamp" yaml:"creationTimestamp"`
+	// Users are the jobs whose pods are mounting the volume
+	Users []DataVolumeUser `json:"users" yaml:"users"`
+}
+
+// DataVolumeUser is a job which mounts the data volume
+type DataVolumeUser struct {
+	// Kind is one of training,serving,model,evaluate and other
+	Kind string `json:"kind" yaml:"kind"`
+	Name string `json:"name" yaml:"name"`
+	Type string `json:"type,omitempty" yaml:"type,omitempty"`
+	// Pods are the running pods which mount the volume
+	Pods []string `json:"pods" yaml:"pods"`
+}
@@ -94,4 +94,7 @@ type EvaluateJobInfo struct {
 	Status string `json:"status" yaml:"status"`
 
 	CreationTimestamp string `json:"creationTimestamp" yaml:"creationTimestamp"`
+
+	// Metrics stores the metrics published by the job, like accuracy, precision and recall
+	Metrics map[string]float64 `json:"metrics,omitempty" yaml:"metrics,omitempty"`
 }
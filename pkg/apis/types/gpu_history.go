@@ -0,0 +1,52 @@
+package types
+
+// GpuMetricSamples are the samples of gpu metrics of a device in a time range
+type GpuMetricSamples struct {
+	DutyCycle []float64
+	// MemoryUsed and MemoryTotal are in bytes
+	MemoryUsed  []float64
+	MemoryTotal []float64
+}
+
+// GPUMetricStats is the statistics of a gpu metric in a time range
+type GPUMetricStats struct {
+	Avg float64 `json:"avg" yaml:"avg"`
+	P95 float64 `json:"p95" yaml:"p95"`
+	Max float64 `json:"max" yaml:"max"`
+}
+
+// GPUDeviceHistory is the gpu utilization and memory of a device in a time range
+type GPUDeviceHistory struct {
+	Id string `json:"id" yaml:"id"`
+	// DutyCycle is the gpu utilization in percent
+	DutyCycle GPUMetricStats `json:"dutyCycle" yaml:"dutyCycle"`
+	// MemoryUsed is the used gpu memory in bytes
+	MemoryUsed GPUMetricStats `json:"memoryUsed" yaml:"memoryUsed"`
+	// MemoryTotal is the total gpu memory in bytes
+	MemoryTotal float64 `json:"memoryTotal" yaml:"memoryTotal"`
+	Samples     int     `json:"samples" yaml:"samples"`
+	// DutyCycleTrend is the downsampled gpu utilization which is displayed as sparkline
+	DutyCycleTrend []float64 `json:"dutyCycleTrend" yaml:"dutyCycleTrend"`
+}
+
+// InstanceGPUHistory is the gpu history of an instance
+type InstanceGPUHistory struct {
+	Name string             `json:"name" yaml:"name"`
+	Node string             `json:"node" yaml:"node"`
+	GPUs []GPUDeviceHistory `json:"gpus" yaml:"gpus"`
+}
+
+// TrainingJobGPUHistory is the gpu history of the instances of a training job in a time range
+type TrainingJobGPUHistory struct {
+	Name      string          `json:"name" yaml:"name"`
+	Namespace string          `json:"namespace" yaml:"namespace"`
+	Trainer   TrainingJobType `json:"trainer" yaml:"trainer"`
+	// Start and End are the unix timestamps of the time range
+	Start int64 `json:"start" yaml:"start"`
+	End   int64 `json:"end" yaml:"end"`
+	// Step is the query resolution step in seconds
+	Step int64 `json:"step" yaml:"step"`
+	// AvgDutyCycle is the average gpu utilization of all the gpus of job
+	AvgDutyCycle float64              `json:"avgDutyCycle" yaml:"avgDutyCycle"`
+	Instances    []InstanceGPUHistory `json:"instances" yaml:"instances"`
+}
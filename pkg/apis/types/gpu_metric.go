@@ -79,6 +79,8 @@ type PrometheusMetricData struct {
 type PrometheusMetricResult struct {
 	Metric map[string]string       `json:"metric"`
 	Value  []PrometheusMetricValue `json:"value"`
+	// Values are the samples of range query
+	Values [][]PrometheusMetricValue `json:"values,omitempty"`
 }
 
 type PrometheusMetricValue interface{}
@@ -94,6 +96,8 @@ type GpuMetricInfo struct {
 	GPUUID        string
 	Id            string
 	AllocateMode  string
+	// Labels are all the labels of metric
+	Labels map[string]string
 }
 
 type JobGpuMetric map[string]PodGpuMetric
@@ -118,3 +122,75 @@ type AdvancedGpuMetric struct {
 	// PodName is combined with namespace and  pod name,like 'namespace/pod_name'
 	PodNames []string `json:"podNames" yaml:"podNames"`
 }
+
+// POD_CPU_USAGE_TMP queries the cpu usage(cores) of pods from cadvisor metrics
+const POD_CPU_USAGE_TMP = `sum(rate(container_cpu_usage_seconds_total{namespace="%s", pod=~"%s", container!="", container!="POD"}[2m])) by (pod)`
+
+// POD_MEMORY_USAGE_TMP queries the memory usage(bytes) of pods from cadvisor metrics
+const POD_MEMORY_USAGE_TMP = `sum(container_memory_working_set_bytes{namespace="%s", pod=~"%s", container!="", container!="POD"}) by (pod)`
+
+// PodResourceMetric is the cpu and memory usage of a pod
+type PodResourceMetric struct {
+	// CPUUsage is the used cpu cores
+	CPUUsage float64 `json:"cpuUsage" yaml:"cpuUsage"`
+	// MemoryUsage is the used memory in bytes
+	MemoryUsage float64 `json:"memoryUsage" yaml:"memoryUsage"`
+}
+
+const (
+	// GPUMetricsProfileConfigKey is the key of global configmap arena-config to select the gpu metrics profile,
+	// the profile is detected from the prometheus if it is empty or auto
+	GPUMetricsProfileConfigKey = "gpuMetricsProfile"
+	// GPUMetricsProfilesConfigKey is the key of global configmap arena-config to define the custom gpu metrics profiles in yaml
+	GPUMetricsProfilesConfigKey = "gpuMetricsProfiles"
+	// AutoGPUMetricsProfile means detecting the gpu metrics profile from the prometheus
+	AutoGPUMetricsProfile = "auto"
+)
+
+// GPUMetricsProfile maps the gpu metric names and labels of an exporter to the ones used by arena
+type GPUMetricsProfile struct {
+	Name string `json:"name" yaml:"name"`
+	// DutyCycleMetric is the gpu utilization in percent
+	DutyCycleMetric string `json:"dutyCycleMetric" yaml:"dutyCycleMetric"`
+	// MemoryUsedMetric is the used gpu memory
+	MemoryUsedMetric string `json:"memoryUsedMetric" yaml:"memoryUsedMetric"`
+	// MemoryTotalMetric is the total gpu memory
+	MemoryTotalMetric string `json:"memoryTotalMetric,omitempty" yaml:"memoryTotalMetric,omitempty"`
+	// MemoryFreeMetric is the free gpu memory, the total gpu memory is the sum of used and free memory
+	// if MemoryTotalMetric is not set
+	MemoryFreeMetric string `json:"memoryFreeMetric,omitempty" yaml:"memoryFreeMetric,omitempty"`
+	// MemoryUnit is the unit of gpu memory metrics, one of B, KiB, MiB and GiB, the default is B
+	MemoryUnit string `json:"memoryUnit,omitempty" yaml:"memoryUnit,omitempty"`
+	// XIDErrorMetric is the last xid error of gpu, 0 means no xid error, it is optional
+	XIDErrorMetric string `json:"xidErrorMetric,omitempty" yaml:"xidErrorMetric,omitempty"`
+	// ECCErrorMetric is the count of uncorrectable ecc errors of gpu, it is optional
+	ECCErrorMetric string `json:"eccErrorMetric,omitempty" yaml:"eccErrorMetric,omitempty"`
+	// ThermalViolationMetric is the counter of the time the gpu is throttled due to thermal constraints, it is optional
+	ThermalViolationMetric string `json:"thermalViolationMetric,omitempty" yaml:"thermalViolationMetric,omitempty"`
+	// Labels maps the labels of metrics
+	Labels GPUMetricsLabels `json:"labels" yaml:"labels"`
+}
+
+// GPUMetricsLabels defines the label names of gpu metrics
+type GPUMetricsLabels struct {
+	Pod          string `json:"pod" yaml:"pod"`
+	Namespace    string `json:"namespace" yaml:"namespace"`
+	Container    string `json:"container" yaml:"container"`
+	Node         string `json:"node" yaml:"node"`
+	GPUID        string `json:"gpuId" yaml:"gpuId"`
+	UUID         string `json:"uuid" yaml:"uuid"`
+	AllocateMode string `json:"allocateMode,omitempty" yaml:"allocateMode,omitempty"`
+	// MIGProfile and MIGInstance are the labels of mig gpu instances, like 1g.10gb and 3
+	MIGProfile  string `json:"migProfile,omitempty" yaml:"migProfile,omitempty"`
+	MIGInstance string `json:"migInstance,omitempty" yaml:"migInstance,omitempty"`
+}
+
+// MIGDeviceMetric is the mig gpu instance reported by the gpu exporter
+type MIGDeviceMetric struct {
+	NodeName     string
+	GPUId        string
+	Profile      string
+	InstanceId   string
+	PodName      string
+	PodNamespace string
+}
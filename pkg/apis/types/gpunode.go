@@ -6,6 +6,7 @@ const (
 	GPUShareNode     NodeType = "GPUShare"
 	GPUExclusiveNode NodeType = "GPUExclusive"
 	GPUTopologyNode  NodeType = "GPUTopology"
+	GPUMIGNode       NodeType = "GPUMIG"
 	NormalNode       NodeType = "Normal"
 	UnknownNode      NodeType = "unknown"
 	AllKnownNode     NodeType = ""
@@ -38,6 +39,11 @@ var NodeTypeSlice = []NodeTypeInfo{
 		Alias:     "share",
 		Shorthand: "s",
 	},
+	{
+		Name:      GPUMIGNode,
+		Alias:     "mig",
+		Shorthand: "m",
+	},
 }
 
 type CommonNodeInfo struct {
@@ -110,6 +116,7 @@ type GPUShareNodeDevice struct {
 
 type GPUExclusiveNodeInfo struct {
 	PodInfos          []GPUExclusivePodInfo `json:"instances" yaml:"instances"`
+	Accelerator       string                `json:"accelerator" yaml:"accelerator"`
 	CommonNodeInfo    `yaml:",inline" json:",inline"`
 	CommonGPUNodeInfo `yaml:",inline" json:",inline"`
 }
@@ -121,6 +128,41 @@ type GPUExclusivePodInfo struct {
 	RequestGPU int    `json:"requestGPUs" yaml:"requestGPUs"`
 }
 
+type GPUMIGNodeInfo struct {
+	PodInfos          []GPUMIGPodInfo     `json:"instances" yaml:"instances"`
+	Profiles          []GPUMIGProfileInfo `json:"profiles" yaml:"profiles"`
+	Devices           []GPUMIGDevice      `json:"devices" yaml:"devices"`
+	CommonGPUNodeInfo `yaml:",inline" json:",inline"`
+	CommonNodeInfo    `yaml:",inline" json:",inline"`
+}
+
+// GPUMIGProfileInfo is the slices of a mig profile on the node
+type GPUMIGProfileInfo struct {
+	Profile   string `json:"profile" yaml:"profile"`
+	Total     int    `json:"total" yaml:"total"`
+	Allocated int    `json:"allocated" yaml:"allocated"`
+}
+
+// GPUMIGDevice is the physical gpu and its mig slices
+type GPUMIGDevice struct {
+	Id     string        `json:"id" yaml:"id"`
+	Slices []GPUMIGSlice `json:"slices" yaml:"slices"`
+}
+
+// GPUMIGSlice is a mig gpu instance, Pod is empty if the slice is free
+type GPUMIGSlice struct {
+	Profile    string `json:"profile" yaml:"profile"`
+	InstanceId string `json:"instanceId" yaml:"instanceId"`
+	Pod        string `json:"pod" yaml:"pod"`
+}
+
+type GPUMIGPodInfo struct {
+	Name              string         `json:"name" yaml:"name"`
+	Namespace         string         `json:"namespace" yaml:"namespace"`
+	Status            string         `json:"status" yaml:"status"`
+	RequestMIGDevices map[string]int `json:"requestMIGDevices" yaml:"requestMIGDevices"`
+}
+
 type NormalNodeInfo struct {
 	CommonNodeInfo `yaml:",inline" json:",inline"`
 }
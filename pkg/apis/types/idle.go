@@ -0,0 +1,61 @@
+package types
+
+import "time"
+
+// IdleJobAction defines the action which is taken on the idle jobs
+type IdleJobAction string
+
+const (
+	// NoneIdleJobAction only reports the idle jobs
+	NoneIdleJobAction IdleJobAction = ""
+	// AnnotateIdleJobAction marks the pods of idle jobs with annotations
+	AnnotateIdleJobAction IdleJobAction = "annotate"
+	// SuspendIdleJobAction pauses the idle jobs to release the gpus, the serving jobs are scaled to 0 and
+	// the tfjobs and pytorchjobs are suspended by spec.runPolicy.suspend
+	SuspendIdleJobAction IdleJobAction = "suspend"
+)
+
+const (
+	// IdleSinceAnnotation records the time when the gpus of job become idle
+	IdleSinceAnnotation = "arena.kubeflow.org/idle-since"
+	// IdleGPUHoursAnnotation records the gpu hours which are wasted by the idle job
+	IdleGPUHoursAnnotation = "arena.kubeflow.org/idle-gpu-hours"
+	// SuspendedReplicasAnnotation records the replicas of serving job before it is suspended
+	SuspendedReplicasAnnotation = "arena.kubeflow.org/suspended-replicas"
+)
+
+// IdleJobArgs defines the args of finding idle jobs
+type IdleJobArgs struct {
+	Namespace     string `json:"namespace" yaml:"namespace"`
+	AllNamespaces bool   `json:"allNamespaces" yaml:"allNamespaces"`
+	// Threshold is the gpu utilization(percent) under which the gpu is regarded as idle
+	Threshold float64 `json:"threshold" yaml:"threshold"`
+	// Duration is the minimal duration of the gpus keeping idle
+	Duration time.Duration `json:"duration" yaml:"duration"`
+	// Action is taken on the idle jobs
+	Action IdleJobAction `json:"action" yaml:"action"`
+}
+
+// IdleJobInfo is a job which holds gpus but leaves them idle
+type IdleJobInfo struct {
+	Name      string `json:"name" yaml:"name"`
+	Namespace string `json:"namespace" yaml:"namespace"`
+	Owner     string `json:"owner" yaml:"owner"`
+	// Kind is training or serving
+	Kind string `json:"kind" yaml:"kind"`
+	// Type is the training job type or serving job type
+	Type    string `json:"type" yaml:"type"`
+	Version string `json:"version,omitempty" yaml:"version,omitempty"`
+	// AllocatedGPUs is the gpus which are held by the job
+	AllocatedGPUs float64 `json:"allocatedGPUs" yaml:"allocatedGPUs"`
+	// MaxDutyCycle is the max gpu utilization of job in the idle duration
+	MaxDutyCycle float64 `json:"maxDutyCycle" yaml:"maxDutyCycle"`
+	// IdleSince is the unix timestamp when the gpus become idle
+	IdleSince int64 `json:"idleSince" yaml:"idleSince"`
+	// IdleDuration is the seconds of gpus keeping idle
+	IdleDuration int64 `json:"idleDuration" yaml:"idleDuration"`
+	// WastedGPUHours is the allocated gpus multiplied by the idle hours
+	WastedGPUHours float64 `json:"wastedGPUHours" yaml:"wastedGPUHours"`
+	// Action is the result of the action taken on the job
+	Action string `json:"action,omitempty" yaml:"action,omitempty"`
+}
@@ -106,6 +106,80 @@ type ModelJobInfo struct {
 
 	// CreationTimestamp stores the job parameters
 	Params map[string]string `json:"params" yaml:"params"`
+
+	// Results stores the structured results reported by the job
+	Results *ModelJobResults `json:"results,omitempty" yaml:"results,omitempty"`
+}
+
+// ModelJobResults is the results json which is reported by the model job, the job writes
+// it to the file $ARENA_MODEL_RESULTS_FILE or the configmap <job_name>-results
+type ModelJobResults struct {
+	Benchmark *ModelBenchmarkResults `json:"benchmark,omitempty" yaml:"benchmark,omitempty"`
+	Profile   *ModelProfileResults   `json:"profile,omitempty" yaml:"profile,omitempty"`
+}
+
+// ModelBenchmarkResults stores the inference performance of a model benchmark job
+type ModelBenchmarkResults struct {
+	// Concurrency is the number of concurrent clients
+	Concurrency int `json:"concurrency" yaml:"concurrency"`
+	// Requests is the total number of requests
+	Requests int64 `json:"requests" yaml:"requests"`
+	// FailedRequests is the number of failed requests
+	FailedRequests int64 `json:"failedRequests" yaml:"failedRequests"`
+	// Duration is the benchmark duration in seconds
+	Duration float64 `json:"duration" yaml:"duration"`
+	// Throughput is the number of requests per second
+	Throughput float64 `json:"throughput" yaml:"throughput"`
+	// Latency stores the latency percentiles in milliseconds
+	Latency ModelLatencyResults `json:"latency" yaml:"latency"`
+	// GPUUtilization is the average gpu utilization in percent
+	GPUUtilization float64 `json:"gpuUtilization,omitempty" yaml:"gpuUtilization,omitempty"`
+	// GPUMemoryUsed is the max gpu memory used in MiB
+	GPUMemoryUsed float64 `json:"gpuMemoryUsed,omitempty" yaml:"gpuMemoryUsed,omitempty"`
+}
+
+// ModelLatencyResults stores the latency percentiles in milliseconds
+type ModelLatencyResults struct {
+	Avg float64 `json:"avg" yaml:"avg"`
+	P50 float64 `json:"p50" yaml:"p50"`
+	P90 float64 `json:"p90" yaml:"p90"`
+	P95 float64 `json:"p95" yaml:"p95"`
+	P99 float64 `json:"p99" yaml:"p99"`
+	Max float64 `json:"max" yaml:"max"`
+}
+
+// ModelProfileResults stores the summary of a model profile job
+type ModelProfileResults struct {
+	// TotalTime is the total time of one inference in milliseconds
+	TotalTime float64 `json:"totalTime" yaml:"totalTime"`
+	// Layers stores the time costs of model layers
+	Layers []ModelLayerProfile `json:"layers" yaml:"layers"`
+}
+
+// ModelLayerProfile stores the time cost of a model layer
+type ModelLayerProfile struct {
+	Name string `json:"name" yaml:"name"`
+	Type string `json:"type" yaml:"type"`
+	// Calls is the number of calls of the layer
+	Calls int64 `json:"calls" yaml:"calls"`
+	// TotalTime is the total time of the layer in milliseconds
+	TotalTime float64 `json:"totalTime" yaml:"totalTime"`
+	// Percent is the percentage of the layer time in the total time
+	Percent float64 `json:"percent" yaml:"percent"`
+}
+
+// ModelJobComparison stores the metrics of model jobs side by side
+type ModelJobComparison struct {
+	Jobs    []string                `json:"jobs" yaml:"jobs"`
+	Metrics []ModelMetricComparison `json:"metrics" yaml:"metrics"`
+}
+
+// ModelMetricComparison stores the values of a metric in the compared jobs, the value
+// is N/A if the job has no such metric, Diff is the relative change of the second job to the first job
+type ModelMetricComparison struct {
+	Name   string   `json:"name" yaml:"name"`
+	Values []string `json:"values" yaml:"values"`
+	Diff   string   `json:"diff" yaml:"diff"`
 }
 
 type ModelJobInstance struct {
@@ -180,6 +254,8 @@ type ModelProfileArgs struct {
 	ReportPath       string `yaml:"reportPath"`       // --report-path
 	UseTensorboard   bool   `yaml:"useTensorboard"`   // --tensorboard
 	TensorboardImage string `yaml:"tensorboardImage"` // --tensorboardImage
+	// ResultsConfigMap stores the results json which is larger than the termination message limit
+	ResultsConfigMap string `yaml:"resultsConfigMap"`
 
 	CommonModelArgs `yaml:",inline"`
 }
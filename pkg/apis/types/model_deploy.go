@@ -0,0 +1,89 @@
+package types
+
+// ModelDeployStage defines the stage of model deploy pipeline
+type ModelDeployStage string
+
+const (
+	// ModelDeployOptimizeStage runs the model optimize job
+	ModelDeployOptimizeStage ModelDeployStage = "optimize"
+	// ModelDeployBenchmarkStage runs the model benchmark job on the optimized model
+	ModelDeployBenchmarkStage ModelDeployStage = "benchmark"
+	// ModelDeployServeStage creates the serving job of the optimized model
+	ModelDeployServeStage ModelDeployStage = "serve"
+)
+
+// ModelDeployStatus defines the status of model deploy pipeline and its stages
+type ModelDeployStatus string
+
+const (
+	ModelDeployPending   ModelDeployStatus = "PENDING"
+	ModelDeployRunning   ModelDeployStatus = "RUNNING"
+	ModelDeploySucceeded ModelDeployStatus = "SUCCEEDED"
+	ModelDeployFailed    ModelDeployStatus = "FAILED"
+	// ModelDeploySkipped means the stage is not required or not run because the previous stage is not passed
+	ModelDeploySkipped ModelDeployStatus = "SKIPPED"
+)
+
+// ModelDeployArgs defines the args of model deploy pipeline, the pipeline runs the optimize job,
+// benchmarks the optimized model and serves it if the benchmark throughput meets the requirement
+type ModelDeployArgs struct {
+	Name      string `yaml:"name"`      // --name
+	Namespace string `yaml:"namespace"` // --namespace
+
+	// Benchmark enables the benchmark stage, match option --benchmark
+	Benchmark bool `yaml:"benchmark"`
+	// MinThroughput is the min requests per second of the optimized model to serve it, match option --min-throughput
+	MinThroughput float64 `yaml:"minThroughput"`
+	// OptimizedModelPath is the path of optimized model to benchmark, it is required by the benchmark stage
+	OptimizedModelPath string `yaml:"optimizedModelPath"` // --optimized-model-path
+	// ServeType is the serving type of the optimized model, only triton is supported, match option --serve-type
+	ServeType ServingJobType `yaml:"serveType"`
+	// Timeout is the max seconds to wait for each job, match option --timeout
+	Timeout int `yaml:"timeout"`
+
+	OptimizeArgs  *ModelOptimizeArgs  `yaml:"optimize"`
+	BenchmarkArgs *ModelBenchmarkArgs `yaml:"benchmark"`
+	TritonArgs    *TritonServingArgs  `yaml:"triton"`
+}
+
+// ModelDeployInfo stores the status of model deploy pipeline
+type ModelDeployInfo struct {
+	Name string `json:"name" yaml:"name"`
+
+	Namespace string `json:"namespace" yaml:"namespace"`
+
+	Status ModelDeployStatus `json:"status" yaml:"status"`
+
+	// Message tells why the pipeline is failed or the serving stage is skipped
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+
+	MinThroughput float64 `json:"minThroughput" yaml:"minThroughput"`
+
+	Stages []ModelDeployStageInfo `json:"stages" yaml:"stages"`
+
+	CreationTimestamp string `json:"creationTimestamp" yaml:"creationTimestamp"`
+
+	UpdateTimestamp string `json:"updateTimestamp" yaml:"updateTimestamp"`
+}
+
+// ModelDeployStageInfo stores the status of a stage in model deploy pipeline
+type ModelDeployStageInfo struct {
+	Name ModelDeployStage `json:"name" yaml:"name"`
+
+	// JobName is the name of model job or serving job created by the stage
+	JobName string `json:"jobName,omitempty" yaml:"jobName,omitempty"`
+
+	// JobType is the type of the job, like optimize, benchmark or triton-serving
+	JobType string `json:"jobType,omitempty" yaml:"jobType,omitempty"`
+
+	Status ModelDeployStatus `json:"status" yaml:"status"`
+
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+
+	// Throughput is the requests per second reported by the benchmark stage
+	Throughput float64 `json:"throughput,omitempty" yaml:"throughput,omitempty"`
+
+	StartTime string `json:"startTime,omitempty" yaml:"startTime,omitempty"`
+
+	EndTime string `json:"endTime,omitempty" yaml:"endTime,omitempty"`
+}
@@ -0,0 +1,67 @@
+package types
+
+// ModelStage defines the stage of a registered model version
+type ModelStage string
+
+const (
+	// ModelStageNone means the model version is registered but not promoted
+	ModelStageNone ModelStage = "none"
+	// ModelStageStaging means the model version is under validation
+	ModelStageStaging ModelStage = "staging"
+	// ModelStageProduction means the model version is serving the production traffic
+	ModelStageProduction ModelStage = "production"
+	// ModelStageArchived means the model version is replaced by a newer one
+	ModelStageArchived ModelStage = "archived"
+)
+
+// ModelRegistryScheme is the scheme of model uri which refers to a registered model,
+// like registry://resnet@3 or registry://resnet@production
+const ModelRegistryScheme = "registry://"
+
+// RegisteredModelInfo stores the information of a registered model version
+type RegisteredModelInfo struct {
+	Name string `json:"name" yaml:"name"`
+
+	Namespace string `json:"namespace" yaml:"namespace"`
+
+	Version string `json:"version" yaml:"version"`
+
+	// StorageURI is the location of model files, like pvc://<pvc_name>/<path>, oss://<bucket>/<path> or a path in the image
+	StorageURI string `json:"storageUri" yaml:"storageUri"`
+
+	Framework string `json:"framework" yaml:"framework"`
+
+	Stage ModelStage `json:"stage" yaml:"stage"`
+
+	Metrics map[string]string `json:"metrics,omitempty" yaml:"metrics,omitempty"`
+
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+
+	// SourceJob is the name of training job which produced the model
+	SourceJob string `json:"sourceJob,omitempty" yaml:"sourceJob,omitempty"`
+
+	// SourceJobType is the type of training job which produced the model
+	SourceJobType string `json:"sourceJobType,omitempty" yaml:"sourceJobType,omitempty"`
+
+	// SourceJobUID is the uid of training job which produced the model, it equals to TrainingJobInfo.UUID
+	SourceJobUID string `json:"sourceJobUid,omitempty" yaml:"sourceJobUid,omitempty"`
+
+	CreationTimestamp string `json:"creationTimestamp" yaml:"creationTimestamp"`
+
+	UpdateTimestamp string `json:"updateTimestamp" yaml:"updateTimestamp"`
+}
+
+// RegisterModelArgs defines the args to register a model version
+type RegisterModelArgs struct {
+	Name          string            `yaml:"name"`          // --name
+	Namespace     string            `yaml:"namespace"`     // --namespace
+	Version       string            `yaml:"version"`       // --version
+	StorageURI    string            `yaml:"storageUri"`    // --storage-uri
+	Framework     string            `yaml:"framework"`     // --framework
+	Stage         ModelStage        `yaml:"stage"`         // --stage
+	Metrics       map[string]string `yaml:"metrics"`       // --metric
+	Description   string            `yaml:"description"`   // --description
+	SourceJob     string            `yaml:"sourceJob"`     // --source-job
+	SourceJobType string            `yaml:"sourceJobType"` // --source-job-type
+	SourceJobUID  string            `yaml:"sourceJobUid"`
+}
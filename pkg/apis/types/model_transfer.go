@@ -0,0 +1,49 @@
+package types
+
+import "time"
+
+// ModelTransferType defines the direction of model transfer
+type ModelTransferType string
+
+const (
+	// ModelPushTransfer exports the model from a local path or a pvc to the object storage or a pvc
+	ModelPushTransfer ModelTransferType = "push"
+	// ModelPullTransfer imports the model from the object storage or a pvc to a local path or a pvc
+	ModelPullTransfer ModelTransferType = "pull"
+)
+
+const (
+	// PVCStorageScheme is the scheme of model path in a pvc, like pvc://<pvc_name>/<path>
+	PVCStorageScheme = "pvc://"
+	// S3StorageScheme is the scheme of model path in a S3 compatible object storage, like s3://<bucket>/<path>
+	S3StorageScheme = "s3://"
+)
+
+// ModelTransferArgs defines the args to transfer the model artifact, the source and the destination
+// can be a local path, pvc://<pvc_name>/<path> or s3://<bucket>/<path>, but not both local paths
+type ModelTransferArgs struct {
+	Type        ModelTransferType `yaml:"type"`
+	Namespace   string            `yaml:"namespace"`   // --namespace
+	Source      string            `yaml:"source"`      // the first argument
+	Destination string            `yaml:"destination"` // --to
+	// Secret is the name of secret which stores the AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY of the object storage
+	Secret string `yaml:"secret"` // --secret
+	// Endpoint is the url of S3 compatible object storage, like http://minio.minio:9000
+	Endpoint string `yaml:"endpoint"` // --endpoint
+	Region   string `yaml:"region"`   // --region
+	// Image is the image of transfer job which runs rclone
+	Image   string        `yaml:"image"`   // --image
+	Timeout time.Duration `yaml:"timeout"` // --timeout
+}
+
+// ModelTransferResult stores the result of model transfer
+type ModelTransferResult struct {
+	Type        ModelTransferType `json:"type" yaml:"type"`
+	JobName     string            `json:"jobName" yaml:"jobName"`
+	Namespace   string            `json:"namespace" yaml:"namespace"`
+	Source      string            `json:"source" yaml:"source"`
+	Destination string            `json:"destination" yaml:"destination"`
+	// Files is the number of files verified by the checksum
+	Files    int    `json:"files" yaml:"files"`
+	Duration string `json:"duration" yaml:"duration"`
+}
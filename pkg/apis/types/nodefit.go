@@ -0,0 +1,61 @@
+package types
+
+// DefaultNodePoolLabel is the label used to group nodes into node pools
+const DefaultNodePoolLabel = "node.kubernetes.io/instance-type"
+
+// NodeFitArgs is the resources requested by each worker of a job, which is used to
+// check whether the job can be placed on the nodes
+type NodeFitArgs struct {
+	// GPUs is the whole gpus requested by each worker
+	GPUs int `json:"gpus" yaml:"gpus"`
+	// GPUMemory is the gpu memory(GiB) of one device requested by each worker on gpushare nodes
+	GPUMemory float64 `json:"gpuMemory" yaml:"gpuMemory"`
+	// CPU is the cpu cores requested by each worker
+	CPU float64 `json:"cpu" yaml:"cpu"`
+	// Memory is the memory(bytes) requested by each worker
+	Memory float64 `json:"memory" yaml:"memory"`
+	// Workers is the count of workers
+	Workers int `json:"workers" yaml:"workers"`
+	// PoolLabel is the node label whose value is the node pool name
+	PoolLabel string `json:"poolLabel" yaml:"poolLabel"`
+}
+
+// NodeFitInfo is the free resources of a node and the count of workers which can be placed on it
+type NodeFitInfo struct {
+	Name     string   `json:"name" yaml:"name"`
+	Pool     string   `json:"pool" yaml:"pool"`
+	Type     NodeType `json:"type" yaml:"type"`
+	FreeGPUs int      `json:"freeGPUs" yaml:"freeGPUs"`
+	// FreeGPUMemory is the free gpu memory(bytes) of each device on gpushare nodes
+	FreeGPUMemory []float64 `json:"freeGPUMemory,omitempty" yaml:"freeGPUMemory,omitempty"`
+	FreeCPU       float64   `json:"freeCPU" yaml:"freeCPU"`
+	FreeMemory    float64   `json:"freeMemory" yaml:"freeMemory"`
+	// Workers is the count of workers which can be placed on the node
+	Workers int `json:"workers" yaml:"workers"`
+	// Reason explains the blocking resources if no worker can be placed on the node
+	Reason string `json:"reason,omitempty" yaml:"reason,omitempty"`
+}
+
+// NodePoolFragmentation describes how the free gpus of a node pool are scattered
+type NodePoolFragmentation struct {
+	Pool  string `json:"pool" yaml:"pool"`
+	Nodes int    `json:"nodes" yaml:"nodes"`
+	// FreeGPUs is the free whole gpus of all nodes in the pool
+	FreeGPUs int `json:"freeGPUs" yaml:"freeGPUs"`
+	// LargestFreeGPUs is the max free gpus of a single node
+	LargestFreeGPUs int `json:"largestFreeGPUs" yaml:"largestFreeGPUs"`
+	// StrandedGPUs is the free gpus which can not be used by the workers of request
+	StrandedGPUs int `json:"strandedGPUs" yaml:"strandedGPUs"`
+	// Score is 1 - LargestFreeGPUs/FreeGPUs, 0 means all free gpus are on one node
+	Score float64 `json:"score" yaml:"score"`
+}
+
+// NodeFitReport is the result of simulating the placement of a job
+type NodeFitReport struct {
+	Request NodeFitArgs `json:"request" yaml:"request"`
+	// Feasible means all workers can be placed
+	Feasible      bool                    `json:"feasible" yaml:"feasible"`
+	PlacedWorkers int                     `json:"placedWorkers" yaml:"placedWorkers"`
+	Nodes         []NodeFitInfo           `json:"nodes" yaml:"nodes"`
+	Pools         []NodePoolFragmentation `json:"pools" yaml:"pools"`
+}
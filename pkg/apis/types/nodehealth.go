@@ -0,0 +1,54 @@
+package types
+
+import "time"
+
+// DefaultNodeHealthWindow is the time window in which the gpu xid errors, ecc errors and thermal throttling
+// are regarded as recent
+const DefaultNodeHealthWindow = time.Hour
+
+// GPUXIDError is the xid error reported by a gpu
+type GPUXIDError struct {
+	NodeName string `json:"nodeName" yaml:"nodeName"`
+	GPUId    string `json:"gpuId" yaml:"gpuId"`
+	XID      int    `json:"xid" yaml:"xid"`
+	// Time is the first time(unix seconds) the xid error is found
+	Time int64 `json:"time" yaml:"time"`
+}
+
+// GPUDeviceHealth is the health metrics of a gpu in the time window
+type GPUDeviceHealth struct {
+	Id string `json:"id" yaml:"id"`
+	// XID is the xid error which the gpu reported in the time window, 0 means no new xid error
+	XID int `json:"xid" yaml:"xid"`
+	// ECCErrors is the count of uncorrectable ecc errors in the time window
+	ECCErrors float64 `json:"eccErrors" yaml:"eccErrors"`
+	// ThermalThrottled means the gpu was throttled due to thermal constraints
+	ThermalThrottled bool `json:"thermalThrottled" yaml:"thermalThrottled"`
+}
+
+// NodeGPUHealthMetrics is the health metrics of gpus on a node, the key of map is gpu id
+type NodeGPUHealthMetrics map[string]*GPUDeviceHealth
+
+// NodeHealthInfo is the health of a node
+type NodeHealthInfo struct {
+	Name   string   `json:"name" yaml:"name"`
+	Type   NodeType `json:"type" yaml:"type"`
+	Status string   `json:"status" yaml:"status"`
+	// Conditions are the abnormal node conditions, like MemoryPressure
+	Conditions []string `json:"conditions" yaml:"conditions"`
+	// UnhealthyGPUs is the unhealthy gpus reported by the device plugin
+	UnhealthyGPUs float64 `json:"unhealthyGPUs" yaml:"unhealthyGPUs"`
+	// Devices are the gpus which have problems in the time window
+	Devices []GPUDeviceHealth `json:"devices" yaml:"devices"`
+	// Suspicious means the node may be broken, the reasons explain it
+	Suspicious bool     `json:"suspicious" yaml:"suspicious"`
+	Reasons    []string `json:"reasons" yaml:"reasons"`
+}
+
+// NodeHealthReport is the health of nodes
+type NodeHealthReport struct {
+	Window string `json:"window" yaml:"window"`
+	// GPUMetricsEnabled means the gpu health metrics are queried from prometheus
+	GPUMetricsEnabled bool             `json:"gpuMetricsEnabled" yaml:"gpuMetricsEnabled"`
+	Nodes             []NodeHealthInfo `json:"nodes" yaml:"nodes"`
+}
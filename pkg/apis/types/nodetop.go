@@ -0,0 +1,42 @@
+package types
+
+import "time"
+
+// NodeSortBy is the field which the nodes are sorted by
+type NodeSortBy string
+
+const (
+	// NodeSortByName sorts the nodes by name
+	NodeSortByName NodeSortBy = "name"
+	// NodeSortByGPUFree sorts the nodes by free gpus in descending order
+	NodeSortByGPUFree NodeSortBy = "gpu-free"
+	// NodeSortByGPUUtil sorts the nodes by the average gpu duty cycle in descending order,
+	// it requires prometheus
+	NodeSortByGPUUtil NodeSortBy = "gpu-util"
+)
+
+// NodeSortBySlice is the supported fields to sort the nodes
+var NodeSortBySlice = []NodeSortBy{NodeSortByGPUFree, NodeSortByGPUUtil, NodeSortByName}
+
+// TopNodeArgs describes which nodes are displayed and how to display them
+type TopNodeArgs struct {
+	NodeNames []string
+	NodeType  NodeType
+	// Selector filters the nodes by labels, like pool=a100
+	Selector string
+	// SortBy is the field to sort the nodes, the nodes are in the listed order if it is empty
+	SortBy      NodeSortBy
+	ShowDetails bool
+	ShowMetric  bool
+	// ShowPods lists the arena job pods on each node and the gpus allocated to them
+	ShowPods bool
+	// ShowHealth displays the health of nodes instead of the gpu allocation
+	ShowHealth bool
+	// HealthWindow is the time window in which the gpu health metrics are checked
+	HealthWindow time.Duration
+	// Refresh displays the node details continuously, it only supports one node
+	Refresh bool
+	// Watch redraws the nodes in place every interval
+	Watch    bool
+	Interval time.Duration
+}
@@ -19,6 +19,10 @@ const (
 	TritonServingJob ServingJobType = "triton-serving"
 	// CustomServingJob defines the custom serving job
 	CustomServingJob ServingJobType = "custom-serving"
+	// TorchServeServingJob defines the pytorch torchserve job
+	TorchServeServingJob ServingJobType = "torchserve-serving"
+	// ONNXRuntimeServingJob defines the onnx runtime server job
+	ONNXRuntimeServingJob ServingJobType = "onnxruntime-serving"
 	// AllServingJob represents all serving job type
 	AllServingJob ServingJobType = ""
 	// UnknownServingJob defines the unknown serving job
@@ -68,6 +72,16 @@ var ServingTypeMap = map[ServingJobType]ServingTypeInfo{
 		Alias:     "Seldon",
 		Shorthand: "seldon",
 	},
+	TorchServeServingJob: {
+		Name:      TorchServeServingJob,
+		Alias:     "TorchServe",
+		Shorthand: "torchserve",
+	},
+	ONNXRuntimeServingJob: {
+		Name:      ONNXRuntimeServingJob,
+		Alias:     "ONNXRuntime",
+		Shorthand: "onnxruntime",
+	},
 }
 
 // ServingJobInfo display serving job information
@@ -263,6 +277,26 @@ type TritonServingArgs struct {
 	CommonServingArgs `yaml:",inline"`
 }
 
+type TorchServeArgs struct {
+	ModelStore        string   `yaml:"modelStore"`     // --model-store
+	Models            []string `yaml:"models"`         // --models
+	InferencePort     int      `yaml:"inferencePort"`  // --inference-port
+	ManagementPort    int      `yaml:"managementPort"` // --management-port
+	MetricsPort       int      `yaml:"metricsPort"`    // --metrics-port
+	GrpcPort          int      `yaml:"grpcPort"`       // --grpc-port
+	ExtendCommand     string   `yaml:"extendCommand"`  // --extend-command
+	CommonServingArgs `yaml:",inline"`
+}
+
+type ONNXRuntimeServingArgs struct {
+	ModelPath         string `yaml:"modelPath"`     // --model-path
+	HttpPort          int    `yaml:"httpPort"`      // --http-port
+	GrpcPort          int    `yaml:"grpcPort"`      // --grpc-port
+	HttpThreads       int    `yaml:"httpThreads"`   // --http-threads
+	ExtendCommand     string `yaml:"extendCommand"` // --extend-command
+	CommonServingArgs `yaml:",inline"`
+}
+
 type ModelFormat struct {
 	// Name of the model format.
 	// +required
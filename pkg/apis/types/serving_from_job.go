@@ -0,0 +1,53 @@
+package types
+
+const (
+	// ServingSourceJobAnnotation records the name of training job which produced the served model
+	ServingSourceJobAnnotation = "arena.kubeflow.org/source-job"
+	// ServingSourceJobTypeAnnotation records the type of training job which produced the served model
+	ServingSourceJobTypeAnnotation = "arena.kubeflow.org/source-job-type"
+	// ServingSourceJobUIDAnnotation records the uid of training job which produced the served model
+	ServingSourceJobUIDAnnotation = "arena.kubeflow.org/source-job-uid"
+)
+
+// ServingFromJobArgs defines the args of serving the model produced by a training job
+type ServingFromJobArgs struct {
+	// Name is the serving job name, default to the training job name
+	Name string `json:"name" yaml:"name"`
+	// Namespace is the namespace of training job and serving job
+	Namespace string `json:"namespace" yaml:"namespace"`
+	// Version is the serving job version
+	Version string `json:"version" yaml:"version"`
+	// Type is the serving job type, only triton, tf and kserve are supported
+	Type ServingJobType `json:"type" yaml:"type"`
+	// JobName is the name of training job
+	JobName string `json:"jobName" yaml:"jobName"`
+	// JobType is the type of training job, it can be empty
+	JobType TrainingJobType `json:"jobType" yaml:"jobType"`
+	// ModelPVC is the pvc of training job which stores the model, it can be empty if the job mounts only one pvc
+	ModelPVC string `json:"modelPVC" yaml:"modelPVC"`
+	// ModelSubpath is the path of model relative to the root of pvc
+	ModelSubpath string `json:"modelSubpath" yaml:"modelSubpath"`
+	// ModelName is the model name of tensorflow serving, default to the training job name
+	ModelName string `json:"modelName" yaml:"modelName"`
+	// ModelFormat is the model format of kserve, like name:version
+	ModelFormat string `json:"modelFormat" yaml:"modelFormat"`
+	// Image is the serving image, the default image of serving type is used if it is empty
+	Image string `json:"image" yaml:"image"`
+	// GPUCount is the gpu count of each serving replica
+	GPUCount int `json:"gpuCount" yaml:"gpuCount"`
+	// Replicas is the replicas of serving job
+	Replicas int `json:"replicas" yaml:"replicas"`
+}
+
+// TrainingJobSource is the settings of a training job which are reused by the jobs built on its outputs
+type TrainingJobSource struct {
+	Name      string          `json:"name" yaml:"name"`
+	Namespace string          `json:"namespace" yaml:"namespace"`
+	Type      TrainingJobType `json:"type" yaml:"type"`
+	UID       string          `json:"uid" yaml:"uid"`
+	Status    string          `json:"status" yaml:"status"`
+	// DataSet is the pvcs mounted by training job, the key is pvc name and the value is mount path
+	DataSet          map[string]string `json:"dataset" yaml:"dataset"`
+	ImagePullSecrets []string          `json:"imagePullSecrets" yaml:"imagePullSecrets"`
+	NodeSelectors    map[string]string `json:"nodeSelectors" yaml:"nodeSelectors"`
+}
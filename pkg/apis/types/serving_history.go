@@ -0,0 +1,42 @@
+package types
+
+// ServingRevision records the spec of a serving job at the time it was updated
+type ServingRevision struct {
+	// Revision is the sequence number of the revision,it starts from 1
+	Revision int `json:"revision" yaml:"revision"`
+	// Version is the serving version which the revision belongs to
+	Version string `json:"version" yaml:"version"`
+	// ChangeCause describes the operation which created the revision
+	ChangeCause string `json:"changeCause" yaml:"changeCause"`
+	// Image is the image of the serving container
+	Image string `json:"image" yaml:"image"`
+	// Command is the command of the serving container
+	Command []string `json:"command" yaml:"command"`
+	// Args is the args of the serving container
+	Args []string `json:"args" yaml:"args"`
+	// Envs is the environment variables of the serving container
+	Envs map[string]string `json:"envs" yaml:"envs"`
+	// Resources is the resource limits of the serving container
+	Resources map[string]string `json:"resources" yaml:"resources"`
+	// StorageUri is the model storage uri,only for kserve
+	StorageUri string `json:"storageUri,omitempty" yaml:"storageUri,omitempty"`
+	// Replicas is the desired replicas of the serving job
+	Replicas int32 `json:"replicas" yaml:"replicas"`
+	// CreationTimestamp is the time when the revision was recorded
+	CreationTimestamp int64 `json:"creationTimestamp" yaml:"creationTimestamp"`
+	// Spec stores the raw spec which is used to rollback
+	Spec string `json:"spec,omitempty" yaml:"-"`
+}
+
+// ServingRevisionDiff describes a difference between two revisions
+type ServingRevisionDiff struct {
+	Field string `json:"field" yaml:"field"`
+	From  string `json:"from" yaml:"from"`
+	To    string `json:"to" yaml:"to"`
+}
+
+// ServingRevisionInfo is used to display a revision and its diffs with the previous one
+type ServingRevisionInfo struct {
+	ServingRevision `json:",inline" yaml:",inline"`
+	Diffs           []ServingRevisionDiff `json:"diffs" yaml:"diffs"`
+}
@@ -0,0 +1,72 @@
+package types
+
+import "time"
+
+// ServingLoadTestMode defines where the load test runs
+type ServingLoadTestMode string
+
+const (
+	// LocalLoadTestMode sends requests from the local machine through port-forwarding
+	LocalLoadTestMode ServingLoadTestMode = "local"
+	// JobLoadTestMode sends requests from an arena-managed job in the cluster
+	JobLoadTestMode ServingLoadTestMode = "job"
+)
+
+// ServingLoadTestArgs defines the args of load testing a serving job
+type ServingLoadTestArgs struct {
+	// ServingPredictArgs defines the target serving job and the request
+	ServingPredictArgs `json:",inline" yaml:",inline"`
+	// QPS is the target requests per second,0 means no limit
+	QPS int `json:"qps" yaml:"qps"`
+	// Duration is the duration of the load test
+	Duration time.Duration `json:"duration" yaml:"duration"`
+	// Concurrency is the number of concurrent connections
+	Concurrency int `json:"concurrency" yaml:"concurrency"`
+	// Mode defines where the load test runs
+	Mode ServingLoadTestMode `json:"mode" yaml:"mode"`
+	// Image is the load test image used by the job mode
+	Image string `json:"image" yaml:"image"`
+}
+
+// ServingLatencyStats is the latency statistics in milliseconds
+type ServingLatencyStats struct {
+	Min  float64 `json:"min" yaml:"min"`
+	Mean float64 `json:"mean" yaml:"mean"`
+	Max  float64 `json:"max" yaml:"max"`
+	P50  float64 `json:"p50" yaml:"p50"`
+	P90  float64 `json:"p90" yaml:"p90"`
+	P99  float64 `json:"p99" yaml:"p99"`
+}
+
+// ServingLatencyBucket is a bucket of the latency histogram
+type ServingLatencyBucket struct {
+	// UpperBound is the upper bound of the bucket in milliseconds,0 means +Inf
+	UpperBound float64 `json:"upperBound" yaml:"upperBound"`
+	// Count is the number of requests in the bucket
+	Count int `json:"count" yaml:"count"`
+}
+
+// ServingLoadTestResult is the report of a load test
+type ServingLoadTestResult struct {
+	Name        string              `json:"name" yaml:"name"`
+	Namespace   string              `json:"namespace" yaml:"namespace"`
+	Version     string              `json:"version" yaml:"version"`
+	Type        string              `json:"type" yaml:"type"`
+	Mode        ServingLoadTestMode `json:"mode" yaml:"mode"`
+	URL         string              `json:"url" yaml:"url"`
+	QPS         int                 `json:"qps" yaml:"qps"`
+	Concurrency int                 `json:"concurrency" yaml:"concurrency"`
+	// Duration is the actual duration in seconds
+	Duration float64 `json:"duration" yaml:"duration"`
+	// Requests is the number of sent requests
+	Requests int `json:"requests" yaml:"requests"`
+	// Errors is the number of failed requests
+	Errors int `json:"errors" yaml:"errors"`
+	// ErrorCodes counts the failed requests by the status code,
+	// the requests which failed without responses are counted as 'network'
+	ErrorCodes map[string]int `json:"errorCodes" yaml:"errorCodes"`
+	// Throughput is the requests per second
+	Throughput float64                `json:"throughput" yaml:"throughput"`
+	Latency    ServingLatencyStats    `json:"latency" yaml:"latency"`
+	Histogram  []ServingLatencyBucket `json:"histogram" yaml:"histogram"`
+}
@@ -0,0 +1,65 @@
+package types
+
+// ServingPredictProtocol defines the inference protocol used to send predict requests
+type ServingPredictProtocol string
+
+const (
+	// V1PredictProtocol is the tensorflow serving REST api and kserve v1 protocol,the path is /v1/models/<model>:predict
+	V1PredictProtocol ServingPredictProtocol = "v1"
+	// V2PredictProtocol is the triton/kserve v2 inference protocol,the path is /v2/models/<model>/infer
+	V2PredictProtocol ServingPredictProtocol = "v2"
+	// SeldonPredictProtocol is the seldon core api,the path is /api/v1.0/predictions
+	SeldonPredictProtocol ServingPredictProtocol = "seldon"
+	// OpenAIPredictProtocol is the openai style api,the path is /v1/chat/completions
+	OpenAIPredictProtocol ServingPredictProtocol = "openai"
+	// TorchServePredictProtocol is the torchserve inference api,the path is /predictions/<model>
+	TorchServePredictProtocol ServingPredictProtocol = "torchserve"
+	// ONNXRuntimePredictProtocol is the onnx runtime server api,the path is /v1/models/<model>/versions/1:predict
+	ONNXRuntimePredictProtocol ServingPredictProtocol = "onnxruntime"
+	// UnknownPredictProtocol means the protocol should be detected by the serving type
+	UnknownPredictProtocol ServingPredictProtocol = ""
+)
+
+// ServingPredictArgs defines the args of sending a predict request to a serving job
+type ServingPredictArgs struct {
+	// Namespace is the namespace of the serving job
+	Namespace string `json:"namespace" yaml:"namespace"`
+	// Name is the serving job name
+	Name string `json:"name" yaml:"name"`
+	// Version is the serving job version
+	Version string `json:"version" yaml:"version"`
+	// Type is the serving job type
+	Type ServingJobType `json:"type" yaml:"type"`
+	// Instance is the pod which the request is forwarded to,it is picked automatically if not set
+	Instance string `json:"instance" yaml:"instance"`
+	// Protocol is the inference protocol,it is detected by the serving type if not set
+	Protocol ServingPredictProtocol `json:"protocol" yaml:"protocol"`
+	// ModelName is the model name used to build the request path
+	ModelName string `json:"modelName" yaml:"modelName"`
+	// Path overrides the request path which is built by the protocol
+	Path string `json:"path" yaml:"path"`
+	// Port is the container port to send request to,it is detected if not set
+	Port int `json:"port" yaml:"port"`
+	// Endpoint sends the request to the address directly instead of port-forwarding,eg: http://1.2.3.4:8501
+	Endpoint string `json:"endpoint" yaml:"endpoint"`
+	// Data is the request body
+	Data []byte `json:"-" yaml:"-"`
+	// Headers are the extra request headers
+	Headers map[string]string `json:"headers" yaml:"headers"`
+	// Timeout is the request timeout in seconds
+	Timeout int `json:"timeout" yaml:"timeout"`
+}
+
+// ServingPredictResult is the result of a predict request
+type ServingPredictResult struct {
+	// URL is the request url
+	URL string `json:"url" yaml:"url"`
+	// StatusCode is the http status code
+	StatusCode int `json:"statusCode" yaml:"statusCode"`
+	// Status is the http status
+	Status string `json:"status" yaml:"status"`
+	// Latency is the request latency in milliseconds
+	Latency float64 `json:"latency" yaml:"latency"`
+	// Body is the response body
+	Body string `json:"body" yaml:"body"`
+}
@@ -0,0 +1,34 @@
+package types
+
+// ServingInstanceTopInfo is the resource usage and request metrics of a serving instance
+type ServingInstanceTopInfo struct {
+	Name   string `json:"name" yaml:"name"`
+	Status string `json:"status" yaml:"status"`
+	NodeIP string `json:"nodeIP" yaml:"nodeIP"`
+	// CPUUsage is the used cpu cores
+	CPUUsage float64 `json:"cpuUsage" yaml:"cpuUsage"`
+	// MemoryUsage is the used memory in bytes
+	MemoryUsage float64 `json:"memoryUsage" yaml:"memoryUsage"`
+	RequestGPUs float64 `json:"requestGPUs" yaml:"requestGPUs"`
+	// GPUMetrics is the gpu metrics of the instance,the key is gpu device index
+	GPUMetrics map[string]GpuMetric `json:"gpuMetrics" yaml:"gpuMetrics"`
+	// HasRequestMetrics is true when the request metrics are scraped from the metrics port
+	HasRequestMetrics bool `json:"hasRequestMetrics" yaml:"hasRequestMetrics"`
+	// QPS is the requests per second
+	QPS float64 `json:"qps" yaml:"qps"`
+	// Latency is the average request latency in milliseconds
+	Latency float64 `json:"latency" yaml:"latency"`
+}
+
+// ServingJobTopInfo is the resource usage and request metrics of a serving job
+type ServingJobTopInfo struct {
+	Name               string                   `json:"name" yaml:"name"`
+	Namespace          string                   `json:"namespace" yaml:"namespace"`
+	Type               string                   `json:"type" yaml:"type"`
+	Version            string                   `json:"version" yaml:"version"`
+	Age                string                   `json:"age" yaml:"age"`
+	DesiredInstances   int                      `json:"desiredInstances" yaml:"desiredInstances"`
+	AvailableInstances int                      `json:"availableInstances" yaml:"availableInstances"`
+	RequestGPUs        float64                  `json:"requestGPUs" yaml:"requestGPUs"`
+	Instances          []ServingInstanceTopInfo `json:"instances" yaml:"instances"`
+}
@@ -45,6 +45,13 @@ type CommonSubmitArgs struct {
 	// GPUCount stores the gpu count of the job needs,match option --gpus
 	GPUCount int `yaml:"gpuCount"`
 
+	// GPUMIGProfile stores the mig profile of gpu instances which the job requests,match option --gpu-mig-profile
+	GPUMIGProfile string `yaml:"gpuMIGProfile"`
+
+	// AcceleratorResource stores the resource name of registered accelerator which the gpus of job are requested from,
+	// match option --accelerator
+	AcceleratorResource string `yaml:"acceleratorResource"`
+
 	// Envs stores the envs of container in job, match option --env
 	Envs map[string]string `yaml:"envs"`
 
@@ -132,6 +132,12 @@ type TrainingJobInfo struct {
 
 	// CreationTimestamp stores the creation timestamp of job
 	CreationTimestamp int64 `json:"creationTimestamp" yaml:"creationTimestamp"`
+
+	// GPUHistory stores the gpu utilization of the whole run, it is only set when it is required
+	GPUHistory *TrainingJobGPUHistory `json:"gpuHistory,omitempty" yaml:"gpuHistory,omitempty"`
+
+	// XIDErrors stores the gpu xid errors reported by the nodes of failed job during its lifetime
+	XIDErrors []GPUXIDError `json:"xidErrors,omitempty" yaml:"xidErrors,omitempty"`
 }
 
 // TrainingJobStatus defines all the kinds of JobStatus
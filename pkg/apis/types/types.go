@@ -15,6 +15,8 @@ const (
 	JsonFormat FormatStyle = "json"
 	// Yaml defines the yaml format
 	YamlFormat FormatStyle = "yaml"
+	// CSV defines the csv format, only used by reports
+	CSVFormat FormatStyle = "csv"
 	// Unknwon defines the unknown format
 	UnknownFormat FormatStyle = "unknown"
 )
@@ -35,6 +35,17 @@ type UpdateTritonServingArgs struct {
 	CommonUpdateServingArgs `yaml:",inline"`
 }
 
+type UpdateTorchServeArgs struct {
+	ModelStore              string   `yaml:"modelStore"` // --model-store
+	Models                  []string `yaml:"models"`     // --models
+	CommonUpdateServingArgs `yaml:",inline"`
+}
+
+type UpdateONNXRuntimeServingArgs struct {
+	ModelPath               string `yaml:"modelPath"` // --model-path
+	CommonUpdateServingArgs `yaml:",inline"`
+}
+
 type UpdateCustomServingArgs struct {
 	CommonUpdateServingArgs `yaml:",inline"`
 }
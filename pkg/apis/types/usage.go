@@ -0,0 +1,76 @@
+package types
+
+import "time"
+
+// UsageGroupBy defines how the usage records are aggregated
+type UsageGroupBy string
+
+const (
+	// UsageGroupByNamespace aggregates the usage by namespace
+	UsageGroupByNamespace UsageGroupBy = "namespace"
+	// UsageGroupByUser aggregates the usage by the owner of jobs
+	UsageGroupByUser UsageGroupBy = "user"
+	// UsageGroupByJobType aggregates the usage by the training job type or serving job type
+	UsageGroupByJobType UsageGroupBy = "job-type"
+)
+
+const (
+	// UsageRecordLabel is the label of configmaps which store the usage records
+	UsageRecordLabel = "arena.kubeflow.org/usage-record"
+	// UsageRecordDateLabel is the date of jobs started, the records are sharded by it
+	UsageRecordDateLabel = "arena.kubeflow.org/usage-date"
+	// UsageRecordConfigMapPrefix is the name prefix of configmaps which store the usage records
+	UsageRecordConfigMapPrefix = "arena-usage"
+)
+
+// JobUsageRecord is the resources requested by a job in its lifetime
+type JobUsageRecord struct {
+	Name      string `json:"name" yaml:"name"`
+	Namespace string `json:"namespace" yaml:"namespace"`
+	Uid       string `json:"uid" yaml:"uid"`
+	Owner     string `json:"owner" yaml:"owner"`
+	// Kind is training or serving
+	Kind string `json:"kind" yaml:"kind"`
+	// Type is the training job type or serving job type
+	Type   string `json:"type" yaml:"type"`
+	Status string `json:"status" yaml:"status"`
+	// StartTime is the unix timestamp when the job started
+	StartTime int64 `json:"startTime" yaml:"startTime"`
+	// EndTime is the unix timestamp when the job finished, or the last time when the job is seen
+	EndTime int64 `json:"endTime" yaml:"endTime"`
+	// Finished means the record will not be changed
+	Finished bool `json:"finished" yaml:"finished"`
+	// GPUs is the gpus requested by all instances of job
+	GPUs float64 `json:"gpus" yaml:"gpus"`
+	// CPUs is the cpu cores requested by all instances of job
+	CPUs float64 `json:"cpus" yaml:"cpus"`
+	// MemoryGB is the memory(GiB) requested by all instances of job
+	MemoryGB float64 `json:"memoryGB" yaml:"memoryGB"`
+}
+
+// UsageReportArgs defines the args of generating a usage report
+type UsageReportArgs struct {
+	Namespace     string       `json:"namespace" yaml:"namespace"`
+	AllNamespaces bool         `json:"allNamespaces" yaml:"allNamespaces"`
+	From          time.Time    `json:"from" yaml:"from"`
+	To            time.Time    `json:"to" yaml:"to"`
+	GroupBy       UsageGroupBy `json:"groupBy" yaml:"groupBy"`
+}
+
+// UsageReportItem is the usage of a group in the report
+type UsageReportItem struct {
+	Group         string  `json:"group" yaml:"group"`
+	Jobs          int     `json:"jobs" yaml:"jobs"`
+	GPUHours      float64 `json:"gpuHours" yaml:"gpuHours"`
+	CPUCoreHours  float64 `json:"cpuCoreHours" yaml:"cpuCoreHours"`
+	MemoryGBHours float64 `json:"memoryGBHours" yaml:"memoryGBHours"`
+}
+
+// UsageReport is the usage of resources aggregated by groups in the time range
+type UsageReport struct {
+	From    int64             `json:"from" yaml:"from"`
+	To      int64             `json:"to" yaml:"to"`
+	GroupBy UsageGroupBy      `json:"groupBy" yaml:"groupBy"`
+	Items   []UsageReportItem `json:"items" yaml:"items"`
+	Total   UsageReportItem   `json:"total" yaml:"total"`
+}
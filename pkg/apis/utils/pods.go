@@ -10,6 +10,7 @@ import (
 
 	"encoding/json"
 
+	"github.com/kubeflow/arena/pkg/apis/config"
 	"github.com/kubeflow/arena/pkg/apis/types"
 	v1 "k8s.io/api/core/v1"
 
@@ -26,15 +27,35 @@ func CPUCountInPod(pod *v1.Pod) float64 {
 	return total
 }
 
+// GPUCountInPod returns the gpus requested by the pod, the resources of all registered accelerators are counted
 func GPUCountInPod(pod *v1.Pod) int {
 	total := int64(0)
-	for _, count := range ResourceInContainers(pod, types.NvidiaGPUResourceName) {
-		c := count.(int64)
-		total += c
+	for _, accelerator := range config.GetAccelerators() {
+		for _, count := range ResourceInContainers(pod, accelerator.ResourceName) {
+			c := count.(int64)
+			total += c
+		}
 	}
 	return int(total)
 }
 
+// GPUCountInContainers returns the gpus requested by the containers, the resources of all registered
+// accelerators and the gpus of gpu topology are counted
+func GPUCountInContainers(containers []v1.Container) int64 {
+	total := int64(0)
+	for _, container := range containers {
+		for _, accelerator := range config.GetAccelerators() {
+			if val, ok := container.Resources.Limits[v1.ResourceName(accelerator.ResourceName)]; ok {
+				total += val.Value()
+			}
+		}
+		if val, ok := container.Resources.Limits[v1.ResourceName(types.AliyunGPUResourceName)]; ok {
+			total += val.Value()
+		}
+	}
+	return total
+}
+
 func AliyunGPUCountInPod(pod *v1.Pod) int {
 	total := int64(0)
 	for _, count := range ResourceInContainers(pod, types.AliyunGPUResourceName) {
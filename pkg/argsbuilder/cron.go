@@ -0,0 +1,24 @@
+package argsbuilder
+
+import (
+	"fmt"
+
+	"github.com/kubeflow/arena/pkg/apis/types"
+	"github.com/spf13/cobra"
+)
+
+// addCronCommandFlags adds the flags of cron task which are shared by all cron job types
+func addCronCommandFlags(command *cobra.Command, args *types.CommonCronArgs) {
+	command.Flags().StringVar(&args.Schedule, "schedule", "", "the schedule of cron task")
+	command.Flags().StringVar(&args.ConcurrencyPolicy, "concurrency-policy", "Allow", "specifies how to treat concurrent executions of a task")
+	command.Flags().BoolVar(&args.Suspend, "suspend", false, "if suspend the cron task")
+	command.Flags().StringVar(&args.Deadline, "deadline", "", "the timestamp that a cron job can keep scheduling util then")
+	command.Flags().IntVar(&args.HistoryLimit, "history-limit", 10, "the number of finished job history to retain")
+}
+
+func checkCronArgs(args *types.CommonCronArgs) error {
+	if args.Schedule == "" {
+		return fmt.Errorf("--schedule must be set ")
+	}
+	return nil
+}
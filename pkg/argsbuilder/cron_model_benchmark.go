@@ -0,0 +1,78 @@
+package argsbuilder
+
+import (
+	"fmt"
+	"github.com/kubeflow/arena/pkg/apis/types"
+	"github.com/spf13/cobra"
+	"reflect"
+	"strings"
+)
+
+type CronModelBenchmarkArgsBuilder struct {
+	args        *types.CronModelBenchmarkArgs
+	argValues   map[string]interface{}
+	subBuilders map[string]ArgsBuilder
+}
+
+func NewCronModelBenchmarkArgsBuilder(args *types.CronModelBenchmarkArgs) ArgsBuilder {
+	c := &CronModelBenchmarkArgsBuilder{
+		args:        args,
+		argValues:   map[string]interface{}{},
+		subBuilders: map[string]ArgsBuilder{},
+	}
+	c.AddSubBuilder(
+		NewModelBenchmarkArgsBuilder(&c.args.ModelBenchmarkArgs),
+	)
+	return c
+}
+
+func (c *CronModelBenchmarkArgsBuilder) GetName() string {
+	items := strings.Split(fmt.Sprintf("%v", reflect.TypeOf(*c)), ".")
+	return items[len(items)-1]
+}
+
+func (c *CronModelBenchmarkArgsBuilder) AddSubBuilder(builders ...ArgsBuilder) ArgsBuilder {
+	for _, b := range builders {
+		c.subBuilders[b.GetName()] = b
+	}
+	return c
+}
+
+func (c *CronModelBenchmarkArgsBuilder) AddArgValue(key string, value interface{}) ArgsBuilder {
+	for name := range c.subBuilders {
+		c.subBuilders[name].AddArgValue(key, value)
+	}
+	c.argValues[key] = value
+	return c
+}
+
+func (c *CronModelBenchmarkArgsBuilder) AddCommandFlags(command *cobra.Command) {
+	for name := range c.subBuilders {
+		c.subBuilders[name].AddCommandFlags(command)
+	}
+	// cron task arguments
+	addCronCommandFlags(command, &c.args.CommonCronArgs)
+}
+
+func (c *CronModelBenchmarkArgsBuilder) PreBuild() error {
+	for name := range c.subBuilders {
+		if err := c.subBuilders[name].PreBuild(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *CronModelBenchmarkArgsBuilder) Build() error {
+	for name := range c.subBuilders {
+		if err := c.subBuilders[name].Build(); err != nil {
+			return err
+		}
+	}
+
+	if err := checkCronArgs(&c.args.CommonCronArgs); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package argsbuilder
+
+import (
+	"fmt"
+	"github.com/kubeflow/arena/pkg/apis/types"
+	"github.com/spf13/cobra"
+	"reflect"
+	"strings"
+)
+
+type CronMPIJobArgsBuilder struct {
+	args        *types.CronMPIJobArgs
+	argValues   map[string]interface{}
+	subBuilders map[string]ArgsBuilder
+}
+
+func NewCronMPIJobArgsBuilder(args *types.CronMPIJobArgs) ArgsBuilder {
+	args.TrainingType = types.MPITrainingJob
+	c := &CronMPIJobArgsBuilder{
+		args:        args,
+		argValues:   map[string]interface{}{},
+		subBuilders: map[string]ArgsBuilder{},
+	}
+	c.AddSubBuilder(
+		NewSubmitMPIJobArgsBuilder(&c.args.SubmitMPIJobArgs),
+	)
+	return c
+}
+
+func (c *CronMPIJobArgsBuilder) GetName() string {
+	items := strings.Split(fmt.Sprintf("%v", reflect.TypeOf(*c)), ".")
+	return items[len(items)-1]
+}
+
+func (c *CronMPIJobArgsBuilder) AddSubBuilder(builders ...ArgsBuilder) ArgsBuilder {
+	for _, b := range builders {
+		c.subBuilders[b.GetName()] = b
+	}
+	return c
+}
+
+func (c *CronMPIJobArgsBuilder) AddArgValue(key string, value interface{}) ArgsBuilder {
+	for name := range c.subBuilders {
+		c.subBuilders[name].AddArgValue(key, value)
+	}
+	c.argValues[key] = value
+	return c
+}
+
+func (c *CronMPIJobArgsBuilder) AddCommandFlags(command *cobra.Command) {
+	for name := range c.subBuilders {
+		c.subBuilders[name].AddCommandFlags(command)
+	}
+	// cron task arguments
+	addCronCommandFlags(command, &c.args.CommonCronArgs)
+}
+
+func (c *CronMPIJobArgsBuilder) PreBuild() error {
+	for name := range c.subBuilders {
+		if err := c.subBuilders[name].PreBuild(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *CronMPIJobArgsBuilder) Build() error {
+	for name := range c.subBuilders {
+		if err := c.subBuilders[name].Build(); err != nil {
+			return err
+		}
+	}
+
+	if err := checkCronArgs(&c.args.CommonCronArgs); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -9,17 +9,17 @@ import (
 )
 
 type CronTFJobArgsBuilder struct {
-	args         *types.CronTFJobArgs
-	argValues    map[string]interface{}
-	subBuilders  map[string]ArgsBuilder
+	args        *types.CronTFJobArgs
+	argValues   map[string]interface{}
+	subBuilders map[string]ArgsBuilder
 }
 
 func NewCronTFJobArgsBuilder(args *types.CronTFJobArgs) ArgsBuilder {
 	args.TrainingType = types.TFTrainingJob
 	c := &CronTFJobArgsBuilder{
-		args:         args,
-		argValues:    map[string]interface{}{},
-		subBuilders:  map[string]ArgsBuilder{},
+		args:        args,
+		argValues:   map[string]interface{}{},
+		subBuilders: map[string]ArgsBuilder{},
 	}
 	c.AddSubBuilder(
 		NewSubmitTFJobArgsBuilder(&c.args.SubmitTFJobArgs),
@@ -52,11 +52,7 @@ func (c *CronTFJobArgsBuilder) AddCommandFlags(command *cobra.Command) {
 		c.subBuilders[name].AddCommandFlags(command)
 	}
 	// cron task arguments
-	command.Flags().StringVar(&c.args.Schedule, "schedule", "", "the schedule of cron task")
-	command.Flags().StringVar(&c.args.ConcurrencyPolicy, "concurrency-policy", "Allow", "specifies how to treat concurrent executions of a task")
-	command.Flags().BoolVar(&c.args.Suspend, "suspend", false, "if suspend the cron task")
-	command.Flags().StringVar(&c.args.Deadline, "deadline", "", "the timestamp that a cron job can keep scheduling util then")
-	command.Flags().IntVar(&c.args.HistoryLimit, "history-limit", 10, "the number of finished job history to retain")
+	addCronCommandFlags(command, &c.args.CommonCronArgs)
 }
 
 func (c *CronTFJobArgsBuilder) PreBuild() error {
@@ -75,16 +71,9 @@ func (c *CronTFJobArgsBuilder) Build() error {
 		}
 	}
 
-	if err := c.check(); err != nil {
+	if err := checkCronArgs(&c.args.CommonCronArgs); err != nil {
 		return err
 	}
 
 	return nil
 }
-
-func (c *CronTFJobArgsBuilder) check() error {
-	if c.args.Schedule == "" {
-		return fmt.Errorf("--schedule must be set ")
-	}
-	return nil
-}
\ No newline at end of file
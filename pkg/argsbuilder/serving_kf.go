@@ -4,7 +4,7 @@
 // you may not use this file except in compliance with the License.
 // You may obtain a copy of the License at
 //
-//       http://www.apache.org/licenses/LICENSE-2.0
+//	http://www.apache.org/licenses/LICENSE-2.0
 //
 // Unless required by applicable law or agreed to in writing, software
 // distributed under the License is distributed on an "AS IS" BASIS,
@@ -66,7 +66,7 @@ func (s *KFServingArgsBuilder) AddCommandFlags(command *cobra.Command) {
 		s.subBuilders[name].AddCommandFlags(command)
 	}
 	command.Flags().StringVar(&s.args.ModelType, "model-type", "custom", "the type of serving model,default to custom type")
-	command.Flags().StringVar(&s.args.StorageUri, "storage-uri", "", "the uri direct to the model file")
+	command.Flags().StringVar(&s.args.StorageUri, "storage-uri", "", "the uri direct to the model file, or a registered model like registry://<name>@<version>")
 	command.Flags().IntVar(&s.args.CanaryPercent, "canary-percent", 0, "the percent of the desired canary")
 	command.Flags().IntVar(&s.args.Port, "port", 0, "the port of the application listens in the custom image")
 }
@@ -77,6 +77,9 @@ func (s *KFServingArgsBuilder) PreBuild() error {
 			return err
 		}
 	}
+	if err := s.setRegistryModel(); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -98,3 +101,13 @@ func (s *KFServingArgsBuilder) validate() (err error) {
 	}
 	return nil
 }
+
+// setRegistryModel resolves --storage-uri when it refers to a registered model
+func (s *KFServingArgsBuilder) setRegistryModel() error {
+	storageUri, err := resolveRegistryModelURI(&s.args.CommonServingArgs, s.args.StorageUri)
+	if err != nil {
+		return err
+	}
+	s.args.StorageUri = storageUri
+	return nil
+}
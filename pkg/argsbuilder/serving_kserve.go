@@ -78,7 +78,7 @@ func (s *KServeArgsBuilder) AddCommandFlags(command *cobra.Command) {
 
 	command.Flags().StringVar(&modelFormat, "model-format", "", `the ModelFormat being served. usage: "--model-format=name" or "--model-format=name:version"`)
 	command.Flags().StringVar(&s.args.Runtime, "runtime", "", "the ClusterServingRuntime/ServingRuntime name to use for deployment.")
-	command.Flags().StringVar(&s.args.StorageUri, "storage-uri", "", "the uri direct to the model file")
+	command.Flags().StringVar(&s.args.StorageUri, "storage-uri", "", "the uri direct to the model file, or a registered model like registry://<name>@<version>")
 	command.Flags().IntVar(&s.args.Port, "port", 0, "the port of tcp listening port, default is 8080 in kserve")
 	command.Flags().StringVar(&s.args.RuntimeVersion, "runtime-version", "", "the predictor docker image")
 	command.Flags().StringVar(&s.args.ProtocolVersion, "protocol-version", "", "the protocol version to use by the predictor (i.e. v1 or v2 or grpc-v1 or grpc-v2)")
@@ -101,6 +101,9 @@ func (s *KServeArgsBuilder) PreBuild() error {
 			return err
 		}
 	}
+	if err := s.setRegistryModel(); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -173,3 +176,13 @@ func (s *KServeArgsBuilder) setCanaryTrafficPercent() error {
 	}
 	return nil
 }
+
+// setRegistryModel resolves --storage-uri when it refers to a registered model
+func (s *KServeArgsBuilder) setRegistryModel() error {
+	storageUri, err := resolveRegistryModelURI(&s.args.CommonServingArgs, s.args.StorageUri)
+	if err != nil {
+		return err
+	}
+	s.args.StorageUri = storageUri
+	return nil
+}
@@ -0,0 +1,64 @@
+package argsbuilder
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/kubeflow/arena/pkg/apis/types"
+	"github.com/kubeflow/arena/pkg/model/registry"
+	log "github.com/sirupsen/logrus"
+)
+
+// registryModelMountRoot is the parent mount path of the pvc which stores the registered model
+const registryModelMountRoot = "/mnt/models"
+
+// resolveRegistryModelPath transfers the model uri like registry://<name>@<version> to the model path
+// in the serving container, the pvc of registered model is mounted if it is not mounted by --data
+func resolveRegistryModelPath(args *types.CommonServingArgs, modelPath string) (string, *types.RegisteredModelInfo, error) {
+	if !registry.IsModelRegistryURI(modelPath) {
+		return modelPath, nil, nil
+	}
+	model, err := registry.ResolveModelURI(args.Namespace, modelPath)
+	if err != nil {
+		return "", nil, err
+	}
+
+	storageURI := model.StorageURI
+	switch {
+	case strings.HasPrefix(storageURI, types.PVCStorageScheme):
+		items := strings.SplitN(strings.TrimPrefix(storageURI, types.PVCStorageScheme), "/", 2)
+		pvcName, subPath := items[0], ""
+		if len(items) == 2 {
+			subPath = items[1]
+		}
+		if args.ModelDirs == nil {
+			args.ModelDirs = map[string]string{}
+		}
+		mountPath, ok := args.ModelDirs[pvcName]
+		if !ok {
+			mountPath = path.Join(registryModelMountRoot, pvcName)
+			args.ModelDirs[pvcName] = mountPath
+		}
+		storageURI = path.Join(mountPath, subPath)
+	case strings.HasPrefix(storageURI, "/"):
+	default:
+		return "", nil, fmt.Errorf("the storage uri %s of model %s version %s can not be mounted, only pvc:// or the path in the image is supported", storageURI, model.Name, model.Version)
+	}
+	log.Infof("use the version %s of registered model %s, the model path is %s", model.Version, model.Name, storageURI)
+	return storageURI, model, nil
+}
+
+// resolveRegistryModelURI transfers the model uri like registry://<name>@<version> to the storage uri
+// of the registered model, it is used by the serving types which download the model by themselves
+func resolveRegistryModelURI(args *types.CommonServingArgs, uri string) (string, error) {
+	if !registry.IsModelRegistryURI(uri) {
+		return uri, nil
+	}
+	model, err := registry.ResolveModelURI(args.Namespace, uri)
+	if err != nil {
+		return "", err
+	}
+	log.Infof("use the version %s of registered model %s, the storage uri is %s", model.Version, model.Name, model.StorageURI)
+	return model.StorageURI, nil
+}
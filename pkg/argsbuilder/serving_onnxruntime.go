@@ -0,0 +1,139 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License
+package argsbuilder
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/kubeflow/arena/pkg/apis/types"
+	"github.com/spf13/cobra"
+)
+
+const (
+	DefaultONNXRuntimeServingImage = "mcr.microsoft.com/onnxruntime/server:latest"
+)
+
+type ONNXRuntimeServingArgsBuilder struct {
+	args        *types.ONNXRuntimeServingArgs
+	argValues   map[string]interface{}
+	subBuilders map[string]ArgsBuilder
+}
+
+func NewONNXRuntimeServingArgsBuilder(args *types.ONNXRuntimeServingArgs) ArgsBuilder {
+	args.Type = types.ONNXRuntimeServingJob
+	s := &ONNXRuntimeServingArgsBuilder{
+		args:        args,
+		argValues:   map[string]interface{}{},
+		subBuilders: map[string]ArgsBuilder{},
+	}
+	s.AddSubBuilder(
+		NewServingArgsBuilder(&s.args.CommonServingArgs),
+	)
+	s.AddArgValue("default-image", DefaultONNXRuntimeServingImage)
+	return s
+}
+
+func (s *ONNXRuntimeServingArgsBuilder) GetName() string {
+	items := strings.Split(fmt.Sprintf("%v", reflect.TypeOf(*s)), ".")
+	return items[len(items)-1]
+}
+
+func (s *ONNXRuntimeServingArgsBuilder) AddSubBuilder(builders ...ArgsBuilder) ArgsBuilder {
+	for _, b := range builders {
+		s.subBuilders[b.GetName()] = b
+	}
+	return s
+}
+
+func (s *ONNXRuntimeServingArgsBuilder) AddArgValue(key string, value interface{}) ArgsBuilder {
+	for name := range s.subBuilders {
+		s.subBuilders[name].AddArgValue(key, value)
+	}
+	s.argValues[key] = value
+	return s
+}
+
+func (s *ONNXRuntimeServingArgsBuilder) AddCommandFlags(command *cobra.Command) {
+	for name := range s.subBuilders {
+		s.subBuilders[name].AddCommandFlags(command)
+	}
+	command.Flags().StringVar(&s.args.ModelPath, "model-path", "", "the path of onnx model file in the container, or a registered model like registry://<name>@<version>")
+	command.Flags().IntVar(&s.args.HttpPort, "http-port", 8001, "the port of http serving server")
+	command.Flags().IntVar(&s.args.GrpcPort, "grpc-port", 50051, "the port of grpc serving server")
+	command.Flags().IntVar(&s.args.HttpThreads, "http-threads", 0, "the number of http threads,default is the number of cpu cores")
+	command.Flags().StringVar(&s.args.Command, "command", "", "the command will inject to container's command.")
+	command.Flags().StringVar(&s.args.ExtendCommand, "extend-command", "", "the command will attach to server's command.")
+}
+
+func (s *ONNXRuntimeServingArgsBuilder) PreBuild() error {
+	for name := range s.subBuilders {
+		if err := s.subBuilders[name].PreBuild(); err != nil {
+			return err
+		}
+	}
+	if err := s.setRegistryModel(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *ONNXRuntimeServingArgsBuilder) Build() error {
+	for name := range s.subBuilders {
+		if err := s.subBuilders[name].Build(); err != nil {
+			return err
+		}
+	}
+	if err := s.checkPortsIsOk(); err != nil {
+		return err
+	}
+	if err := s.validate(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *ONNXRuntimeServingArgsBuilder) validate() (err error) {
+	if s.args.Image == "" {
+		return fmt.Errorf("image must be specified")
+	}
+	if s.args.Command == "" && s.args.ModelPath == "" {
+		return fmt.Errorf("--model-path must be specified")
+	}
+	if s.args.HttpThreads < 0 {
+		return fmt.Errorf("--http-threads must not be negative")
+	}
+	return nil
+}
+
+func (s *ONNXRuntimeServingArgsBuilder) checkPortsIsOk() error {
+	if s.args.HttpPort <= 0 || s.args.GrpcPort <= 0 {
+		return fmt.Errorf("--http-port and --grpc-port must be greater than 0")
+	}
+	if s.args.HttpPort == s.args.GrpcPort {
+		return fmt.Errorf("--http-port and --grpc-port use the same port %v", s.args.HttpPort)
+	}
+	return nil
+}
+
+// setRegistryModel resolves --model-path when it refers to a registered model
+func (s *ONNXRuntimeServingArgsBuilder) setRegistryModel() error {
+	modelPath, _, err := resolveRegistryModelPath(&s.args.CommonServingArgs, s.args.ModelPath)
+	if err != nil {
+		return err
+	}
+	s.args.ModelPath = modelPath
+	return nil
+}
@@ -4,7 +4,7 @@
 // you may not use this file except in compliance with the License.
 // You may obtain a copy of the License at
 //
-//       http://www.apache.org/licenses/LICENSE-2.0
+//	http://www.apache.org/licenses/LICENSE-2.0
 //
 // Unless required by applicable law or agreed to in writing, software
 // distributed under the License is distributed on an "AS IS" BASIS,
@@ -88,7 +88,7 @@ func (s *TensorflowServingArgsBuilder) AddCommandFlags(command *cobra.Command) {
 
 	command.Flags().StringVar(&s.args.ModelPath, "modelPath", "", "the model path for serving in the container")
 	command.Flags().MarkDeprecated("modelPath", "please use --model-path instead")
-	command.Flags().StringVar(&s.args.ModelPath, "model-path", "", "the model path for serving in the container, ignored if --model-config-file flag is set, otherwise required")
+	command.Flags().StringVar(&s.args.ModelPath, "model-path", "", "the model path for serving in the container or a registered model like registry://<name>@<version>, ignored if --model-config-file flag is set, otherwise required")
 
 	command.Flags().StringVar(&s.args.ModelConfigFile, "modelConfigFile", "", "corresponding with --model_config_file in tensorflow serving")
 	command.Flags().MarkDeprecated("modelConfigFile", "please use --model-config-file instead")
@@ -109,6 +109,9 @@ func (s *TensorflowServingArgsBuilder) PreBuild() error {
 			return err
 		}
 	}
+	if err := s.setRegistryModel(); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -193,3 +196,16 @@ func (s *TensorflowServingArgsBuilder) checkPortsIsOk() error {
 	}
 	return fmt.Errorf("all  ports are 0,invalid configuration.")
 }
+
+// setRegistryModel resolves --model-path when it refers to a registered model
+func (s *TensorflowServingArgsBuilder) setRegistryModel() error {
+	modelPath, model, err := resolveRegistryModelPath(&s.args.CommonServingArgs, s.args.ModelPath)
+	if err != nil {
+		return err
+	}
+	s.args.ModelPath = modelPath
+	if model != nil && s.args.ModelName == "" {
+		s.args.ModelName = model.Name
+	}
+	return nil
+}
@@ -4,7 +4,7 @@
 // you may not use this file except in compliance with the License.
 // You may obtain a copy of the License at
 //
-//       http://www.apache.org/licenses/LICENSE-2.0
+//	http://www.apache.org/licenses/LICENSE-2.0
 //
 // Unless required by applicable law or agreed to in writing, software
 // distributed under the License is distributed on an "AS IS" BASIS,
@@ -70,7 +70,7 @@ func (s *TensorRTServingArgsBuilder) AddCommandFlags(command *cobra.Command) {
 	for name := range s.subBuilders {
 		s.subBuilders[name].AddCommandFlags(command)
 	}
-	command.Flags().StringVar(&s.args.ModelStore, "model-store", "", "the path of tensorRT model path")
+	command.Flags().StringVar(&s.args.ModelStore, "model-store", "", "the path of tensorRT model path, or a registered model like registry://<name>@<version>")
 	command.Flags().IntVar(&s.args.HttpPort, "http-port", 8000, "the port of http serving server")
 	command.Flags().IntVar(&s.args.GrpcPort, "grpc-port", 8001, "the port of grpc serving server")
 	command.Flags().IntVar(&s.args.MetricsPort, "metric-port", 8002, "the port of metrics server")
@@ -84,6 +84,9 @@ func (s *TensorRTServingArgsBuilder) PreBuild() error {
 			return err
 		}
 	}
+	if err := s.setRegistryModel(); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -121,3 +124,13 @@ func (s *TensorRTServingArgsBuilder) checkPortsIsOk() error {
 	}
 	return fmt.Errorf("all  ports are 0,invalid configuration.")
 }
+
+// setRegistryModel resolves --model-store when it refers to a registered model
+func (s *TensorRTServingArgsBuilder) setRegistryModel() error {
+	modelStore, _, err := resolveRegistryModelPath(&s.args.CommonServingArgs, s.args.ModelStore)
+	if err != nil {
+		return err
+	}
+	s.args.ModelStore = modelStore
+	return nil
+}
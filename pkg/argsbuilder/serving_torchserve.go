@@ -0,0 +1,172 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License
+package argsbuilder
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/kubeflow/arena/pkg/apis/types"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+const (
+	DefaultTorchServeImage = "pytorch/torchserve:latest-gpu"
+)
+
+type TorchServeArgsBuilder struct {
+	args        *types.TorchServeArgs
+	argValues   map[string]interface{}
+	subBuilders map[string]ArgsBuilder
+}
+
+func NewTorchServeArgsBuilder(args *types.TorchServeArgs) ArgsBuilder {
+	args.Type = types.TorchServeServingJob
+	s := &TorchServeArgsBuilder{
+		args:        args,
+		argValues:   map[string]interface{}{},
+		subBuilders: map[string]ArgsBuilder{},
+	}
+	s.AddSubBuilder(
+		NewServingArgsBuilder(&s.args.CommonServingArgs),
+	)
+	s.AddArgValue("default-image", DefaultTorchServeImage)
+	return s
+}
+
+func (s *TorchServeArgsBuilder) GetName() string {
+	items := strings.Split(fmt.Sprintf("%v", reflect.TypeOf(*s)), ".")
+	return items[len(items)-1]
+}
+
+func (s *TorchServeArgsBuilder) AddSubBuilder(builders ...ArgsBuilder) ArgsBuilder {
+	for _, b := range builders {
+		s.subBuilders[b.GetName()] = b
+	}
+	return s
+}
+
+func (s *TorchServeArgsBuilder) AddArgValue(key string, value interface{}) ArgsBuilder {
+	for name := range s.subBuilders {
+		s.subBuilders[name].AddArgValue(key, value)
+	}
+	s.argValues[key] = value
+	return s
+}
+
+func (s *TorchServeArgsBuilder) AddCommandFlags(command *cobra.Command) {
+	for name := range s.subBuilders {
+		s.subBuilders[name].AddCommandFlags(command)
+	}
+	var models []string
+	command.Flags().StringVar(&s.args.ModelStore, "model-store", "", "the path of torchserve model store which contains the .mar files, or a registered model like registry://<name>@<version>")
+	command.Flags().IntVar(&s.args.InferencePort, "inference-port", 8080, "the port of inference api")
+	command.Flags().IntVar(&s.args.ManagementPort, "management-port", 8081, "the port of management api")
+	command.Flags().IntVar(&s.args.MetricsPort, "metrics-port", 8082, "the port of metrics api")
+	command.Flags().IntVar(&s.args.GrpcPort, "grpc-port", 7070, "the port of grpc inference api")
+	command.Flags().StringVar(&s.args.Command, "command", "", "the command will inject to container's command.")
+	command.Flags().StringVar(&s.args.ExtendCommand, "extend-command", "", "the command will attach to server's command.")
+	command.Flags().StringArrayVar(&models, "model", []string{}, `the models to load when torchserve starts,default load all models in the model store, usage:"--model <model-name>=<model-name>.mar"`)
+
+	s.AddArgValue("model", &models)
+}
+
+func (s *TorchServeArgsBuilder) PreBuild() error {
+	for name := range s.subBuilders {
+		if err := s.subBuilders[name].PreBuild(); err != nil {
+			return err
+		}
+	}
+	if err := s.setRegistryModel(); err != nil {
+		return err
+	}
+	if err := s.setModels(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *TorchServeArgsBuilder) Build() error {
+	for name := range s.subBuilders {
+		if err := s.subBuilders[name].Build(); err != nil {
+			return err
+		}
+	}
+	if err := s.checkPortsIsOk(); err != nil {
+		return err
+	}
+	if err := s.validate(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *TorchServeArgsBuilder) validate() (err error) {
+	if s.args.Image == "" {
+		return fmt.Errorf("image must be specified")
+	}
+	if s.args.Command == "" && s.args.ModelStore == "" {
+		return fmt.Errorf("--model-store must be specified")
+	}
+	return nil
+}
+
+func (s *TorchServeArgsBuilder) checkPortsIsOk() error {
+	ports := map[int]string{}
+	for name, port := range map[string]int{
+		"inference-port":  s.args.InferencePort,
+		"management-port": s.args.ManagementPort,
+		"metrics-port":    s.args.MetricsPort,
+		"grpc-port":       s.args.GrpcPort,
+	} {
+		if port <= 0 {
+			return fmt.Errorf("--%v must be greater than 0", name)
+		}
+		if other, ok := ports[port]; ok {
+			return fmt.Errorf("--%v and --%v use the same port %v", other, name, port)
+		}
+		ports[port] = name
+	}
+	return nil
+}
+
+func (s *TorchServeArgsBuilder) setModels() error {
+	argKey := "model"
+	value, ok := s.argValues[argKey]
+	if !ok {
+		return nil
+	}
+	models := value.(*[]string)
+	s.args.Models = []string{}
+	for _, model := range *models {
+		if strings.Contains(model, ",") {
+			return fmt.Errorf("invalid model %v,please use '--model' multiple times to load multiple models", model)
+		}
+		s.args.Models = append(s.args.Models, model)
+	}
+	log.Debugf("Models: %v", s.args.Models)
+	return nil
+}
+
+// setRegistryModel resolves --model-store when it refers to a registered model
+func (s *TorchServeArgsBuilder) setRegistryModel() error {
+	modelStore, _, err := resolveRegistryModelPath(&s.args.CommonServingArgs, s.args.ModelStore)
+	if err != nil {
+		return err
+	}
+	s.args.ModelStore = modelStore
+	return nil
+}
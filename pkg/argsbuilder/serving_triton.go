@@ -72,7 +72,7 @@ func (s *TritonServingArgsBuilder) AddCommandFlags(command *cobra.Command) {
 		s.subBuilders[name].AddCommandFlags(command)
 	}
 	var loadModels []string
-	command.Flags().StringVar(&s.args.ModelRepository, "model-repository", "", "the path of triton model path")
+	command.Flags().StringVar(&s.args.ModelRepository, "model-repository", "", "the path of triton model path, or a registered model like registry://<name>@<version>")
 	command.Flags().IntVar(&s.args.HttpPort, "http-port", 8000, "the port of http serving server")
 	command.Flags().IntVar(&s.args.GrpcPort, "grpc-port", 8001, "the port of grpc serving server")
 	command.Flags().IntVar(&s.args.MetricsPort, "metrics-port", 8002, "the port of metrics server")
@@ -90,6 +90,9 @@ func (s *TritonServingArgsBuilder) PreBuild() error {
 			return err
 		}
 	}
+	if err := s.setRegistryModel(); err != nil {
+		return err
+	}
 	if err := s.setLoadModels(); err != nil {
 		return err
 	}
@@ -151,3 +154,13 @@ func (s *TritonServingArgsBuilder) setLoadModels() error {
 	log.Debugf("Load Models: %v", s.args.LoadModels)
 	return nil
 }
+
+// setRegistryModel resolves --model-repository when it refers to a registered model
+func (s *TritonServingArgsBuilder) setRegistryModel() error {
+	modelRepository, _, err := resolveRegistryModelPath(&s.args.CommonServingArgs, s.args.ModelRepository)
+	if err != nil {
+		return err
+	}
+	s.args.ModelRepository = modelRepository
+	return nil
+}
@@ -19,6 +19,7 @@ import (
 	"path"
 	"path/filepath"
 	"reflect"
+	"regexp"
 	"strings"
 
 	"github.com/kubeflow/arena/pkg/apis/config"
@@ -28,6 +29,9 @@ import (
 	"github.com/spf13/cobra"
 )
 
+// gpuMIGProfileRegexp matches the mig profiles like 1g.10gb and 3g.40gb
+var gpuMIGProfileRegexp = regexp.MustCompile(`^\d+g\.\d+gb$`)
+
 type SubmitArgsBuilder struct {
 	args        *types.CommonSubmitArgs
 	argValues   map[string]interface{}
@@ -88,6 +92,12 @@ func (s *SubmitArgsBuilder) AddCommandFlags(command *cobra.Command) {
 	// add option --gpus
 	command.Flags().IntVar(&s.args.GPUCount, "gpus", 0,
 		"the GPU count of each worker to run the training.")
+	// add option --gpu-mig-profile
+	command.Flags().StringVar(&s.args.GPUMIGProfile, "gpu-mig-profile", "",
+		`request the gpu instances of the mig profile instead of the whole gpus, the count is given by --gpus, usage: "--gpu-mig-profile 1g.10gb"`)
+	// add option --accelerator
+	command.Flags().StringVar(&s.args.AcceleratorResource, "accelerator", "",
+		`the resource name of accelerator registered in configmap arena-config which the gpus are requested from, the default is nvidia.com/gpu, usage: "--accelerator amd.com/gpu"`)
 	// add option --workers
 	command.Flags().IntVar(&s.args.WorkerCount, "workers", 1,
 		"the worker number to run the distributed training.")
@@ -186,6 +196,12 @@ func (s *SubmitArgsBuilder) Build() error {
 	if err := s.setDataDirs(); err != nil {
 		return err
 	}
+	if err := s.checkGPUMIGProfile(); err != nil {
+		return err
+	}
+	if err := s.checkAccelerator(); err != nil {
+		return err
+	}
 	// set annotation
 	if err := s.setAnnotations(); err != nil {
 		return err
@@ -612,6 +628,42 @@ func (s *SubmitArgsBuilder) setEnvs() error {
 	return nil
 }
 
+// checkGPUMIGProfile checks the mig profile is like 1g.10gb and the count of gpu instances is given
+func (s *SubmitArgsBuilder) checkGPUMIGProfile() error {
+	if s.args.GPUMIGProfile == "" {
+		return nil
+	}
+	s.args.GPUMIGProfile = strings.TrimPrefix(s.args.GPUMIGProfile, types.MIGResourceNamePrefix)
+	if !gpuMIGProfileRegexp.MatchString(s.args.GPUMIGProfile) {
+		return fmt.Errorf("invalid gpu mig profile %v,it should be like 1g.10gb", s.args.GPUMIGProfile)
+	}
+	if s.args.GPUCount <= 0 {
+		return fmt.Errorf("--gpus must be greater than 0 when --gpu-mig-profile is set,it is the count of gpu instances")
+	}
+	return nil
+}
+
+// checkAccelerator checks the accelerator is registered, nvidia.com/gpu is used by the charts if it is not set
+func (s *SubmitArgsBuilder) checkAccelerator() error {
+	if s.args.AcceleratorResource == "" {
+		return nil
+	}
+	if _, ok := config.GetAcceleratorByResourceName(s.args.AcceleratorResource); !ok {
+		names := []string{}
+		for _, accelerator := range config.GetAccelerators() {
+			names = append(names, accelerator.ResourceName)
+		}
+		return fmt.Errorf("unknown accelerator %v,only support:[%v]", s.args.AcceleratorResource, strings.Join(names, "|"))
+	}
+	if s.args.GPUMIGProfile != "" {
+		return fmt.Errorf("--accelerator can not be used with --gpu-mig-profile")
+	}
+	if s.args.AcceleratorResource == types.NvidiaGPUResourceName {
+		s.args.AcceleratorResource = ""
+	}
+	return nil
+}
+
 func (s *SubmitArgsBuilder) disabledNvidiaENVWithNoneGPURequest() error {
 	if s.args.Envs == nil {
 		s.args.Envs = map[string]string{}
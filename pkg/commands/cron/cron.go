@@ -23,11 +23,17 @@ var (
 
 Available Commands:
   tfjob                Submit a cron tfjob.
+  pytorchjob           Submit a cron pytorchjob.
+  mpijob               Submit a cron mpijob.
+  model-benchmark      Submit a cron model benchmark job.
   list,ls              List the crons.
   get                  Get cron by name.
   delete,del           Delete cron by name.
   suspend              Suspend a cron.
   resume               Resume the suspend cron.
+  update               Update the schedule or job template of a cron.
+  next                 Show the next fire times of a cron.
+  trigger,run-now      Trigger a job of cron immediately.
     `
 )
 
@@ -43,11 +49,17 @@ func NewCronCommand() *cobra.Command {
 	}
 
 	command.AddCommand(NewCronTFJobCommand())
+	command.AddCommand(NewCronPyTorchJobCommand())
+	command.AddCommand(NewCronMPIJobCommand())
+	command.AddCommand(NewCronModelBenchmarkCommand())
 	command.AddCommand(NewCronGetCommand())
 	command.AddCommand(NewCronListCommand())
 	command.AddCommand(NewCronDeleteCommand())
 	command.AddCommand(NewCronSuspendCommand())
 	command.AddCommand(NewCronResumeCommand())
+	command.AddCommand(NewCronUpdateCommand())
+	command.AddCommand(NewCronNextCommand())
+	command.AddCommand(NewCronTriggerCommand())
 
 	return command
 }
@@ -0,0 +1,74 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cron
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kubeflow/arena/pkg/apis/arenaclient"
+	"github.com/kubeflow/arena/pkg/apis/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func NewCronUpdateCommand() *cobra.Command {
+	var envs []string
+	updateArgs := &types.UpdateCronArgs{}
+	var command = &cobra.Command{
+		Use:   "update",
+		Short: "update cron in place.",
+		PreRun: func(cmd *cobra.Command, args []string) {
+			viper.BindPFlags(cmd.Flags())
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				cmd.HelpFunc()(cmd, args)
+				return fmt.Errorf("not set cron name, please set it")
+			}
+			updateArgs.Name = args[0]
+
+			if len(envs) > 0 {
+				updateArgs.Envs = map[string]string{}
+				for _, env := range envs {
+					kv := strings.SplitN(env, "=", 2)
+					if len(kv) != 2 || kv[0] == "" {
+						return fmt.Errorf("invalid env %s, it should be like KEY=VALUE", env)
+					}
+					updateArgs.Envs[kv[0]] = kv[1]
+				}
+			}
+
+			client, err := arenaclient.NewArenaClient(types.ArenaClientArgs{
+				Kubeconfig:     viper.GetString("config"),
+				LogLevel:       viper.GetString("loglevel"),
+				Namespace:      viper.GetString("namespace"),
+				ArenaNamespace: viper.GetString("arena-namespace"),
+				IsDaemonMode:   false,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to create arena client: %v", err)
+			}
+			return client.Cron().Update(updateArgs)
+		},
+	}
+	command.Flags().StringVar(&updateArgs.Schedule, "schedule", "", "the schedule of cron task")
+	command.Flags().StringVar(&updateArgs.ConcurrencyPolicy, "concurrency-policy", "", "specifies how to treat concurrent executions of a task, one of: Allow|Forbid|Replace")
+	command.Flags().StringVar(&updateArgs.Deadline, "deadline", "", "the timestamp that a cron job can keep scheduling util then, like 2006-01-02T15:04:05Z")
+	command.Flags().IntVar(&updateArgs.HistoryLimit, "history-limit", 0, "the number of finished job history to retain")
+	command.Flags().StringVar(&updateArgs.Image, "image", "", "the image of all containers in the job template")
+	command.Flags().StringArrayVarP(&envs, "env", "e", []string{}, "the environment variables set to all containers in the job template, usage: --env KEY=VALUE")
+	return command
+}
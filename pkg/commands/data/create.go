@@ -0,0 +1,60 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data
+
+import (
+	"fmt"
+
+	"github.com/kubeflow/arena/pkg/apis/arenaclient"
+	"github.com/kubeflow/arena/pkg/apis/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func NewDataCreateCommand() *cobra.Command {
+	createArgs := &types.DataVolumeArgs{}
+	var command = &cobra.Command{
+		Use:   "create NAME [--nfs server:/path | --hostpath /path | --storage-class sc] --size 100Gi --access-mode ReadWriteMany",
+		Short: "create a data volume.",
+		PreRun: func(cmd *cobra.Command, args []string) {
+			viper.BindPFlags(cmd.Flags())
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				cmd.HelpFunc()(cmd, args)
+				return fmt.Errorf("not set data volume name, please set it")
+			}
+			client, err := arenaclient.NewArenaClient(types.ArenaClientArgs{
+				Kubeconfig:     viper.GetString("config"),
+				LogLevel:       viper.GetString("loglevel"),
+				Namespace:      viper.GetString("namespace"),
+				ArenaNamespace: viper.GetString("arena-namespace"),
+				IsDaemonMode:   false,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to create arena client: %v", err)
+			}
+			createArgs.Name = args[0]
+			return client.Data().Create(createArgs)
+		},
+	}
+	command.Flags().StringVar(&createArgs.NFS, "nfs", "", "the nfs export which backs the volume, like server:/path")
+	command.Flags().StringVar(&createArgs.HostPath, "hostpath", "", "the directory of node which backs the volume")
+	command.Flags().StringVar(&createArgs.StorageClass, "storage-class", "", "the storage class which provisions the volume")
+	command.Flags().StringVar(&createArgs.Size, "size", "10Gi", "the capacity of the volume")
+	command.Flags().StringVar(&createArgs.AccessMode, "access-mode", "ReadWriteMany", "the access mode of the volume, one of: ReadWriteOnce|ReadOnlyMany|ReadWriteMany|ReadWriteOncePod")
+	command.Flags().StringVar(&createArgs.Description, "description", "", "the description of the volume")
+	return command
+}
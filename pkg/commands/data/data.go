@@ -23,6 +23,9 @@ var (
 
 Available Commands:
   list,ls              List the data volumes.
+  create               Create a data volume.
+  describe             Describe a data volume and the jobs which are mounting it.
+  delete,del           Delete the data volumes which are not in use.
     `
 )
 
@@ -38,6 +41,9 @@ func NewDataCommand() *cobra.Command {
 	}
 
 	command.AddCommand(NewDataListCommand())
+	command.AddCommand(NewDataCreateCommand())
+	command.AddCommand(NewDataDescribeCommand())
+	command.AddCommand(NewDataDeleteCommand())
 
 	return command
 }
@@ -0,0 +1,50 @@
+package evaluate
+
+import (
+	"fmt"
+	"github.com/kubeflow/arena/pkg/apis/arenaclient"
+	"github.com/kubeflow/arena/pkg/apis/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func NewEvaluateCompareCommand() *cobra.Command {
+	var modelName string
+	var sortBy string
+	var ascending bool
+	var format string
+	var command = &cobra.Command{
+		Use:   "compare [JOB_NAME...]",
+		Short: "compare the metrics of evaluate jobs, like the evaluate jobs of different model versions.",
+		Example: `  # compare the given evaluate jobs
+  arena evaluate compare resnet-v1-eval resnet-v2-eval
+
+  # compare all evaluate jobs of the model and sort them by accuracy
+  arena evaluate compare --model-name resnet --sort-by accuracy`,
+		PreRun: func(cmd *cobra.Command, args []string) {
+			viper.BindPFlags(cmd.Flags())
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 && modelName == "" {
+				cmd.HelpFunc()(cmd, args)
+				return fmt.Errorf("not set evaluate job names or --model-name, please set it")
+			}
+			client, err := arenaclient.NewArenaClient(types.ArenaClientArgs{
+				Kubeconfig:     viper.GetString("config"),
+				LogLevel:       viper.GetString("loglevel"),
+				Namespace:      viper.GetString("namespace"),
+				ArenaNamespace: viper.GetString("arena-namespace"),
+				IsDaemonMode:   false,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to create arena client: %v", err)
+			}
+			return client.Evaluate().CompareAndPrint(args, modelName, sortBy, ascending, format)
+		},
+	}
+	command.Flags().StringVar(&modelName, "model-name", "", "compare all evaluate jobs of the model if no job name is given")
+	command.Flags().StringVar(&sortBy, "sort-by", "", "sort the evaluate jobs by the metric, like accuracy, the higher value is listed first")
+	command.Flags().BoolVar(&ascending, "ascending", false, "sort in ascending order and mark the lowest value of the sorted metric as the best")
+	command.Flags().StringVarP(&format, "output", "o", "wide", "Output format. One of: json|yaml|wide")
+	return command
+}
@@ -11,6 +11,7 @@ Available Commands:
   model                Submit a evaluate job.
   list,ls              List the evaluate job.
   get                  Get evaluate job by name.
+  compare              Compare the metrics of evaluate jobs.
   delete,del           Delete evaluate job by name.
 `
 )
@@ -30,6 +31,7 @@ func NewEvaluateCommand() *cobra.Command {
 	command.AddCommand(NewEvaluateDeleteCommand())
 	command.AddCommand(NewEvaluateListCommand())
 	command.AddCommand(NewEvaluateGetCommand())
+	command.AddCommand(NewEvaluateCompareCommand())
 
 	return command
 }
@@ -10,6 +10,8 @@ import (
 func NewEvaluateListCommand() *cobra.Command {
 	var allNamespaces bool
 	var format string
+	var sortBy string
+	var ascending bool
 	var command = &cobra.Command{
 		Use:     "list",
 		Short:   "List evaluate jobs",
@@ -29,11 +31,16 @@ func NewEvaluateListCommand() *cobra.Command {
 				return err
 			}
 
+			if sortBy != "" {
+				return client.Evaluate().ListSortedByMetricAndPrint(allNamespaces, sortBy, ascending, format)
+			}
 			return client.Evaluate().ListAndPrint(allNamespaces, format)
 		},
 	}
 
 	command.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "show all the namespaces")
 	command.Flags().StringVarP(&format, "output", "o", "wide", "Output format. One of: json|yaml|wide")
+	command.Flags().StringVar(&sortBy, "sort-by", "", "sort the evaluate jobs by the metric, like accuracy, the higher value is listed first")
+	command.Flags().BoolVar(&ascending, "ascending", false, "sort the evaluate jobs in ascending order, the lower value is listed first")
 	return command
-}
\ No newline at end of file
+}
@@ -0,0 +1,41 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/kubeflow/arena/pkg/apis/arenaclient"
+	"github.com/kubeflow/arena/pkg/apis/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// NewExporterCommand runs the exporter which publishes the state of arena jobs and nodes to prometheus
+func NewExporterCommand() *cobra.Command {
+	var (
+		address     string
+		metricsPath string
+	)
+	var command = &cobra.Command{
+		Use:   "exporter",
+		Short: "Serve the metrics of arena jobs and nodes in prometheus format, it should run in the cluster.",
+		PreRun: func(cmd *cobra.Command, args []string) {
+			viper.BindPFlags(cmd.Flags())
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := arenaclient.NewArenaClient(types.ArenaClientArgs{
+				Kubeconfig:     viper.GetString("config"),
+				LogLevel:       viper.GetString("loglevel"),
+				Namespace:      viper.GetString("namespace"),
+				ArenaNamespace: viper.GetString("arena-namespace"),
+				IsDaemonMode:   true,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to create arena client: %v", err)
+			}
+			return client.Exporter().Run(address, metricsPath)
+		},
+	}
+	command.Flags().StringVar(&address, "address", ":9700", "The address to listen on for the metrics")
+	command.Flags().StringVar(&metricsPath, "metrics-path", "/metrics", "The path under which the metrics are exposed")
+	return command
+}
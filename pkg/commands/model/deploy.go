@@ -0,0 +1,92 @@
+package model
+
+import (
+	"fmt"
+	"github.com/kubeflow/arena/pkg/apis/arenaclient"
+	"github.com/kubeflow/arena/pkg/apis/config"
+	"github.com/kubeflow/arena/pkg/apis/model"
+	"github.com/kubeflow/arena/pkg/apis/types"
+	"github.com/kubeflow/arena/pkg/apis/utils"
+	modeljob "github.com/kubeflow/arena/pkg/model"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func NewModelDeployCommand() *cobra.Command {
+	builder := model.NewModelDeployBuilder()
+	var command = &cobra.Command{
+		Use:   "deploy",
+		Short: "Optimize, benchmark and serve a model in one command",
+		Long: `Run the model deploy pipeline in the foreground:
+  1. submit the model optimize job <name>-optimize and wait for it
+  2. benchmark the optimized model with the job <name>-benchmark if --benchmark is specified
+  3. create the triton serving job <name> if --serve-type is specified and the throughput is not less than --min-throughput
+
+Run 'arena model deploy status <name>' to check the status of all stages.`,
+		Example: `  arena model deploy --name=resnet18 \
+    --image=registry.cn-beijing.aliyuncs.com/kube-ai/easy-inference:1.0.0 \
+    --gpus=1 --data=model-pvc:/data \
+    --model-config-file=/data/models/resnet18/config.json \
+    --optimizer=tensorrt --export-path=/data/models/resnet18 \
+    --benchmark --min-throughput=500 \
+    --serve-type=triton --model-repository=/data/models/triton`,
+		Args: cobra.NoArgs,
+		PreRun: func(cmd *cobra.Command, args []string) {
+			viper.BindPFlags(cmd.Flags())
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := arenaclient.NewArenaClient(types.ArenaClientArgs{
+				Kubeconfig:     viper.GetString("config"),
+				LogLevel:       viper.GetString("loglevel"),
+				Namespace:      viper.GetString("namespace"),
+				ArenaNamespace: viper.GetString("arena-namespace"),
+				IsDaemonMode:   false,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to create arena client: %v\n", err)
+			}
+			deployArgs, err := builder.Namespace(config.GetArenaConfiger().GetNamespace()).Build()
+			if err != nil {
+				return fmt.Errorf("failed to validate command args: %v", err)
+			}
+			info, deployErr := client.Model().Deploy(deployArgs)
+			if info != nil {
+				modeljob.DisplayModelDeploy(info, utils.TransferPrintFormat("wide"))
+			}
+			return deployErr
+		},
+	}
+	builder.AddCommandFlags(command)
+	command.AddCommand(NewModelDeployStatusCommand())
+	return command
+}
+
+func NewModelDeployStatusCommand() *cobra.Command {
+	var format string
+	var command = &cobra.Command{
+		Use:   "status NAME",
+		Short: "Display the status of all stages of the model deploy pipeline",
+		PreRun: func(cmd *cobra.Command, args []string) {
+			viper.BindPFlags(cmd.Flags())
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				cmd.HelpFunc()(cmd, args)
+				return fmt.Errorf("the model deploy name must be specified")
+			}
+			client, err := arenaclient.NewArenaClient(types.ArenaClientArgs{
+				Kubeconfig:     viper.GetString("config"),
+				LogLevel:       viper.GetString("loglevel"),
+				Namespace:      viper.GetString("namespace"),
+				ArenaNamespace: viper.GetString("arena-namespace"),
+				IsDaemonMode:   false,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to create arena client: %v\n", err)
+			}
+			return client.Model().GetDeployAndPrint(args[0], format)
+		},
+	}
+	command.Flags().StringVarP(&format, "output", "o", "wide", "Output format. One of: json|yaml|wide")
+	return command
+}
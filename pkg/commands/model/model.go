@@ -9,7 +9,12 @@ Available Commands:
   profile          Submit a model profile job.
   evaluate         Submit a model evaluate job.
   optimize         Submit a model optimize job.
-  benchmark        Submit a model benchmark job`
+  benchmark        Submit a model benchmark job.
+  deploy           Optimize, benchmark and serve a model in one command.
+  compare          Compare the results of two model jobs.
+  push             Export the model to the object storage or a pvc.
+  pull             Import the model from the object storage or a pvc.
+  registry         Manage the registered models.`
 )
 
 func NewModelCommand() *cobra.Command {
@@ -28,6 +33,11 @@ func NewModelCommand() *cobra.Command {
 	command.AddCommand(NewGetModelJobCommand())
 	command.AddCommand(NewListModelJobsCommand())
 	command.AddCommand(NewDeleteModelJobCommand())
+	command.AddCommand(NewCompareModelJobsCommand())
+	command.AddCommand(NewModelRegistryCommand())
+	command.AddCommand(NewModelDeployCommand())
+	command.AddCommand(NewModelPushCommand())
+	command.AddCommand(NewModelPullCommand())
 
 	return command
 }
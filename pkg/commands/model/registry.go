@@ -0,0 +1,33 @@
+package model
+
+import "github.com/spf13/cobra"
+
+var (
+	registryLong = `manage the registered models.
+
+Available Commands:
+  create           Register a new version of model.
+  list,ls          List the registered model versions.
+  get              Get a registered model version.
+  promote          Move a model version to a stage like staging or production.
+
+The served model can be referred as registry://<name>@<version> in the model path options
+of arena serve commands, the version can also be a stage or be omitted to use the latest version.`
+)
+
+func NewModelRegistryCommand() *cobra.Command {
+	var command = &cobra.Command{
+		Use:   "registry",
+		Short: "Manage the registered models.",
+		Long:  registryLong,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.HelpFunc()(cmd, args)
+		},
+	}
+	command.AddCommand(NewModelRegistryCreateCommand())
+	command.AddCommand(NewModelRegistryListCommand())
+	command.AddCommand(NewModelRegistryGetCommand())
+	command.AddCommand(NewModelRegistryPromoteCommand())
+
+	return command
+}
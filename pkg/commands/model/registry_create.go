@@ -0,0 +1,65 @@
+package model
+
+import (
+	"fmt"
+	"github.com/kubeflow/arena/pkg/apis/arenaclient"
+	"github.com/kubeflow/arena/pkg/apis/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"strings"
+)
+
+func NewModelRegistryCreateCommand() *cobra.Command {
+	var metrics []string
+	var stage string
+	registerArgs := &types.RegisterModelArgs{}
+	var command = &cobra.Command{
+		Use:   "create",
+		Short: "Register a new version of model",
+		PreRun: func(cmd *cobra.Command, args []string) {
+			viper.BindPFlags(cmd.Flags())
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				cmd.HelpFunc()(cmd, args)
+				return fmt.Errorf("not set model name, please set it")
+			}
+			registerArgs.Name = args[0]
+			registerArgs.Stage = types.ModelStage(stage)
+			registerArgs.Metrics = map[string]string{}
+			for _, metric := range metrics {
+				kv := strings.SplitN(metric, "=", 2)
+				if len(kv) != 2 || kv[0] == "" {
+					return fmt.Errorf("invalid metric %s, it should be like <name>=<value>", metric)
+				}
+				registerArgs.Metrics[kv[0]] = kv[1]
+			}
+
+			client, err := arenaclient.NewArenaClient(types.ArenaClientArgs{
+				Kubeconfig:     viper.GetString("config"),
+				LogLevel:       viper.GetString("loglevel"),
+				Namespace:      viper.GetString("namespace"),
+				ArenaNamespace: viper.GetString("arena-namespace"),
+				IsDaemonMode:   false,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to create arena client: %v", err)
+			}
+			model, err := client.ModelRegistry().Create(registerArgs)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("model %s version %s is registered, it can be served with %s%s@%s\n", model.Name, model.Version, types.ModelRegistryScheme, model.Name, model.Version)
+			return nil
+		},
+	}
+	command.Flags().StringVar(&registerArgs.Version, "version", "", "the model version, the max number version plus 1 is used if not set")
+	command.Flags().StringVar(&registerArgs.StorageURI, "storage-uri", "", "the location of model files, like pvc://<pvc_name>/<path>, oss://<bucket>/<path> or a path in the image")
+	command.Flags().StringVar(&registerArgs.Framework, "framework", "", "the framework of model, like tensorflow, pytorch or onnx")
+	command.Flags().StringVar(&stage, "stage", string(types.ModelStageNone), "the stage of model version, one of: none|staging|production")
+	command.Flags().StringArrayVar(&metrics, "metric", []string{}, "the metrics of model, usage: --metric accuracy=0.92")
+	command.Flags().StringVar(&registerArgs.Description, "description", "", "the description of model version")
+	command.Flags().StringVar(&registerArgs.SourceJob, "source-job", "", "the training job which produced the model")
+	command.Flags().StringVar(&registerArgs.SourceJobType, "source-job-type", "", "the type of source training job, it is searched by name if not set")
+	return command
+}
@@ -0,0 +1,49 @@
+package model
+
+import (
+	"fmt"
+	"github.com/kubeflow/arena/pkg/apis/arenaclient"
+	"github.com/kubeflow/arena/pkg/apis/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func NewModelRegistryPromoteCommand() *cobra.Command {
+	var version string
+	var stage string
+	var command = &cobra.Command{
+		Use:   "promote",
+		Short: "Move a model version to a stage like staging or production",
+		PreRun: func(cmd *cobra.Command, args []string) {
+			viper.BindPFlags(cmd.Flags())
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				cmd.HelpFunc()(cmd, args)
+				return fmt.Errorf("not set model name, please set it")
+			}
+			if version == "" {
+				return fmt.Errorf("--version must be specified")
+			}
+			client, err := arenaclient.NewArenaClient(types.ArenaClientArgs{
+				Kubeconfig:     viper.GetString("config"),
+				LogLevel:       viper.GetString("loglevel"),
+				Namespace:      viper.GetString("namespace"),
+				ArenaNamespace: viper.GetString("arena-namespace"),
+				IsDaemonMode:   false,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to create arena client: %v", err)
+			}
+			model, err := client.ModelRegistry().Promote(args[0], version, types.ModelStage(stage))
+			if err != nil {
+				return err
+			}
+			fmt.Printf("model %s version %s is promoted to %s\n", model.Name, model.Version, model.Stage)
+			return nil
+		},
+	}
+	command.Flags().StringVar(&version, "version", "", "the model version to promote")
+	command.Flags().StringVar(&stage, "stage", string(types.ModelStageProduction), "the target stage, one of: none|staging|production|archived")
+	return command
+}
@@ -0,0 +1,75 @@
+package model
+
+import (
+	"fmt"
+	"github.com/kubeflow/arena/pkg/apis/arenaclient"
+	"github.com/kubeflow/arena/pkg/apis/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"time"
+)
+
+func NewModelPushCommand() *cobra.Command {
+	return newModelTransferCommand(types.ModelPushTransfer)
+}
+
+func NewModelPullCommand() *cobra.Command {
+	return newModelTransferCommand(types.ModelPullTransfer)
+}
+
+func newModelTransferCommand(transferType types.ModelTransferType) *cobra.Command {
+	args := &types.ModelTransferArgs{Type: transferType}
+	var format string
+	use := "push SOURCE --to s3://<bucket>/<path>|pvc://<pvc_name>/<path>"
+	short := "Export the model from a local path or a pvc to the object storage or a pvc"
+	example := `  # export the model in the pvc to minio
+  arena model push pvc://model-pvc/models/resnet18 --to s3://models/resnet18 \
+    --secret minio-credentials --endpoint http://minio.minio:9000
+
+  # upload the local model to the pvc
+  arena model push ./resnet18 --to pvc://model-pvc/models/resnet18`
+	if transferType == types.ModelPullTransfer {
+		use = "pull SOURCE --to <local path>|pvc://<pvc_name>/<path>"
+		short = "Import the model from the object storage or a pvc to a local path or a pvc"
+		example = `  # import the model from minio to the pvc
+  arena model pull s3://models/resnet18 --to pvc://model-pvc/models/resnet18 \
+    --secret minio-credentials --endpoint http://minio.minio:9000
+
+  # download the model in the pvc to the local directory
+  arena model pull pvc://model-pvc/models/resnet18 --to ./resnet18`
+	}
+	var command = &cobra.Command{
+		Use:     use,
+		Short:   short,
+		Example: example,
+		PreRun: func(cmd *cobra.Command, _ []string) {
+			viper.BindPFlags(cmd.Flags())
+		},
+		RunE: func(cmd *cobra.Command, cmdArgs []string) error {
+			if len(cmdArgs) != 1 {
+				cmd.HelpFunc()(cmd, cmdArgs)
+				return fmt.Errorf("the source path must be specified")
+			}
+			args.Source = cmdArgs[0]
+			client, err := arenaclient.NewArenaClient(types.ArenaClientArgs{
+				Kubeconfig:     viper.GetString("config"),
+				LogLevel:       viper.GetString("loglevel"),
+				Namespace:      viper.GetString("namespace"),
+				ArenaNamespace: viper.GetString("arena-namespace"),
+				IsDaemonMode:   false,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to create arena client: %v\n", err)
+			}
+			return client.Model().TransferAndPrint(args, format)
+		},
+	}
+	command.Flags().StringVar(&args.Destination, "to", "", "the destination of model, like s3://<bucket>/<path>, pvc://<pvc_name>/<path> or a local path")
+	command.Flags().StringVar(&args.Secret, "secret", "", "the secret which stores AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY of the object storage")
+	command.Flags().StringVar(&args.Endpoint, "endpoint", "", "the endpoint of S3 compatible object storage, like http://minio.minio:9000")
+	command.Flags().StringVar(&args.Region, "region", "", "the region of object storage")
+	command.Flags().StringVar(&args.Image, "image", "", "the image of transfer job which runs rclone, default is rclone/rclone:1.62")
+	command.Flags().DurationVar(&args.Timeout, "timeout", time.Hour, "the max time to wait for the transfer")
+	command.Flags().StringVarP(&format, "output", "o", "wide", "Output format. One of: json|yaml|wide")
+	return command
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/kubeflow/arena/pkg/commands/serving"
 	topcommand "github.com/kubeflow/arena/pkg/commands/top"
 	"github.com/kubeflow/arena/pkg/commands/training"
+	usagecommand "github.com/kubeflow/arena/pkg/commands/usage"
 	"github.com/spf13/cobra"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 )
@@ -54,5 +55,7 @@ func NewCommand() *cobra.Command {
 	command.AddCommand(evaluate.NewEvaluateCommand())
 	command.AddCommand(NewWhoamiCommand())
 	command.AddCommand(model.NewModelCommand())
+	command.AddCommand(usagecommand.NewUsageCommand())
+	command.AddCommand(NewExporterCommand())
 	return command
 }
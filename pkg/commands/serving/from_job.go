@@ -0,0 +1,64 @@
+package serving
+
+import (
+	"fmt"
+
+	"github.com/kubeflow/arena/pkg/apis/arenaclient"
+	"github.com/kubeflow/arena/pkg/apis/types"
+	"github.com/kubeflow/arena/pkg/apis/utils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// NewServeFromJobCommand
+func NewServeFromJobCommand() *cobra.Command {
+	var servingType string
+	var jobType string
+	fromJobArgs := &types.ServingFromJobArgs{}
+	var command = &cobra.Command{
+		Use:   "from-job TRAINING_JOB --type triton|tf|kserve --model-subpath outputs/model",
+		Short: "Serve the model produced by a succeeded training job",
+		PreRun: func(cmd *cobra.Command, args []string) {
+			viper.BindPFlags(cmd.Flags())
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return fmt.Errorf("not set training job name,please set it")
+			}
+			client, err := arenaclient.NewArenaClient(types.ArenaClientArgs{
+				Kubeconfig:     viper.GetString("config"),
+				LogLevel:       viper.GetString("loglevel"),
+				Namespace:      viper.GetString("namespace"),
+				ArenaNamespace: viper.GetString("arena-namespace"),
+				IsDaemonMode:   false,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to create arena client: %v", err)
+			}
+			fromJobArgs.JobName = args[0]
+			fromJobArgs.Type = utils.TransferServingJobType(servingType)
+			if fromJobArgs.Type == types.AllServingJob {
+				return fmt.Errorf("not set serving type,please set it by --type")
+			}
+			if jobType != "" {
+				fromJobArgs.JobType = utils.TransferTrainingJobType(jobType)
+				if fromJobArgs.JobType == types.UnknownTrainingJob {
+					return fmt.Errorf("unknown training job type %s, the possible option is %v", jobType, utils.GetSupportTrainingJobTypesInfo())
+				}
+			}
+			return client.Serving().SubmitFromJob(fromJobArgs)
+		},
+	}
+	command.Flags().StringVar(&servingType, "type", "", "The serving type, the possible option is [triton|tf|kserve]")
+	command.Flags().StringVar(&jobType, "job-type", "", fmt.Sprintf("The training job type, the possible option is %v. (optional)", utils.GetSupportTrainingJobTypesInfo()))
+	command.Flags().StringVar(&fromJobArgs.ModelSubpath, "model-subpath", "", "The path of the model relative to the root of the pvc mounted by the training job, eg: outputs/model")
+	command.Flags().StringVar(&fromJobArgs.ModelPVC, "model-pvc", "", "The pvc which stores the model, required if the training job mounts multiple pvcs by --data")
+	command.Flags().StringVar(&fromJobArgs.Name, "name", "", "The serving job name, default to the training job name")
+	command.Flags().StringVar(&fromJobArgs.Version, "version", "", "The serving job version")
+	command.Flags().StringVar(&fromJobArgs.Image, "image", "", "The serving image, default to the image of the serving type")
+	command.Flags().IntVar(&fromJobArgs.GPUCount, "gpus", 0, "The gpu count of each serving replica")
+	command.Flags().IntVar(&fromJobArgs.Replicas, "replicas", 1, "The replicas number of the serving job")
+	command.Flags().StringVar(&fromJobArgs.ModelName, "model-name", "", "The model name of tensorflow serving, default to the training job name")
+	command.Flags().StringVar(&fromJobArgs.ModelFormat, "model-format", "", `The model format of kserve. usage: "--model-format=name" or "--model-format=name:version"`)
+	return command
+}
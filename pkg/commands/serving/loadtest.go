@@ -0,0 +1,62 @@
+package serving
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kubeflow/arena/pkg/apis/arenaclient"
+	"github.com/kubeflow/arena/pkg/apis/types"
+	"github.com/kubeflow/arena/pkg/apis/utils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// NewLoadTestCommand
+func NewLoadTestCommand() *cobra.Command {
+	var servingType string
+	var version string
+	var output string
+	var data string
+	var protocol string
+	var mode string
+	var headers []string
+	loadTestArgs := &types.ServingLoadTestArgs{}
+	var command = &cobra.Command{
+		Use:   "loadtest JOB [-T JOB_TYPE] [-v JOB_VERSION] --qps 200 --duration 2m --concurrency 32 -d @payload.json",
+		Short: "Load test a serving job and report the throughput, latency and errors",
+		PreRun: func(cmd *cobra.Command, args []string) {
+			viper.BindPFlags(cmd.Flags())
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return fmt.Errorf("not set job name,please set it")
+			}
+			client, err := arenaclient.NewArenaClient(types.ArenaClientArgs{
+				Kubeconfig:     viper.GetString("config"),
+				LogLevel:       viper.GetString("loglevel"),
+				Namespace:      viper.GetString("namespace"),
+				ArenaNamespace: viper.GetString("arena-namespace"),
+				IsDaemonMode:   false,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to create arena client: %v", err)
+			}
+			if err := completePredictArgs(&loadTestArgs.ServingPredictArgs, args[0], version, servingType, protocol, data, headers); err != nil {
+				return err
+			}
+			loadTestArgs.Mode = types.ServingLoadTestMode(strings.ToLower(mode))
+			return client.Serving().LoadTestAndPrint(loadTestArgs, output)
+		},
+	}
+	addPredictFlags(command, &loadTestArgs.ServingPredictArgs, &data, &protocol, &headers)
+	command.Flags().StringVarP(&version, "version", "v", "", "Set the serving job version")
+	command.Flags().StringVarP(&servingType, "type", "T", "", fmt.Sprintf("The serving type, the possible option is [%v]. (optional)", utils.GetSupportServingJobTypesInfo()))
+	command.Flags().StringVarP(&output, "output", "o", "wide", "Output format. One of: json|yaml|wide")
+	command.Flags().IntVar(&loadTestArgs.QPS, "qps", 0, "The target requests per second, 0 means sending requests as fast as possible, at most 100000")
+	command.Flags().DurationVar(&loadTestArgs.Duration, "duration", time.Minute, "The duration of the load test, eg: 30s,2m")
+	command.Flags().IntVar(&loadTestArgs.Concurrency, "concurrency", 8, "The number of concurrent connections")
+	command.Flags().StringVar(&mode, "mode", string(types.LocalLoadTestMode), fmt.Sprintf("Where to run the load test, the possible option is [%v|%v]", types.LocalLoadTestMode, types.JobLoadTestMode))
+	command.Flags().StringVar(&loadTestArgs.Image, "image", "", "The load test image used by the job mode, default to fortio/fortio:latest")
+	return command
+}
@@ -0,0 +1,115 @@
+package serving
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/kubeflow/arena/pkg/apis/arenaclient"
+	"github.com/kubeflow/arena/pkg/apis/types"
+	"github.com/kubeflow/arena/pkg/apis/utils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// NewPredictCommand
+func NewPredictCommand() *cobra.Command {
+	var servingType string
+	var version string
+	var output string
+	var data string
+	var protocol string
+	var headers []string
+	predictArgs := &types.ServingPredictArgs{}
+	var command = &cobra.Command{
+		Use:   "predict JOB [-T JOB_TYPE] [-v JOB_VERSION] -d @input.json",
+		Short: "Send a predict request to a serving job and print the response",
+		PreRun: func(cmd *cobra.Command, args []string) {
+			viper.BindPFlags(cmd.Flags())
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return fmt.Errorf("not set job name,please set it")
+			}
+			client, err := arenaclient.NewArenaClient(types.ArenaClientArgs{
+				Kubeconfig:     viper.GetString("config"),
+				LogLevel:       viper.GetString("loglevel"),
+				Namespace:      viper.GetString("namespace"),
+				ArenaNamespace: viper.GetString("arena-namespace"),
+				IsDaemonMode:   false,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to create arena client: %v", err)
+			}
+			if err := completePredictArgs(predictArgs, args[0], version, servingType, protocol, data, headers); err != nil {
+				return err
+			}
+			return client.Serving().PredictAndPrint(predictArgs, output)
+		},
+	}
+	addPredictFlags(command, predictArgs, &data, &protocol, &headers)
+	command.Flags().StringVarP(&version, "version", "v", "", "Set the serving job version")
+	command.Flags().StringVarP(&servingType, "type", "T", "", fmt.Sprintf("The serving type, the possible option is [%v]. (optional)", utils.GetSupportServingJobTypesInfo()))
+	command.Flags().StringVarP(&output, "output", "o", "wide", "Output format. One of: json|yaml|wide")
+	return command
+}
+
+func addPredictFlags(command *cobra.Command, predictArgs *types.ServingPredictArgs, data, protocol *string, headers *[]string) {
+	command.Flags().StringVarP(data, "data", "d", "", "The request body, use @file to read it from a file or @- to read it from stdin")
+	command.Flags().StringVar(protocol, "protocol", "", fmt.Sprintf("The inference protocol, the possible option is [%v|%v|%v|%v|%v|%v], it is detected by the serving type if not set",
+		types.V1PredictProtocol,
+		types.V2PredictProtocol,
+		types.SeldonPredictProtocol,
+		types.OpenAIPredictProtocol,
+		types.TorchServePredictProtocol,
+		types.ONNXRuntimePredictProtocol,
+	))
+	command.Flags().StringVar(&predictArgs.ModelName, "model-name", "", "The model name used to build the request path, default to the model name of serving job")
+	command.Flags().StringVar(&predictArgs.Path, "path", "", "The request path, it overrides the path built by the protocol")
+	command.Flags().IntVar(&predictArgs.Port, "port", 0, "The container port which accepts http requests, it is detected if not set")
+	command.Flags().StringVarP(&predictArgs.Instance, "instance", "i", "", "The instance which the request is forwarded to, a ready instance is picked if not set")
+	command.Flags().StringVar(&predictArgs.Endpoint, "endpoint", "", "Send requests to the endpoint directly instead of port-forwarding, eg: http://192.168.1.10:8501")
+	command.Flags().StringArrayVarP(headers, "header", "H", []string{}, "Extra request headers, eg: -H 'Authorization: Bearer xxx'")
+	command.Flags().IntVar(&predictArgs.Timeout, "timeout", 60, "The request timeout in seconds")
+}
+
+func completePredictArgs(predictArgs *types.ServingPredictArgs, name, version, servingType, protocol, data string, headers []string) error {
+	predictArgs.Name = name
+	predictArgs.Version = version
+	predictArgs.Type = utils.TransferServingJobType(servingType)
+	if predictArgs.Type == types.UnknownServingJob {
+		return fmt.Errorf("unknown serving type %v,the possible option is [%v]", servingType, utils.GetSupportServingJobTypesInfo())
+	}
+	predictArgs.Protocol = types.ServingPredictProtocol(strings.ToLower(protocol))
+	body, err := readPredictData(data)
+	if err != nil {
+		return err
+	}
+	predictArgs.Data = body
+	predictArgs.Headers = map[string]string{}
+	for _, h := range headers {
+		parts := strings.SplitN(h, ":", 2)
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid header %v,it should be like 'key: value'", h)
+		}
+		predictArgs.Headers[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+	return nil
+}
+
+// readPredictData reads the request body from the data flag
+func readPredictData(data string) ([]byte, error) {
+	if !strings.HasPrefix(data, "@") {
+		return []byte(data), nil
+	}
+	file := strings.TrimPrefix(data, "@")
+	if file == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+	body, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request data from %v: %v", file, err)
+	}
+	return body, nil
+}
@@ -0,0 +1,49 @@
+package serving
+
+import (
+	"fmt"
+
+	"github.com/kubeflow/arena/pkg/apis/arenaclient"
+	"github.com/kubeflow/arena/pkg/apis/types"
+	"github.com/kubeflow/arena/pkg/apis/utils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// NewRollbackCommand
+func NewRollbackCommand() *cobra.Command {
+	var servingType string
+	var version string
+	var toRevision int
+	var command = &cobra.Command{
+		Use:   "rollback JOB [-T JOB_TYPE] [-v JOB_VERSION] [--to-revision REVISION]",
+		Short: "Rollback a serving job to a previous revision",
+		PreRun: func(cmd *cobra.Command, args []string) {
+			viper.BindPFlags(cmd.Flags())
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return fmt.Errorf("not set job name,please set it")
+			}
+			if toRevision < 0 {
+				return fmt.Errorf("--to-revision is invalid")
+			}
+			name := args[0]
+			client, err := arenaclient.NewArenaClient(types.ArenaClientArgs{
+				Kubeconfig:     viper.GetString("config"),
+				LogLevel:       viper.GetString("loglevel"),
+				Namespace:      viper.GetString("namespace"),
+				ArenaNamespace: viper.GetString("arena-namespace"),
+				IsDaemonMode:   false,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to create arena client: %v", err)
+			}
+			return client.Serving().Rollback(name, version, utils.TransferServingJobType(servingType), toRevision)
+		},
+	}
+	command.Flags().StringVarP(&version, "version", "v", "", "Set the serving job version")
+	command.Flags().StringVarP(&servingType, "type", "T", "", fmt.Sprintf("The serving type, the possible option is [%v]. (optional)", utils.GetSupportServingJobTypesInfo()))
+	command.Flags().IntVar(&toRevision, "to-revision", 0, "The revision to rollback to, default to the previous revision")
+	return command
+}
@@ -11,7 +11,10 @@ Available Commands:
   custom         Submit a Custom Serving Job  
   kfserving,kfs  Submit a kubeflow Serving Job
   kserve         Submit a KServe Serving Job
-  seldon         Submit a Seldon Serving Job`
+  seldon         Submit a Seldon Serving Job
+  torchserve     Submit a PyTorch TorchServe Serving Job
+  onnxruntime    Submit an ONNX Runtime Serving Job
+  from-job       Serve the model produced by a succeeded training job`
 )
 
 func NewServeCommand() *cobra.Command {
@@ -30,6 +33,9 @@ func NewServeCommand() *cobra.Command {
 	command.AddCommand(NewSubmitKServeJobCommand())
 	command.AddCommand(NewSubmitSeldonServingJobCommand())
 	command.AddCommand(NewSubmitTritonServingJobCommand())
+	command.AddCommand(NewSubmitTorchServeJobCommand())
+	command.AddCommand(NewSubmitONNXRuntimeServingJobCommand())
+	command.AddCommand(NewServeFromJobCommand())
 	command.AddCommand(NewListCommand())
 	command.AddCommand(NewDeleteCommand())
 	command.AddCommand(NewGetCommand())
@@ -37,6 +43,10 @@ func NewServeCommand() *cobra.Command {
 	command.AddCommand(NewLogsCommand())
 	command.AddCommand(NewTrafficRouterSplitCommand())
 	command.AddCommand(NewUpdateCommand())
+	command.AddCommand(NewHistoryCommand())
+	command.AddCommand(NewRollbackCommand())
+	command.AddCommand(NewPredictCommand())
+	command.AddCommand(NewLoadTestCommand())
 
 	return command
 }
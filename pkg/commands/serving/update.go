@@ -9,7 +9,9 @@ Available Commands:
   tensorflow,tf  Update a TensorFlow Serving Job
   triton         Update a Nvidia Triton Serving Job
   custom         Update a Custom Serving Job
-  kserve         Update a KServe Serving Job`
+  kserve         Update a KServe Serving Job
+  torchserve     Update a PyTorch TorchServe Serving Job
+  onnxruntime    Update an ONNX Runtime Serving Job`
 )
 
 func NewUpdateCommand() *cobra.Command {
@@ -25,6 +27,8 @@ func NewUpdateCommand() *cobra.Command {
 	command.AddCommand(NewUpdateTritonCommand())
 	command.AddCommand(NewUpdateCustomCommand())
 	command.AddCommand(NewUpdateKServeCommand())
+	command.AddCommand(NewUpdateTorchServeCommand())
+	command.AddCommand(NewUpdateONNXRuntimeCommand())
 
 	return command
 }
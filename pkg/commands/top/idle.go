@@ -0,0 +1,86 @@
+package top
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kubeflow/arena/pkg/apis/arenaclient"
+	"github.com/kubeflow/arena/pkg/apis/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func NewTopIdleCommand() *cobra.Command {
+	var (
+		allNamespaces bool
+		format        string
+		threshold     string
+		duration      time.Duration
+		action        string
+	)
+	var command = &cobra.Command{
+		Use:   "idle",
+		Short: "Display the jobs which hold gpus but leave them idle.",
+		PreRun: func(cmd *cobra.Command, args []string) {
+			viper.BindPFlags(cmd.Flags())
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			value, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(threshold), "%"), 64)
+			if err != nil {
+				return fmt.Errorf("invalid threshold %v,it should be a percent like 5%%", threshold)
+			}
+			client, err := arenaclient.NewArenaClient(types.ArenaClientArgs{
+				Kubeconfig:     viper.GetString("config"),
+				LogLevel:       viper.GetString("loglevel"),
+				Namespace:      viper.GetString("namespace"),
+				ArenaNamespace: viper.GetString("arena-namespace"),
+				IsDaemonMode:   false,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to create arena client: %v", err)
+			}
+			return client.IdleJob().ListAndPrint(&types.IdleJobArgs{
+				AllNamespaces: allNamespaces,
+				Threshold:     value,
+				Duration:      duration,
+				Action:        types.IdleJobAction(action),
+			}, format)
+		},
+	}
+	command.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "show all the namespaces")
+	command.Flags().StringVar(&threshold, "threshold", "5%", "The gpu utilization under which the gpu is regarded as idle")
+	command.Flags().DurationVar(&duration, "for", 2*time.Hour, "The minimal duration of the gpus keeping idle, like 30m or 2h")
+	command.Flags().StringVar(&action, "action", "", "The action taken on the idle jobs, one of: annotate|suspend. annotate marks the pods of jobs, suspend scales the serving jobs to 0 and suspends the tfjobs and pytorchjobs")
+	command.Flags().StringVarP(&format, "output", "o", "wide", "Output format. One of: json|yaml|wide")
+	command.AddCommand(NewTopIdleResumeCommand())
+	return command
+}
+
+func NewTopIdleResumeCommand() *cobra.Command {
+	var command = &cobra.Command{
+		Use:   "resume JOB_NAME",
+		Short: "Resume the job which is suspended by the suspend action.",
+		PreRun: func(cmd *cobra.Command, args []string) {
+			viper.BindPFlags(cmd.Flags())
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("the job name must be specified")
+			}
+			client, err := arenaclient.NewArenaClient(types.ArenaClientArgs{
+				Kubeconfig:     viper.GetString("config"),
+				LogLevel:       viper.GetString("loglevel"),
+				Namespace:      viper.GetString("namespace"),
+				ArenaNamespace: viper.GetString("arena-namespace"),
+				IsDaemonMode:   false,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to create arena client: %v", err)
+			}
+			return client.IdleJob().Resume(args[0])
+		},
+	}
+	return command
+}
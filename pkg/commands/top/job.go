@@ -2,6 +2,7 @@ package top
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/kubeflow/arena/pkg/apis/arenaclient"
 	"github.com/kubeflow/arena/pkg/apis/types"
@@ -17,14 +18,23 @@ func NewTopJobCommand() *cobra.Command {
 		jobType       string
 		notStop       bool
 		instanceName  string
+		since         time.Duration
 	)
 	var command = &cobra.Command{
-		Use:   "job",
+		Use:   "job [JOB]",
 		Short: "Display Resource (GPU) usage of jobs.",
 		PreRun: func(cmd *cobra.Command, args []string) {
 			viper.BindPFlags(cmd.Flags())
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if since > 0 {
+				if len(args) == 0 {
+					return fmt.Errorf("the job name is required when --since is set")
+				}
+				if notStop {
+					return fmt.Errorf("--since can not be used with --refresh")
+				}
+			}
 			isDaemonMode := false
 			if notStop {
 				isDaemonMode = true
@@ -39,6 +49,9 @@ func NewTopJobCommand() *cobra.Command {
 			if err != nil {
 				return fmt.Errorf("failed to create arena client: %v", err)
 			}
+			if since > 0 {
+				return client.Training().GPUHistoryAndPrint(args[0], utils.TransferTrainingJobType(jobType), since, format)
+			}
 			return client.Training().Top(
 				args,
 				allNamespaces,
@@ -55,6 +68,7 @@ func NewTopJobCommand() *cobra.Command {
 	command.Flags().StringVarP(&format, "output", "o", "wide", "Output format. One of: json|yaml|wide")
 	command.Flags().BoolVarP(&notStop, "refresh", "r", false, "Display continuously")
 	command.Flags().StringVarP(&instanceName, "instance", "i", "", "Display instance top info")
+	command.Flags().DurationVar(&since, "since", 0, "Display the avg/p95/max gpu utilization and memory of job instances over the time window, like 30m or 2h")
 	command.Flags().StringVarP(&jobType, "type", "T", "", fmt.Sprintf("The training type, the possible option is [%v]. (optional)", utils.GetSupportTrainingJobTypesInfo()))
 	return command
 }
@@ -16,6 +16,7 @@ package top
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -25,6 +26,7 @@ import (
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 func NewTopNodeCommand() *cobra.Command {
@@ -34,6 +36,15 @@ func NewTopNodeCommand() *cobra.Command {
 		output      string
 		nodeType    string
 		notStop     bool
+		fit         string
+		poolLabel   string
+		watch       bool
+		interval    time.Duration
+		selector    string
+		sortBy      string
+		showPods    bool
+		showHealth  bool
+		window      time.Duration
 	)
 	var command = &cobra.Command{
 		Use:   "node",
@@ -60,13 +71,86 @@ func NewTopNodeCommand() *cobra.Command {
 			if err != nil {
 				return fmt.Errorf("failed to create arena client: %v", err)
 			}
-			return client.Node().ListAndPrintNodes(args, utils.TransferNodeType(nodeType), utils.TransferPrintFormat(output), showDetails, notStop, showMetric)
+			if fit != "" {
+				if notStop {
+					return fmt.Errorf("'--fit' can not be used with '-r'")
+				}
+				fitArgs, err := parseNodeFitArgs(fit)
+				if err != nil {
+					return err
+				}
+				fitArgs.PoolLabel = poolLabel
+				return client.Node().FitAndPrint(fitArgs, utils.TransferPrintFormat(output))
+			}
+			return client.Node().TopAndPrintNodes(&types.TopNodeArgs{
+				NodeNames:    args,
+				NodeType:     utils.TransferNodeType(nodeType),
+				Selector:     selector,
+				SortBy:       types.NodeSortBy(sortBy),
+				ShowDetails:  showDetails,
+				ShowMetric:   showMetric,
+				ShowPods:     showPods,
+				ShowHealth:   showHealth,
+				HealthWindow: window,
+				Refresh:      notStop,
+				Watch:        watch,
+				Interval:     interval,
+			}, utils.TransferPrintFormat(output))
 		},
 	}
 	command.Flags().BoolVarP(&showDetails, "details", "d", false, "Display details")
 	command.Flags().BoolVarP(&notStop, "refresh", "r", false, "Display continuously")
 	command.Flags().StringVarP(&nodeType, "gpu-mode", "m", "", fmt.Sprintf("Display node information with following gpu mode:[%v]", strings.Join(utils.GetSupportedNodeTypes(), "|")))
 	command.Flags().StringVarP(&output, "output", "o", "wide", "Output format. One of: json|yaml|wide")
+	command.Flags().StringVar(&fit, "fit", "", `Check whether a job fits the nodes, usage: "--fit gpus=8,cpu=32,memory=200Gi[,workers=4]", the resources are requested by each worker and gpu-memory(GiB) of one device can be requested on gpushare nodes`)
+	command.Flags().StringVar(&poolLabel, "pool-label", types.DefaultNodePoolLabel, "The node label which groups nodes into node pools, it is used with '--fit'")
+	command.Flags().BoolVarP(&watch, "watch", "w", false, "Redraw the nodes in place every interval")
+	command.Flags().DurationVar(&interval, "interval", 5*time.Second, "The interval to redraw the nodes, it is used with '--watch'")
+	command.Flags().StringVarP(&selector, "selector", "l", "", "Filter the nodes by labels, like pool=a100")
+	command.Flags().StringVar(&sortBy, "sort-by", "", "Sort the nodes, one of: gpu-free|gpu-util|name. gpu-util works with prometheus")
+	command.Flags().BoolVar(&showPods, "pods", false, "List the arena jobs on each node and the gpus allocated to them")
+	command.Flags().BoolVar(&showHealth, "health", false, "Display the health of nodes, the gpu xid errors, ecc errors and thermal throttling are checked with prometheus")
+	command.Flags().DurationVar(&window, "health-window", types.DefaultNodeHealthWindow, "The time window to check the gpu xid errors, ecc errors and thermal throttling, it is used with '--health'")
 	command.Flags().BoolVar(&showMetric, "metric", false, "Work with prometheus,this option requires prometheus has been installed in cluster")
 	return command
 }
+
+// parseNodeFitArgs parses the request like "gpus=8,cpu=32,memory=200Gi,workers=4", the gpu-memory
+// is the gpu memory(GiB) of one device requested on gpushare nodes
+func parseNodeFitArgs(request string) (*types.NodeFitArgs, error) {
+	args := &types.NodeFitArgs{Workers: 1}
+	for _, item := range strings.Split(request, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		kv := strings.SplitN(item, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("invalid item %v of request,it should be like key=value", item)
+		}
+		key, value := strings.TrimSpace(kv[0]), strings.TrimSpace(kv[1])
+		var err error
+		switch key {
+		case "gpus":
+			args.GPUs, err = strconv.Atoi(value)
+		case "gpu-memory":
+			args.GPUMemory, err = strconv.ParseFloat(value, 64)
+		case "workers":
+			args.Workers, err = strconv.Atoi(value)
+		case "cpu", "memory":
+			var quantity resource.Quantity
+			quantity, err = resource.ParseQuantity(value)
+			if key == "cpu" {
+				args.CPU = float64(quantity.MilliValue()) / 1000
+			} else {
+				args.Memory = float64(quantity.Value())
+			}
+		default:
+			return nil, fmt.Errorf("unknown key %v of request,only support:[gpus|gpu-memory|cpu|memory|workers]", key)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %v of %v: %v", value, key, err)
+		}
+	}
+	return args, nil
+}
@@ -25,6 +25,8 @@ var (
 Available Commands:
   node        Display Resource (GPU) usage of nodes
   job         Display Resource (GPU) usage of pods
+  serving     Display Resource (GPU) usage and request metrics of serving jobs
+  idle        Display the jobs which hold gpus but leave them idle
     `
 )
 
@@ -41,6 +43,8 @@ func NewTopCommand() *cobra.Command {
 	// create subcommands
 	command.AddCommand(NewTopNodeCommand())
 	command.AddCommand(NewTopJobCommand())
+	command.AddCommand(NewTopServingCommand())
+	command.AddCommand(NewTopIdleCommand())
 
 	return command
 }
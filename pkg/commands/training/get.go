@@ -35,6 +35,7 @@ func NewGetCommand() *cobra.Command {
 	var jobType string
 	var showEvents bool
 	var showGPUs bool
+	var showGPUHistory bool
 	var output string
 	var command = &cobra.Command{
 		Use:   "get JOB [-T JOB_TYPE]",
@@ -62,12 +63,16 @@ func NewGetCommand() *cobra.Command {
 			if err != nil {
 				return fmt.Errorf("failed to create arena client: %v", err)
 			}
+			if showGPUHistory {
+				return client.Training().GetWithGPUHistoryAndPrint(name, utils.TransferTrainingJobType(jobType), output, showEvents, showGPUs)
+			}
 			return client.Training().GetAndPrint(name, utils.TransferTrainingJobType(jobType), output, showEvents, showGPUs)
 		},
 	}
 	command.Flags().StringVarP(&jobType, "type", "T", "", fmt.Sprintf("The training type to get, the possible option is %v. (optional)", utils.GetSupportTrainingJobTypesInfo()))
 	command.Flags().BoolVarP(&showEvents, "events", "e", false, "Specify if show pending pod's events.")
 	command.Flags().BoolVarP(&showGPUs, "gpus", "g", false, "Specify if show gpu utilizations of job.")
+	command.Flags().BoolVar(&showGPUHistory, "gpu-history", false, "Specify if show the gpu utilization and memory summary of the whole run of job.")
 	command.Flags().StringVarP(&output, "output", "o", "wide", "Output format. One of: json|yaml|wide")
 	return command
 }
@@ -0,0 +1,38 @@
+package usage
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kubeflow/arena/pkg/apis/arenaclient"
+	"github.com/kubeflow/arena/pkg/apis/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// NewUsageRecorderCommand runs the recorder which saves the usage of jobs into configmaps
+func NewUsageRecorderCommand() *cobra.Command {
+	var interval time.Duration
+	var command = &cobra.Command{
+		Use:   "recorder",
+		Short: "Record the usage of jobs continuously, it should run in the cluster.",
+		PreRun: func(cmd *cobra.Command, args []string) {
+			viper.BindPFlags(cmd.Flags())
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := arenaclient.NewArenaClient(types.ArenaClientArgs{
+				Kubeconfig:     viper.GetString("config"),
+				LogLevel:       viper.GetString("loglevel"),
+				Namespace:      viper.GetString("namespace"),
+				ArenaNamespace: viper.GetString("arena-namespace"),
+				IsDaemonMode:   true,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to create arena client: %v", err)
+			}
+			return client.Usage().RunRecorder(interval)
+		},
+	}
+	command.Flags().DurationVar(&interval, "interval", time.Minute, "The interval of recording the usage of jobs")
+	return command
+}
@@ -0,0 +1,79 @@
+package usage
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kubeflow/arena/pkg/apis/arenaclient"
+	"github.com/kubeflow/arena/pkg/apis/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// NewUsageReportCommand reports the usage of jobs in the time range
+func NewUsageReportCommand() *cobra.Command {
+	var (
+		allNamespaces bool
+		from          string
+		to            string
+		groupBy       string
+		format        string
+	)
+	var command = &cobra.Command{
+		Use:   "report",
+		Short: "Report the gpu hours, cpu core hours and memory GB hours of jobs.",
+		PreRun: func(cmd *cobra.Command, args []string) {
+			viper.BindPFlags(cmd.Flags())
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			now := time.Now()
+			reportArgs := &types.UsageReportArgs{
+				AllNamespaces: allNamespaces,
+				GroupBy:       types.UsageGroupBy(groupBy),
+				// the default time range is from the first day of this month to now
+				From: time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location()),
+				To:   now,
+			}
+			var err error
+			if from != "" {
+				if reportArgs.From, err = parseTime(from); err != nil {
+					return err
+				}
+			}
+			if to != "" {
+				if reportArgs.To, err = parseTime(to); err != nil {
+					return err
+				}
+			}
+			client, err := arenaclient.NewArenaClient(types.ArenaClientArgs{
+				Kubeconfig:     viper.GetString("config"),
+				LogLevel:       viper.GetString("loglevel"),
+				Namespace:      viper.GetString("namespace"),
+				ArenaNamespace: viper.GetString("arena-namespace"),
+				IsDaemonMode:   false,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to create arena client: %v", err)
+			}
+			return client.Usage().ReportAndPrint(reportArgs, format)
+		},
+	}
+	command.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "report the jobs of all namespaces")
+	command.Flags().StringVar(&from, "from", "", "The start of time range, like 2021-09-01 or 2021-09-01T08:00:00Z, default is the first day of this month")
+	command.Flags().StringVar(&to, "to", "", "The end of time range, like 2021-10-01 or 2021-10-01T08:00:00Z, default is now")
+	command.Flags().StringVar(&groupBy, "group-by", string(types.UsageGroupByNamespace), "Aggregate the usage by one of: namespace|user|job-type")
+	command.Flags().StringVarP(&format, "output", "o", "wide", "Output format. One of: csv|json|yaml|wide")
+	return command
+}
+
+// parseTime parses the date in local time or the time in RFC3339 format
+func parseTime(value string) (time.Time, error) {
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return t, fmt.Errorf("invalid time %v,it should be like 2021-09-01 or 2021-09-01T08:00:00Z", value)
+	}
+	return t, nil
+}
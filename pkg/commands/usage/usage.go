@@ -0,0 +1,31 @@
+package usage
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var (
+	usageLong = `report the resource usage of jobs.
+
+Available Commands:
+  report               Report the gpu hours, cpu core hours and memory GB hours of jobs.
+  recorder             Record the usage of jobs continuously, so that the pruned jobs are counted in reports.
+    `
+)
+
+// NewUsageCommand creates the usage command
+func NewUsageCommand() *cobra.Command {
+	var command = &cobra.Command{
+		Use:   "usage",
+		Short: "report the resource usage of jobs.",
+		Long:  usageLong,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.HelpFunc()(cmd, args)
+		},
+	}
+
+	command.AddCommand(NewUsageReportCommand())
+	command.AddCommand(NewUsageRecorderCommand())
+
+	return command
+}
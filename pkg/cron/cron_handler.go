@@ -2,13 +2,20 @@ package cron
 
 import (
 	"context"
+	"fmt"
 	"github.com/kubeflow/arena/pkg/apis/config"
 	"github.com/kubeflow/arena/pkg/apis/types"
 	"github.com/kubeflow/arena/pkg/k8saccesser"
 	"github.com/kubeflow/arena/pkg/operators/kubedl-operator/apis/apps/v1alpha1"
 	"github.com/kubeflow/arena/pkg/operators/kubedl-operator/client/clientset/versioned"
 	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 )
 
@@ -20,6 +27,8 @@ type CronHandler struct {
 	client *kubernetes.Clientset
 	// cronClient client
 	cronClient *versioned.Clientset
+	// dynamicClient is used to manage the workloads of cron
+	dynamicClient dynamic.Interface
 	// check if it's enabled
 	enabled bool
 }
@@ -37,9 +46,10 @@ func NewCronHandler() *CronHandler {
 	}
 	log.Debugf("Succeed to init CronHandler")
 	return &CronHandler{
-		client:     arenaConfiger.GetClientSet(),
-		cronClient: cronClient,
-		enabled:    enable,
+		client:        arenaConfiger.GetClientSet(),
+		cronClient:    cronClient,
+		dynamicClient: arenaConfiger.GetDynamicClient(),
+		enabled:       enable,
 	}
 }
 
@@ -93,12 +103,133 @@ func (ch *CronHandler) UpdateCron(namespace string, name string, suspend bool) e
 	return err
 }
 
+// UpdateCronSpec updates the schedule options and the workload template of the cron in place
+func (ch *CronHandler) UpdateCronSpec(namespace string, args *types.UpdateCronArgs) error {
+	cron, err := ch.cronClient.AppsV1alpha1().Crons(namespace).Get(context.TODO(), args.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	if args.Schedule != "" {
+		cron.Spec.Schedule = args.Schedule
+	}
+
+	if args.ConcurrencyPolicy != "" {
+		cron.Spec.ConcurrencyPolicy = v1alpha1.ConcurrencyPolicy(args.ConcurrencyPolicy)
+	}
+
+	if args.Deadline != "" {
+		deadline, err := parseDeadline(args.Deadline)
+		if err != nil {
+			return err
+		}
+		cron.Spec.Deadline = &metav1.Time{Time: deadline}
+	}
+
+	if args.HistoryLimit > 0 {
+		historyLimit := int32(args.HistoryLimit)
+		cron.Spec.HistoryLimit = &historyLimit
+	}
+
+	if args.Image != "" || len(args.Envs) > 0 {
+		if cron.Spec.CronTemplate.Workload == nil {
+			return fmt.Errorf("the workload template of cron %s is empty", args.Name)
+		}
+		raw, err := updateWorkloadContainers(cron.Spec.CronTemplate.Workload.Raw, args.Image, args.Envs)
+		if err != nil {
+			return err
+		}
+		cron.Spec.CronTemplate.Workload = &runtime.RawExtension{Raw: raw}
+	}
+
+	_, err = ch.cronClient.AppsV1alpha1().Crons(namespace).Update(context.TODO(), cron, metav1.UpdateOptions{})
+	return err
+}
+
+// TriggerCron creates a workload from the template of cron immediately, the workload is labeled and
+// controlled by the cron so that the cron controller records it in the status of cron,
+// the concurrency policy of cron is respected
+func (ch *CronHandler) TriggerCron(namespace string, name string) (string, error) {
+	cron, err := ch.cronClient.AppsV1alpha1().Crons(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	if cron.Spec.CronTemplate.Workload == nil {
+		return "", fmt.Errorf("the workload template of cron %s is empty", name)
+	}
+
+	switch cron.Spec.ConcurrencyPolicy {
+	case v1alpha1.ForbidConcurrent:
+		if len(cron.Status.Active) > 0 {
+			return "", fmt.Errorf("cron %s forbids concurrent runs, but it has %d active jobs", name, len(cron.Status.Active))
+		}
+	case v1alpha1.ReplaceConcurrent:
+		for _, active := range cron.Status.Active {
+			err = ch.deleteWorkload(namespace, active)
+			if err != nil && !errors.IsNotFound(err) {
+				return "", fmt.Errorf("failed to replace the active job %s of cron %s: %v", active.Name, name, err)
+			}
+			log.Debugf("the active job %s of cron %s is deleted", active.Name, name)
+		}
+	}
+
+	workload := &unstructured.Unstructured{}
+	if err := workload.UnmarshalJSON(cron.Spec.CronTemplate.Workload.Raw); err != nil {
+		return "", fmt.Errorf("failed to parse the workload template of cron %s: %v", name, err)
+	}
+	if workload.GetAPIVersion() == "" {
+		workload.SetAPIVersion(cron.Spec.CronTemplate.APIVersion)
+	}
+	if workload.GetKind() == "" {
+		workload.SetKind(cron.Spec.CronTemplate.Kind)
+	}
+
+	now := metav1.Now()
+	// keep the same naming rule with the jobs scheduled by the cron controller
+	workload.SetName(fmt.Sprintf("%s-%d", cron.Name, now.Unix()))
+	workload.SetNamespace(namespace)
+	labels := workload.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[cronNameLabelKey] = cron.Name
+	workload.SetLabels(labels)
+	workload.SetOwnerReferences([]metav1.OwnerReference{
+		*metav1.NewControllerRef(cron, v1alpha1.GroupVersion.WithKind("Cron")),
+	})
+
+	resource, err := workloadResource(workload.GroupVersionKind())
+	if err != nil {
+		return "", err
+	}
+	created, err := ch.dynamicClient.Resource(resource).Namespace(namespace).Create(context.TODO(), workload, metav1.CreateOptions{})
+	if err != nil {
+		return "", err
+	}
+	return created.GetName(), nil
+}
+
+func (ch *CronHandler) deleteWorkload(namespace string, ref corev1.ObjectReference) error {
+	resource, err := workloadResource(schema.FromAPIVersionAndKind(ref.APIVersion, ref.Kind))
+	if err != nil {
+		return err
+	}
+	if ref.Namespace != "" {
+		namespace = ref.Namespace
+	}
+	propagation := metav1.DeletePropagationBackground
+	return ch.dynamicClient.Resource(resource).Namespace(namespace).Delete(context.TODO(), ref.Name, metav1.DeleteOptions{
+		PropagationPolicy: &propagation,
+	})
+}
+
 func (ch *CronHandler) buildCronInfo(cron *v1alpha1.Cron) *types.CronInfo {
 	cronInfo := &types.CronInfo{
 		UUID:              string(cron.UID),
 		Name:              cron.Name,
 		Namespace:         cron.Namespace,
-		Type:              cron.Spec.CronTemplate.Kind,
+		Type:              cronTemplateType(cron),
 		Schedule:          cron.Spec.Schedule,
 		ConcurrencyPolicy: string(cron.Spec.ConcurrencyPolicy),
 		HistoryLimit:      int64(*cron.Spec.HistoryLimit),
@@ -125,11 +256,15 @@ func (ch *CronHandler) buildCronInfo(cron *v1alpha1.Cron) *types.CronInfo {
 			history := types.CronHistoryInfo{
 				Namespace: cron.Namespace,
 				Name:      item.Object.Name,
-				Group:     *item.Object.APIGroup,
 				Kind:      item.Object.Kind,
 				Status:    string(item.Status),
 			}
 
+			// the workloads of core api groups like batch/v1 Job may have no api group
+			if item.Object.APIGroup != nil {
+				history.Group = *item.Object.APIGroup
+			}
+
 			if item.Created != nil {
 				history.CreateTime = formatTime(item.Created.Time)
 			}
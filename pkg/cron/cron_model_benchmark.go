@@ -0,0 +1,26 @@
+package cron
+
+import (
+	"github.com/kubeflow/arena/pkg/apis/types"
+	"github.com/kubeflow/arena/pkg/model"
+	"github.com/kubeflow/arena/pkg/util"
+	"github.com/kubeflow/arena/pkg/workflow"
+	log "github.com/sirupsen/logrus"
+)
+
+func SubmitCronModelBenchmarkJob(namespace string, submitArgs *types.CronModelBenchmarkArgs) (err error) {
+	submitArgs.ModelBenchmarkArgs.Namespace = namespace
+	if submitArgs.ModelBenchmarkArgs.Command == "" {
+		submitArgs.ModelBenchmarkArgs.Command = model.BuildModelBenchmarkCommand(&submitArgs.ModelBenchmarkArgs)
+	}
+	cronChart := util.GetChartsFolder() + "/cron-model-benchmark"
+
+	err = workflow.SubmitJobByHelm(submitArgs.Name, string(types.CronModelBenchmarkJob), namespace, submitArgs, cronChart, submitArgs.HelmOptions...)
+	if err != nil {
+		return err
+	}
+	log.Infof("The cron model benchmark job %s has been submitted successfully", submitArgs.Name)
+	log.Infof("You can run `arena cron get %s` to check the cron status", submitArgs.Name)
+
+	return nil
+}
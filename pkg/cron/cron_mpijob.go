@@ -0,0 +1,21 @@
+package cron
+
+import (
+	"github.com/kubeflow/arena/pkg/apis/types"
+	"github.com/kubeflow/arena/pkg/util"
+	"github.com/kubeflow/arena/pkg/workflow"
+	log "github.com/sirupsen/logrus"
+)
+
+func SubmitCronMPIJob(namespace string, submitArgs *types.CronMPIJobArgs) (err error) {
+	cronChart := util.GetChartsFolder() + "/cron-mpijob"
+
+	err = workflow.SubmitJobByHelm(submitArgs.Name, string(types.CronMPITrainingJob), namespace, submitArgs, cronChart, submitArgs.HelmOptions...)
+	if err != nil {
+		return err
+	}
+	log.Infof("The cron mpijob %s has been submitted successfully", submitArgs.Name)
+	log.Infof("You can run `arena cron get %s` to check the cron status", submitArgs.Name)
+
+	return nil
+}
@@ -0,0 +1,21 @@
+package cron
+
+import (
+	"github.com/kubeflow/arena/pkg/apis/types"
+	"github.com/kubeflow/arena/pkg/util"
+	"github.com/kubeflow/arena/pkg/workflow"
+	log "github.com/sirupsen/logrus"
+)
+
+func SubmitCronPyTorchJob(namespace string, submitArgs *types.CronPyTorchJobArgs) (err error) {
+	cronChart := util.GetChartsFolder() + "/cron-pytorchjob"
+
+	err = workflow.SubmitJobByHelm(submitArgs.Name, string(types.CronPyTorchTrainingJob), namespace, submitArgs, cronChart, submitArgs.HelmOptions...)
+	if err != nil {
+		return err
+	}
+	log.Infof("The cron pytorchjob %s has been submitted successfully", submitArgs.Name)
+	log.Infof("You can run `arena cron get %s` to check the cron status", submitArgs.Name)
+
+	return nil
+}
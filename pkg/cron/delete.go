@@ -3,7 +3,6 @@ package cron
 import (
 	"fmt"
 	"github.com/kubeflow/arena/pkg/util/kubectl"
-	"strings"
 )
 
 func DeleteCron(name, namespace, jobType string) error {
@@ -14,6 +13,6 @@ func DeleteCron(name, namespace, jobType string) error {
 	out := fmt.Sprintf("cron %s has deleted", name)
 	fmt.Println(out)
 
-	configMapName := fmt.Sprintf("%s-%s", name, strings.ToLower(jobType))
+	configMapName := fmt.Sprintf("%s-%s", name, cronTypeOfKind(jobType))
 	return kubectl.DeleteAppConfigMap(configMapName, namespace)
 }
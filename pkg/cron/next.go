@@ -0,0 +1,141 @@
+package cron
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/kubeflow/arena/pkg/apis/types"
+	robfigcron "github.com/robfig/cron/v3"
+	"gopkg.in/yaml.v2"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// scheduleParser parses the schedule of cron the same as the kubedl controller, which uses the
+// standard 5 fields and the descriptors like @daily and @every 1h, the CRON_TZ prefix is supported
+var scheduleParser = robfigcron.NewParser(
+	robfigcron.Minute | robfigcron.Hour | robfigcron.Dom | robfigcron.Month | robfigcron.Dow | robfigcron.Descriptor,
+)
+
+var nextCronTemplate = `
+Name:       %v
+Namespace:  %v
+Schedule:   %v
+Timezone:   %v
+Suspend:    %v
+Deadline:   %v
+%v
+`
+
+func parseSchedule(schedule string) (robfigcron.Schedule, error) {
+	return scheduleParser.Parse(schedule)
+}
+
+// parseControllerSchedule parses the schedule in the timezone of the kubedl controller, which is UTC
+// unless the schedule has the CRON_TZ prefix
+func parseControllerSchedule(schedule string) (robfigcron.Schedule, error) {
+	if !strings.HasPrefix(schedule, "CRON_TZ=") && !strings.HasPrefix(schedule, "TZ=") {
+		schedule = "CRON_TZ=UTC " + schedule
+	}
+	return scheduleParser.Parse(schedule)
+}
+
+// GetCronNextSchedules computes the next fire times of the cron from now,
+// the times after the deadline of cron are dropped
+func GetCronNextSchedules(name, namespace string, count int, timezone string) (*types.CronScheduleInfo, error) {
+	if count <= 0 {
+		return nil, fmt.Errorf("the count of fire times must be greater than 0")
+	}
+
+	location := time.Local
+	if timezone != "" {
+		loc, err := time.LoadLocation(timezone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timezone %s: %v", timezone, err)
+		}
+		location = loc
+	}
+
+	cronInfo, err := GetCronInfo(name, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	var deadline time.Time
+	if cronInfo.Deadline != "" {
+		deadline, err = parseTime(cronInfo.Deadline)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	nextTimes, err := nextScheduleTimes(cronInfo.Schedule, time.Now(), count, deadline, location)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule %s of cron %s: %v", cronInfo.Schedule, name, err)
+	}
+
+	return &types.CronScheduleInfo{
+		Name:              cronInfo.Name,
+		Namespace:         cronInfo.Namespace,
+		Schedule:          cronInfo.Schedule,
+		Timezone:          location.String(),
+		Suspend:           cronInfo.Suspend,
+		Deadline:          cronInfo.Deadline,
+		NextScheduleTimes: nextTimes,
+	}, nil
+}
+
+// nextScheduleTimes returns at most count fire times after from, the schedule is evaluated in the timezone
+// of controller and the fire times are formatted in location
+func nextScheduleTimes(schedule string, from time.Time, count int, deadline time.Time, location *time.Location) ([]string, error) {
+	s, err := parseControllerSchedule(schedule)
+	if err != nil {
+		return nil, err
+	}
+	nextTimes := []string{}
+	next := from
+	for i := 0; i < count; i++ {
+		next = s.Next(next)
+		if next.IsZero() || (!deadline.IsZero() && next.After(deadline)) {
+			break
+		}
+		nextTimes = append(nextTimes, next.In(location).Format(time.RFC3339))
+	}
+	return nextTimes, nil
+}
+
+func DisplayCronNextSchedules(scheduleInfo *types.CronScheduleInfo, format types.FormatStyle) {
+	switch format {
+	case "json":
+		data, _ := json.MarshalIndent(scheduleInfo, "", "    ")
+		fmt.Printf("%v", string(data))
+		return
+	case "yaml":
+		data, _ := yaml.Marshal(scheduleInfo)
+		fmt.Printf("%v", string(data))
+		return
+	case "", "wide":
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+		lines := []string{"\nNext Schedules:", "INDEX\tTIME"}
+		lines = append(lines, "-----\t----")
+		for i, item := range scheduleInfo.NextScheduleTimes {
+			lines = append(lines, fmt.Sprintf("%d\t%s", i+1, item))
+		}
+
+		printLine(w, fmt.Sprintf(strings.Trim(nextCronTemplate, "\n"),
+			scheduleInfo.Name,
+			scheduleInfo.Namespace,
+			scheduleInfo.Schedule,
+			scheduleInfo.Timezone,
+			strconv.FormatBool(scheduleInfo.Suspend),
+			scheduleInfo.Deadline,
+			strings.Join(lines, "\n"),
+		))
+
+		_ = w.Flush()
+		return
+	}
+}
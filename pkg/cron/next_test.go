@@ -0,0 +1,87 @@
+package cron
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseSchedule(t *testing.T) {
+	tc := []struct {
+		Schedule string
+		Expected bool
+	}{
+		{Schedule: "0 23 * * *", Expected: true},
+		{Schedule: "*/5 * * * 1-5", Expected: true},
+		{Schedule: "@daily", Expected: true},
+		{Schedule: "@every 1h", Expected: true},
+		{Schedule: "CRON_TZ=UTC 0 23 * * *", Expected: true},
+		{Schedule: "0 0 23 * * ?", Expected: false},
+		{Schedule: "0 0 23 * * *", Expected: false},
+		{Schedule: "0 23 * *", Expected: false},
+	}
+	for _, c := range tc {
+		_, err := parseSchedule(c.Schedule)
+		if actual := err == nil; actual != c.Expected {
+			t.Errorf("parseSchedule(%q): Expected %v; Got %v(%v)", c.Schedule, c.Expected, actual, err)
+		}
+	}
+}
+
+func TestNextScheduleTimes(t *testing.T) {
+	// the client runs in UTC+8, the controller evaluates the schedule in UTC
+	local := time.Local
+	time.Local = time.FixedZone("UTC+8", 8*3600)
+	defer func() { time.Local = local }()
+
+	from := time.Date(2021, 8, 20, 12, 0, 0, 0, time.UTC)
+	tc := []struct {
+		Schedule string
+		Location *time.Location
+		Deadline time.Time
+		Expected []string
+	}{
+		{
+			Schedule: "0 23 * * *",
+			Location: time.Local,
+			Expected: []string{"2021-08-21T07:00:00+08:00", "2021-08-22T07:00:00+08:00"},
+		},
+		{
+			Schedule: "0 23 * * *",
+			Location: time.UTC,
+			Expected: []string{"2021-08-20T23:00:00Z", "2021-08-21T23:00:00Z"},
+		},
+		{
+			Schedule: "CRON_TZ=Etc/GMT-8 0 23 * * *",
+			Location: time.UTC,
+			Expected: []string{"2021-08-20T15:00:00Z", "2021-08-21T15:00:00Z"},
+		},
+		{
+			Schedule: "0 23 * * *",
+			Location: time.UTC,
+			Deadline: time.Date(2021, 8, 21, 0, 0, 0, 0, time.UTC),
+			Expected: []string{"2021-08-20T23:00:00Z"},
+		},
+	}
+	for _, c := range tc {
+		if _, err := time.LoadLocation("Etc/GMT-8"); err != nil && strings.HasPrefix(c.Schedule, "CRON_TZ=") {
+			t.Logf("skip %q since the timezone database is not found", c.Schedule)
+			continue
+		}
+		actual, err := nextScheduleTimes(c.Schedule, from, 2, c.Deadline, c.Location)
+		if err != nil {
+			t.Errorf("nextScheduleTimes(%q): unexpected error %v", c.Schedule, err)
+			continue
+		}
+		if len(actual) != len(c.Expected) {
+			t.Errorf("nextScheduleTimes(%q): Expected %v; Got %v", c.Schedule, c.Expected, actual)
+			continue
+		}
+		for i := range actual {
+			if actual[i] != c.Expected[i] {
+				t.Errorf("nextScheduleTimes(%q): Expected %v; Got %v", c.Schedule, c.Expected, actual)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,14 @@
+package cron
+
+import "fmt"
+
+// TriggerCron creates a job from the cron immediately
+func TriggerCron(name string, namespace string) error {
+	jobName, err := GetCronHandler().TriggerCron(namespace, name)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("cron %s trigger success, job %s is created\n", name, jobName)
+	return nil
+}
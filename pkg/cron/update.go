@@ -0,0 +1,144 @@
+package cron
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/kubeflow/arena/pkg/apis/types"
+	"sort"
+	"time"
+)
+
+// UpdateCron updates the cron in place
+func UpdateCron(namespace string, args *types.UpdateCronArgs) error {
+	if err := validateUpdateCronArgs(args); err != nil {
+		return err
+	}
+
+	err := GetCronHandler().UpdateCronSpec(namespace, args)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("cron %s update success\n", args.Name)
+	return nil
+}
+
+func validateUpdateCronArgs(args *types.UpdateCronArgs) error {
+	if args.Schedule == "" && args.ConcurrencyPolicy == "" && args.Deadline == "" &&
+		args.HistoryLimit == 0 && args.Image == "" && len(args.Envs) == 0 {
+		return fmt.Errorf("nothing to update, please set at least one of --schedule,--concurrency-policy,--deadline,--history-limit,--image,--env")
+	}
+
+	if args.Schedule != "" {
+		if _, err := parseSchedule(args.Schedule); err != nil {
+			return fmt.Errorf("invalid schedule %s: %v", args.Schedule, err)
+		}
+	}
+
+	switch types.ConcurrencyPolicy(args.ConcurrencyPolicy) {
+	case "", types.ConcurrencyAllow, types.ConcurrencyForbid, types.ConcurrencyReplace:
+	default:
+		return fmt.Errorf("invalid concurrency policy %s, only support:[Allow|Forbid|Replace]", args.ConcurrencyPolicy)
+	}
+
+	if args.Deadline != "" {
+		if _, err := parseDeadline(args.Deadline); err != nil {
+			return err
+		}
+	}
+
+	if args.HistoryLimit < 0 {
+		return fmt.Errorf("--history-limit must be greater than 0")
+	}
+	return nil
+}
+
+func parseDeadline(deadline string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, deadline)
+	if err != nil {
+		return t, fmt.Errorf("invalid deadline %s, it should be like %s: %v", deadline, formatLayout, err)
+	}
+	return t, nil
+}
+
+// updateWorkloadContainers sets the image and envs of all containers found in the pod templates
+// of the workload, like the replica specs of tfjob or the template of batch job
+func updateWorkloadContainers(raw []byte, image string, envs map[string]string) ([]byte, error) {
+	workload := map[string]interface{}{}
+	if err := json.Unmarshal(raw, &workload); err != nil {
+		return nil, fmt.Errorf("failed to parse the workload template of cron: %v", err)
+	}
+
+	count := walkContainers(workload, func(container map[string]interface{}) {
+		if image != "" {
+			container["image"] = image
+		}
+		if len(envs) > 0 {
+			container["env"] = mergeContainerEnvs(container["env"], envs)
+		}
+	})
+	if count == 0 {
+		return nil, fmt.Errorf("not found any container in the workload template of cron")
+	}
+
+	return json.Marshal(workload)
+}
+
+// walkContainers calls fn for every container of the object and returns the number of containers
+func walkContainers(obj interface{}, fn func(container map[string]interface{})) int {
+	count := 0
+	switch value := obj.(type) {
+	case map[string]interface{}:
+		for key, item := range value {
+			containers, ok := item.([]interface{})
+			if key != "containers" || !ok {
+				count += walkContainers(item, fn)
+				continue
+			}
+			for _, c := range containers {
+				if container, ok := c.(map[string]interface{}); ok {
+					fn(container)
+					count++
+				}
+			}
+		}
+	case []interface{}:
+		for _, item := range value {
+			count += walkContainers(item, fn)
+		}
+	}
+	return count
+}
+
+// mergeContainerEnvs replaces the values of the existing envs and appends the new ones
+func mergeContainerEnvs(current interface{}, envs map[string]string) []interface{} {
+	items, _ := current.([]interface{})
+	updated := map[string]bool{}
+	for _, item := range items {
+		env, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := env["name"].(string)
+		if value, found := envs[name]; found {
+			env["value"] = value
+			delete(env, "valueFrom")
+			updated[name] = true
+		}
+	}
+
+	var names []string
+	for name := range envs {
+		if !updated[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		items = append(items, map[string]interface{}{
+			"name":  name,
+			"value": envs[name],
+		})
+	}
+	return items
+}
@@ -2,6 +2,8 @@ package cron
 
 import (
 	"fmt"
+	"github.com/kubeflow/arena/pkg/apis/config"
+	"github.com/kubeflow/arena/pkg/operators/kubedl-operator/apis/apps/v1alpha1"
 	"io"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"strings"
@@ -18,6 +20,41 @@ var gvr = schema.GroupVersionResource{
 	Resource: "crons",
 }
 
+// cronTypeLabelKey is the label of cron whose workload kind can not tell the cron type,
+// like the model benchmark which runs as a tfjob
+const cronTypeLabelKey = "arena.kubeflow.org/cron-type"
+
+// cronNameLabelKey is the label which the cron controller puts on the workloads of cron,
+// the controller adopts the labeled workloads which are controlled by the cron into its status
+const cronNameLabelKey = "kubedl.io/cron-name"
+
+// cronTypeOfKind returns the cron type of the workload kind in the cron template or the cron type label,
+// it is used to find the configmap which is created when submitting the cron
+func cronTypeOfKind(kind string) string {
+	return strings.ToLower(kind)
+}
+
+// cronTemplateType returns the cron type label of cron, or the workload kind in the cron template
+func cronTemplateType(cron *v1alpha1.Cron) string {
+	if cronType := cron.Labels[cronTypeLabelKey]; cronType != "" {
+		return cronType
+	}
+	return cron.Spec.CronTemplate.Kind
+}
+
+// workloadResource finds the resource of the workload kind, like tfjobs for TFJob
+func workloadResource(gvk schema.GroupVersionKind) (schema.GroupVersionResource, error) {
+	mapper, err := config.GetArenaConfiger().ToRESTMapper()
+	if err != nil {
+		return schema.GroupVersionResource{}, err
+	}
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return schema.GroupVersionResource{}, fmt.Errorf("failed to find the resource of %s: %v", gvk.String(), err)
+	}
+	return mapping.Resource, nil
+}
+
 func printLine(w io.Writer, fields ...string) {
 	buffer := strings.Join(fields, "\t")
 	fmt.Fprintln(w, buffer)
@@ -0,0 +1,178 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datahouse
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kubeflow/arena/pkg/apis/config"
+	"github.com/kubeflow/arena/pkg/apis/types"
+	log "github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var supportedAccessModes = []v1.PersistentVolumeAccessMode{
+	v1.ReadWriteOnce,
+	v1.ReadOnlyMany,
+	v1.ReadWriteMany,
+	v1.ReadWriteOncePod,
+}
+
+// CreateDataVolume creates the pvc of data volume, the pv is also created and bound to
+// the pvc if the volume is backed by nfs or host path
+func CreateDataVolume(args *types.DataVolumeArgs) error {
+	sourceType, err := validateDataVolumeArgs(args)
+	if err != nil {
+		return err
+	}
+	size, err := resource.ParseQuantity(args.Size)
+	if err != nil {
+		return fmt.Errorf("invalid size %s: %v", args.Size, err)
+	}
+	client := config.GetArenaConfiger().GetClientSet()
+	_, err = client.CoreV1().PersistentVolumeClaims(args.Namespace).Get(context.TODO(), args.Name, metav1.GetOptions{})
+	if err == nil {
+		return fmt.Errorf("the data volume %s already exists in namespace %s", args.Name, args.Namespace)
+	}
+	if !k8serrors.IsNotFound(err) {
+		return err
+	}
+
+	labels := map[string]string{
+		"createdBy":           "arena",
+		types.DataVolumeLabel: args.Name,
+	}
+	annotations := map[string]string{
+		dataOwner: config.GetArenaConfiger().GetUser().GetName(),
+	}
+	if args.Description != "" {
+		annotations[dataDescritpion] = args.Description
+	}
+	accessModes := []v1.PersistentVolumeAccessMode{v1.PersistentVolumeAccessMode(args.AccessMode)}
+	pvc := &v1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        args.Name,
+			Namespace:   args.Namespace,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Spec: v1.PersistentVolumeClaimSpec{
+			AccessModes: accessModes,
+			Resources: v1.ResourceRequirements{
+				Requests: v1.ResourceList{
+					v1.ResourceStorage: size,
+				},
+			},
+		},
+	}
+	if sourceType == types.StorageClassDataVolume {
+		pvc.Spec.StorageClassName = &args.StorageClass
+		if _, err := client.CoreV1().PersistentVolumeClaims(args.Namespace).Create(context.TODO(), pvc, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("failed to create pvc %s: %v", args.Name, err)
+		}
+		log.Infof("the data volume %s is created, it is provisioned by storage class %s", args.Name, args.StorageClass)
+		return nil
+	}
+
+	// the pv is bound to the pvc statically, so the empty storage class is used to disable the dynamic provisioning
+	storageClass := ""
+	pv := &v1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        dataVolumePVName(args.Namespace, args.Name),
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Spec: v1.PersistentVolumeSpec{
+			Capacity: v1.ResourceList{
+				v1.ResourceStorage: size,
+			},
+			AccessModes:                   accessModes,
+			PersistentVolumeReclaimPolicy: v1.PersistentVolumeReclaimRetain,
+			StorageClassName:              storageClass,
+			ClaimRef: &v1.ObjectReference{
+				Namespace: args.Namespace,
+				Name:      args.Name,
+			},
+		},
+	}
+	switch sourceType {
+	case types.NFSDataVolume:
+		items := strings.SplitN(args.NFS, ":", 2)
+		pv.Spec.NFS = &v1.NFSVolumeSource{
+			Server: items[0],
+			Path:   items[1],
+		}
+	case types.HostPathDataVolume:
+		pv.Spec.HostPath = &v1.HostPathVolumeSource{
+			Path: args.HostPath,
+		}
+	}
+	if _, err := client.CoreV1().PersistentVolumes().Create(context.TODO(), pv, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("failed to create pv %s: %v", pv.Name, err)
+	}
+	pvc.Spec.StorageClassName = &storageClass
+	pvc.Spec.VolumeName = pv.Name
+	if _, err := client.CoreV1().PersistentVolumeClaims(args.Namespace).Create(context.TODO(), pvc, metav1.CreateOptions{}); err != nil {
+		if deleteErr := client.CoreV1().PersistentVolumes().Delete(context.TODO(), pv.Name, metav1.DeleteOptions{}); deleteErr != nil {
+			log.Warnf("failed to clean up pv %s: %v", pv.Name, deleteErr)
+		}
+		return fmt.Errorf("failed to create pvc %s: %v", args.Name, err)
+	}
+	log.Infof("the data volume %s is created, it is bound to pv %s", args.Name, pv.Name)
+	return nil
+}
+
+func validateDataVolumeArgs(args *types.DataVolumeArgs) (types.DataVolumeSourceType, error) {
+	sources := []types.DataVolumeSourceType{}
+	if args.NFS != "" {
+		items := strings.SplitN(args.NFS, ":", 2)
+		if len(items) != 2 || items[0] == "" || !strings.HasPrefix(items[1], "/") {
+			return "", fmt.Errorf("invalid nfs export %s, it should be like server:/path", args.NFS)
+		}
+		sources = append(sources, types.NFSDataVolume)
+	}
+	if args.HostPath != "" {
+		if !strings.HasPrefix(args.HostPath, "/") {
+			return "", fmt.Errorf("invalid host path %s, it should be an absolute path", args.HostPath)
+		}
+		sources = append(sources, types.HostPathDataVolume)
+	}
+	if args.StorageClass != "" {
+		sources = append(sources, types.StorageClassDataVolume)
+	}
+	if len(sources) != 1 {
+		return "", fmt.Errorf("one and only one of --nfs, --hostpath and --storage-class should be specified")
+	}
+	for _, mode := range supportedAccessModes {
+		if string(mode) == args.AccessMode {
+			return sources[0], nil
+		}
+	}
+	modes := []string{}
+	for _, mode := range supportedAccessModes {
+		modes = append(modes, string(mode))
+	}
+	return "", fmt.Errorf("invalid access mode %s, only support [%s]", args.AccessMode, strings.Join(modes, "|"))
+}
+
+// dataVolumePVName returns the name of pv, the namespace is included because pv is cluster scoped
+func dataVolumePVName(namespace, name string) string {
+	return fmt.Sprintf("%s-%s", namespace, name)
+}
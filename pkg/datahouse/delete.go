@@ -0,0 +1,75 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datahouse
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kubeflow/arena/pkg/apis/config"
+	"github.com/kubeflow/arena/pkg/apis/types"
+	log "github.com/sirupsen/logrus"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DeleteDataVolume deletes the pvc of data volume, it refuses to delete the volume which is still
+// mounted by jobs. The pv is also deleted if it is created by arena, but the data in the backend
+// storage is retained
+func DeleteDataVolume(namespace, name string) error {
+	client := config.GetArenaConfiger().GetClientSet()
+	pvc, err := client.CoreV1().PersistentVolumeClaims(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return fmt.Errorf("not found the data volume %s in namespace %s", name, namespace)
+		}
+		return err
+	}
+	users, err := listDataVolumeUsers(namespace, name)
+	if err != nil {
+		return err
+	}
+	if len(users) != 0 {
+		items := []string{}
+		for _, user := range users {
+			items = append(items, fmt.Sprintf("%s job %s", user.Kind, user.Name))
+		}
+		return fmt.Errorf("the data volume %s is still in use by %s, please delete them first", name, strings.Join(items, ","))
+	}
+	if err := client.CoreV1().PersistentVolumeClaims(namespace).Delete(context.TODO(), name, metav1.DeleteOptions{}); err != nil {
+		return fmt.Errorf("failed to delete pvc %s: %v", name, err)
+	}
+	log.Infof("the data volume %s is deleted", name)
+	if pvc.Labels[types.DataVolumeLabel] != name || pvc.Spec.VolumeName == "" {
+		return nil
+	}
+	pv, err := client.CoreV1().PersistentVolumes().Get(context.TODO(), pvc.Spec.VolumeName, metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	// the pv which is provisioned by storage class is deleted by its reclaim policy
+	if pv.Labels[types.DataVolumeLabel] != name {
+		return nil
+	}
+	if err := client.CoreV1().PersistentVolumes().Delete(context.TODO(), pv.Name, metav1.DeleteOptions{}); err != nil {
+		return fmt.Errorf("failed to delete pv %s: %v", pv.Name, err)
+	}
+	log.Infof("the pv %s of data volume %s is deleted", pv.Name, name)
+	return nil
+}
@@ -0,0 +1,229 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datahouse
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/kubeflow/arena/pkg/apis/config"
+	"github.com/kubeflow/arena/pkg/apis/types"
+	"github.com/kubeflow/arena/pkg/k8saccesser"
+	"gopkg.in/yaml.v2"
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var describeDataVolumeTemplate = `
+Name:               %v
+Namespace:          %v
+Status:             %v
+Capacity:           %v
+AccessModes:        %v
+StorageClass:       %v
+Volume:             %v
+Source:             %v
+Description:        %v
+Owner:              %v
+CreatedByArena:     %v
+CreationTimestamp:  %v
+%v
+`
+
+// GetDataVolume returns the detail of data volume, the jobs which are mounting the volume are included
+func GetDataVolume(namespace, name string) (*types.DataVolumeInfo, error) {
+	client := config.GetArenaConfiger().GetClientSet()
+	pvc, err := client.CoreV1().PersistentVolumeClaims(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, fmt.Errorf("not found the data volume %s in namespace %s", name, namespace)
+		}
+		return nil, err
+	}
+	storage := pvc.Status.Capacity[v1.ResourceStorage]
+	if storage.IsZero() {
+		storage = pvc.Spec.Resources.Requests[v1.ResourceStorage]
+	}
+	info := &types.DataVolumeInfo{
+		Name:              pvc.Name,
+		Namespace:         pvc.Namespace,
+		Status:            string(pvc.Status.Phase),
+		VolumeName:        pvc.Spec.VolumeName,
+		Capacity:          storage.String(),
+		AccessModes:       getAccessModesAsString(pvc.Spec.AccessModes),
+		Description:       pvc.Annotations[dataDescritpion],
+		Owner:             pvc.Annotations[dataOwner],
+		CreatedByArena:    pvc.Labels[types.DataVolumeLabel] == pvc.Name,
+		CreationTimestamp: pvc.CreationTimestamp.Format("2006-01-02 15:04:05"),
+	}
+	if pvc.Spec.StorageClassName != nil {
+		info.StorageClass = *pvc.Spec.StorageClassName
+	}
+	if pvc.Spec.VolumeName != "" {
+		pv, err := client.CoreV1().PersistentVolumes().Get(context.TODO(), pvc.Spec.VolumeName, metav1.GetOptions{})
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return nil, err
+		}
+		if err == nil {
+			switch {
+			case pv.Spec.NFS != nil:
+				info.SourceType = types.NFSDataVolume
+				info.Source = fmt.Sprintf("%s:%s", pv.Spec.NFS.Server, pv.Spec.NFS.Path)
+			case pv.Spec.HostPath != nil:
+				info.SourceType = types.HostPathDataVolume
+				info.Source = pv.Spec.HostPath.Path
+			case pv.Spec.CSI != nil:
+				info.Source = fmt.Sprintf("%s:%s", pv.Spec.CSI.Driver, pv.Spec.CSI.VolumeHandle)
+			}
+		}
+	}
+	if info.SourceType == "" && info.StorageClass != "" {
+		info.SourceType = types.StorageClassDataVolume
+	}
+	info.Users, err = listDataVolumeUsers(namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// listDataVolumeUsers scans the volumes of pods to find the jobs which are mounting the pvc,
+// the pods which have completed are ignored
+func listDataVolumeUsers(namespace, pvcName string) ([]types.DataVolumeUser, error) {
+	pods, err := k8saccesser.GetK8sResourceAccesser().ListPods(namespace, "", "", func(pod *v1.Pod) bool {
+		if pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
+			return false
+		}
+		for _, volume := range pod.Spec.Volumes {
+			if volume.PersistentVolumeClaim != nil && volume.PersistentVolumeClaim.ClaimName == pvcName {
+				return true
+			}
+		}
+		return false
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %v", err)
+	}
+	users := []types.DataVolumeUser{}
+	index := map[string]int{}
+	for _, pod := range pods {
+		user := getDataVolumeUser(pod)
+		key := fmt.Sprintf("%s/%s/%s", user.Kind, user.Type, user.Name)
+		i, ok := index[key]
+		if !ok {
+			i = len(users)
+			index[key] = i
+			users = append(users, user)
+		}
+		users[i].Pods = append(users[i].Pods, pod.Name)
+	}
+	sort.Slice(users, func(i, j int) bool {
+		if users[i].Kind != users[j].Kind {
+			return users[i].Kind < users[j].Kind
+		}
+		return users[i].Name < users[j].Name
+	})
+	return users, nil
+}
+
+// getDataVolumeUser finds the job of pod by the labels which are set by the charts of arena
+func getDataVolumeUser(pod *v1.Pod) types.DataVolumeUser {
+	app := pod.Labels["app"]
+	release := pod.Labels["release"]
+	switch {
+	case pod.Labels["servingName"] != "":
+		return types.DataVolumeUser{Kind: "serving", Name: pod.Labels["servingName"], Type: pod.Labels["servingType"]}
+	case app == "modeljob" && release != "":
+		return types.DataVolumeUser{Kind: "model", Name: release, Type: pod.Labels["type"]}
+	case app == "evaluatejob" && release != "":
+		return types.DataVolumeUser{Kind: "evaluate", Name: release}
+	}
+	if _, ok := types.TrainingTypeMap[types.TrainingJobType(app)]; ok && release != "" {
+		return types.DataVolumeUser{Kind: "training", Name: release, Type: app}
+	}
+	name := pod.Name
+	for _, owner := range pod.OwnerReferences {
+		if owner.Controller != nil && *owner.Controller {
+			name = owner.Name
+			break
+		}
+	}
+	return types.DataVolumeUser{Kind: "other", Name: name}
+}
+
+// DisplayDataVolume prints the detail of data volume
+func DisplayDataVolume(info *types.DataVolumeInfo, format types.FormatStyle) {
+	switch format {
+	case "json":
+		data, _ := json.MarshalIndent(info, "", "    ")
+		fmt.Printf("%v", string(data))
+		return
+	case "yaml":
+		data, _ := yaml.Marshal(info)
+		fmt.Printf("%v", string(data))
+		return
+	case "", "wide":
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		lines := []string{"\nMounted By:"}
+		if len(info.Users) == 0 {
+			lines = append(lines, "none")
+		} else {
+			lines = append(lines, "KIND\tNAME\tTYPE\tPODS", "----\t----\t----\t----")
+			for _, user := range info.Users {
+				lines = append(lines, fmt.Sprintf("%s\t%s\t%s\t%s", user.Kind, user.Name, valueOrNone(user.Type), strings.Join(user.Pods, ",")))
+			}
+		}
+		source := info.Source
+		if info.SourceType != "" && source != "" {
+			source = fmt.Sprintf("%s(%s)", info.SourceType, source)
+		}
+		printLine(w, fmt.Sprintf(strings.Trim(describeDataVolumeTemplate, "\n"),
+			info.Name,
+			info.Namespace,
+			info.Status,
+			info.Capacity,
+			info.AccessModes,
+			valueOrNone(info.StorageClass),
+			valueOrNone(info.VolumeName),
+			valueOrNone(source),
+			valueOrNone(info.Description),
+			valueOrNone(info.Owner),
+			info.CreatedByArena,
+			info.CreationTimestamp,
+			strings.Join(lines, "\n"),
+		))
+		_ = w.Flush()
+		return
+	}
+}
+
+func valueOrNone(value string) string {
+	if value == "" {
+		return "N/A"
+	}
+	return value
+}
+
+func printLine(w io.Writer, fields ...string) {
+	buffer := strings.Join(fields, "\t")
+	fmt.Fprintln(w, buffer)
+}
@@ -0,0 +1,110 @@
+package evaluate
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/kubeflow/arena/pkg/apis/types"
+	"gopkg.in/yaml.v2"
+	"os"
+	"text/tabwriter"
+)
+
+// CompareEvaluateJobs gets the evaluate jobs to compare, the jobs are the given names or the
+// evaluate jobs of the model, the jobs without metrics are skipped
+func CompareEvaluateJobs(names []string, modelName, namespace string) ([]*types.EvaluateJobInfo, error) {
+	var jobs []*types.EvaluateJobInfo
+	if len(names) != 0 {
+		for _, name := range names {
+			job, err := GetEvaluateJob(name, namespace)
+			if err != nil {
+				return nil, err
+			}
+			jobs = append(jobs, job)
+		}
+	} else {
+		if modelName == "" {
+			return nil, fmt.Errorf("the evaluate job names or the model name must be specified")
+		}
+		all, err := ListEvaluateJobs(namespace, false)
+		if err != nil {
+			return nil, err
+		}
+		for _, job := range all {
+			if job.ModelName == modelName {
+				jobs = append(jobs, job)
+			}
+		}
+	}
+
+	var result []*types.EvaluateJobInfo
+	for _, job := range jobs {
+		if len(job.Metrics) == 0 {
+			if len(names) != 0 {
+				return nil, fmt.Errorf("the evaluate job %s has not published any metric, status: %s", job.Name, job.Status)
+			}
+			continue
+		}
+		result = append(result, job)
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("not found any evaluate job of model %s which has published metrics", modelName)
+	}
+	return result, nil
+}
+
+// DisplayEvaluateJobComparison prints the metrics of evaluate jobs side by side,
+// the best value of each metric is marked with "*", the higher is the better
+// unless the metric is sorted in ascending order
+func DisplayEvaluateJobComparison(jobs []*types.EvaluateJobInfo, sortBy string, ascending bool, format types.FormatStyle) {
+	if sortBy != "" {
+		SortEvaluateJobsByMetric(jobs, sortBy, ascending)
+	}
+	switch format {
+	case "json":
+		data, _ := json.MarshalIndent(jobs, "", "    ")
+		fmt.Printf("%v", string(data))
+		return
+	case "yaml":
+		data, _ := yaml.Marshal(jobs)
+		fmt.Printf("%v", string(data))
+		return
+	case "", "wide":
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		metrics := metricNames(jobs)
+		header := []string{"NAME", "MODEL_NAME", "MODEL_VERSION"}
+		for _, metric := range metrics {
+			header = append(header, upperMetricName(metric))
+		}
+		printLine(w, header...)
+
+		best := map[string]float64{}
+		for _, metric := range metrics {
+			found := false
+			for _, job := range jobs {
+				value, ok := job.Metrics[metric]
+				if !ok {
+					continue
+				}
+				lower := metric == sortBy && ascending
+				if !found || (lower && value < best[metric]) || (!lower && value > best[metric]) {
+					best[metric] = value
+					found = true
+				}
+			}
+		}
+
+		for _, job := range jobs {
+			items := []string{job.Name, job.ModelName, job.ModelVersion}
+			for _, metric := range metrics {
+				item := formatMetric(job.Metrics, metric)
+				if value, ok := job.Metrics[metric]; ok && len(jobs) > 1 && value == best[metric] {
+					item = item + " *"
+				}
+				items = append(items, item)
+			}
+			printLine(w, items...)
+		}
+		_ = w.Flush()
+		return
+	}
+}
@@ -21,6 +21,7 @@ ModelVersion:       %v
 DatasetPath:        %v
 Status:             %v
 CreationTimestamp:  %v
+%v
 `
 
 func GetEvaluateJob(name, namespace string) (*types.EvaluateJobInfo, error) {
@@ -28,7 +29,12 @@ func GetEvaluateJob(name, namespace string) (*types.EvaluateJobInfo, error) {
 	if err != nil {
 		return nil, err
 	}
-	return buildEvaluateJob(job), nil
+	selector := fmt.Sprintf("app=%v,release=%v", types.EvaluateJob, name)
+	pods, err := k8saccesser.GetK8sResourceAccesser().ListPods(namespace, selector, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	return buildEvaluateJob(job, pods), nil
 }
 
 func DisplayEvaluateJob(job *types.EvaluateJobInfo, format types.FormatStyle) {
@@ -44,6 +50,11 @@ func DisplayEvaluateJob(job *types.EvaluateJobInfo, format types.FormatStyle) {
 	case "", "wide":
 		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
 
+		lines := []string{"\nMetrics:", "NAME\tVALUE", "----\t-----"}
+		for _, name := range metricNames([]*types.EvaluateJobInfo{job}) {
+			lines = append(lines, fmt.Sprintf("%s\t%s", name, formatMetric(job.Metrics, name)))
+		}
+
 		printLine(w, fmt.Sprintf(strings.Trim(getEvaluateJobTemplate, "\n"),
 			job.JobID,
 			job.Name,
@@ -54,6 +65,7 @@ func DisplayEvaluateJob(job *types.EvaluateJobInfo, format types.FormatStyle) {
 			job.DatasetPath,
 			job.Status,
 			job.CreationTimestamp,
+			strings.Join(lines, "\n"),
 		))
 
 		_ = w.Flush()
@@ -6,6 +6,7 @@ import (
 	"github.com/kubeflow/arena/pkg/apis/types"
 	"github.com/kubeflow/arena/pkg/k8saccesser"
 	"gopkg.in/yaml.v2"
+	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"os"
 	"text/tabwriter"
@@ -23,9 +24,19 @@ func ListEvaluateJobs(namespace string, allNamespaces bool) ([]*types.EvaluateJo
 		return nil, err
 	}
 
+	pods, err := k8saccesser.GetK8sResourceAccesser().ListPods(namespace, selector, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	jobPods := map[string][]*v1.Pod{}
+	for _, pod := range pods {
+		key := fmt.Sprintf("%s/%s", pod.Namespace, pod.Labels["release"])
+		jobPods[key] = append(jobPods[key], pod)
+	}
+
 	var evaluateJobs []*types.EvaluateJobInfo
 	for _, job := range jobs {
-		evaluateJob := buildEvaluateJob(job)
+		evaluateJob := buildEvaluateJob(job, jobPods[fmt.Sprintf("%s/%s", job.Namespace, job.Name)])
 		evaluateJobs = append(evaluateJobs, evaluateJob)
 	}
 	return evaluateJobs, nil
@@ -48,6 +59,10 @@ func DisplayAllEvaluateJobs(jobs []*types.EvaluateJobInfo, allNamespaces bool, f
 			header = append(header, "NAMESPACE")
 		}
 		header = append(header, []string{"NAME", "MODEL_NAME", "MODEL_VERSION", "STATUS", "CREATE_TIME"}...)
+		metrics := metricNames(jobs)
+		for _, metric := range metrics {
+			header = append(header, upperMetricName(metric))
+		}
 		printLine(w, header...)
 
 		for _, job := range jobs {
@@ -63,6 +78,9 @@ func DisplayAllEvaluateJobs(jobs []*types.EvaluateJobInfo, allNamespaces bool, f
 				job.Status,
 				job.CreationTimestamp,
 			}...)
+			for _, metric := range metrics {
+				items = append(items, formatMetric(job.Metrics, metric))
+			}
 			printLine(w, items...)
 		}
 		_ = w.Flush()
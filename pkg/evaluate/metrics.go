@@ -0,0 +1,174 @@
+package evaluate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/kubeflow/arena/pkg/apis/config"
+	"github.com/kubeflow/arena/pkg/apis/types"
+	log "github.com/sirupsen/logrus"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// metricsFileEnv is the env of evaluate job container which tells where to write the metrics json,
+	// the file is the termination message path of the container, so it is limited to 4KB
+	metricsFileEnv        = "ARENA_EVALUATE_METRICS_FILE"
+	metricsDataKey        = "metrics.json"
+	evaluateContainerName = "evaluatejob"
+	// metricsAnnotationKey is the annotation of evaluate job which keeps the metrics after the job finishes,
+	// so the metrics are still available when the pods are cleaned up
+	metricsAnnotationKey = "arena.kubeflow.org/evaluate-metrics"
+)
+
+// getStoredEvaluateJobMetrics reads the metrics persisted in the annotation of evaluate job
+func getStoredEvaluateJobMetrics(job *batchv1.Job) map[string]float64 {
+	data, ok := job.Annotations[metricsAnnotationKey]
+	if !ok {
+		return nil
+	}
+	metrics, err := parseEvaluateMetrics(data)
+	if err != nil {
+		log.Debugf("failed to parse the metrics annotation of job %s: %v", job.Name, err)
+		return nil
+	}
+	return metrics
+}
+
+// getOrStoreEvaluateJobMetrics reads the metrics from the annotation of evaluate job first,
+// otherwise reads them from the pods and persists them in the annotation once the job finishes
+func getOrStoreEvaluateJobMetrics(job *batchv1.Job, pods []*v1.Pod) map[string]float64 {
+	if metrics := getStoredEvaluateJobMetrics(job); metrics != nil {
+		return metrics
+	}
+	metrics := getEvaluateJobMetrics(pods)
+	if metrics == nil || !(isComplete(job.Status) || isFailed(job.Status)) {
+		return metrics
+	}
+	if err := storeEvaluateJobMetrics(job, metrics); err != nil {
+		log.Debugf("failed to persist the metrics of job %s: %v", job.Name, err)
+	}
+	return metrics
+}
+
+// storeEvaluateJobMetrics persists the metrics in the annotation of evaluate job
+func storeEvaluateJobMetrics(job *batchv1.Job, metrics map[string]float64) error {
+	data, err := json.Marshal(metrics)
+	if err != nil {
+		return err
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{metricsAnnotationKey: string(data)},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	client := config.GetArenaConfiger().GetClientSet()
+	_, err = client.BatchV1().Jobs(job.Namespace).Patch(context.TODO(), job.Name, k8stypes.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
+// appendCollectMetricsCommand copies the metrics.json in the metrics path to the metrics file after the job command succeeds
+func appendCollectMetricsCommand(command, metricsPath string) string {
+	metricsFile := path.Join(metricsPath, metricsDataKey)
+	return fmt.Sprintf("%s && (cp %s $%s || true)", command, metricsFile, metricsFileEnv)
+}
+
+// getEvaluateJobMetrics reads the metrics of evaluate job from the termination message of the completed pods
+func getEvaluateJobMetrics(pods []*v1.Pod) map[string]float64 {
+	sorted := make([]*v1.Pod, len(pods))
+	copy(sorted, pods)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].CreationTimestamp.After(sorted[j].CreationTimestamp.Time)
+	})
+	for _, pod := range sorted {
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.Name != evaluateContainerName || status.State.Terminated == nil {
+				continue
+			}
+			metrics, err := parseEvaluateMetrics(status.State.Terminated.Message)
+			if err != nil {
+				log.Debugf("failed to parse metrics of pod %s: %v", pod.Name, err)
+				continue
+			}
+			return metrics
+		}
+	}
+	return nil
+}
+
+// parseEvaluateMetrics parses the metrics json like {"accuracy": 0.93, "recall": 0.89},
+// the values which are not numbers are ignored
+func parseEvaluateMetrics(data string) (map[string]float64, error) {
+	data = strings.TrimSpace(data)
+	if data == "" {
+		return nil, fmt.Errorf("the metrics is empty")
+	}
+	values := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(data), &values); err != nil {
+		return nil, err
+	}
+	metrics := map[string]float64{}
+	for name, value := range values {
+		if v, ok := value.(float64); ok {
+			metrics[name] = v
+		}
+	}
+	if len(metrics) == 0 {
+		return nil, fmt.Errorf("not found any metric")
+	}
+	return metrics, nil
+}
+
+// metricNames returns the sorted names of metrics reported by the jobs
+func metricNames(jobs []*types.EvaluateJobInfo) []string {
+	seen := map[string]bool{}
+	names := []string{}
+	for _, job := range jobs {
+		for name := range job.Metrics {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SortEvaluateJobsByMetric sorts the jobs by the metric in descending order or ascending order,
+// the jobs without the metric are placed at the end
+func SortEvaluateJobsByMetric(jobs []*types.EvaluateJobInfo, metric string, ascending bool) {
+	sort.SliceStable(jobs, func(i, j int) bool {
+		vi, oki := jobs[i].Metrics[metric]
+		vj, okj := jobs[j].Metrics[metric]
+		if oki != okj {
+			return oki
+		}
+		if ascending {
+			return vi < vj
+		}
+		return vi > vj
+	})
+}
+
+func upperMetricName(name string) string {
+	return strings.ToUpper(name)
+}
+
+func formatMetric(metrics map[string]float64, name string) string {
+	value, ok := metrics[name]
+	if !ok {
+		return "N/A"
+	}
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
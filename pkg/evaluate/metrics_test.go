@@ -0,0 +1,82 @@
+package evaluate
+
+import (
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newEvaluatePod(message string) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "evaluate-abcde"},
+		Status: v1.PodStatus{
+			ContainerStatuses: []v1.ContainerStatus{{
+				Name: evaluateContainerName,
+				State: v1.ContainerState{
+					Terminated: &v1.ContainerStateTerminated{Message: message},
+				},
+			}},
+		},
+	}
+}
+
+func TestGetOrStoreEvaluateJobMetrics(t *testing.T) {
+	complete := batchv1.JobStatus{
+		Conditions: []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: v1.ConditionTrue}},
+	}
+	tc := []struct {
+		Name        string
+		Annotations map[string]string
+		Status      batchv1.JobStatus
+		Pods        []*v1.Pod
+		Expected    map[string]float64
+	}{
+		{
+			Name:        "the persisted metrics are read first",
+			Annotations: map[string]string{metricsAnnotationKey: `{"accuracy": 0.93}`},
+			Status:      complete,
+			Pods:        []*v1.Pod{newEvaluatePod(`{"accuracy": 0.5}`)},
+			Expected:    map[string]float64{"accuracy": 0.93},
+		},
+		{
+			Name:        "the persisted metrics are kept after the pods are cleaned up",
+			Annotations: map[string]string{metricsAnnotationKey: `{"accuracy": 0.93, "recall": 0.89}`},
+			Status:      complete,
+			Expected:    map[string]float64{"accuracy": 0.93, "recall": 0.89},
+		},
+		{
+			Name:     "the metrics of running job are read from the pods",
+			Pods:     []*v1.Pod{newEvaluatePod(`{"accuracy": 0.5}`)},
+			Expected: map[string]float64{"accuracy": 0.5},
+		},
+		{
+			Name:        "the invalid annotation falls back to the pods",
+			Annotations: map[string]string{metricsAnnotationKey: "invalid"},
+			Pods:        []*v1.Pod{newEvaluatePod(`{"accuracy": 0.5}`)},
+			Expected:    map[string]float64{"accuracy": 0.5},
+		},
+		{
+			Name:   "the finished job without metrics",
+			Status: complete,
+		},
+	}
+	for _, c := range tc {
+		job := &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: "evaluate", Annotations: c.Annotations},
+			Status:     c.Status,
+		}
+		actual := getOrStoreEvaluateJobMetrics(job, c.Pods)
+		if len(actual) != len(c.Expected) {
+			t.Errorf("%s: Expected %v; Got %v", c.Name, c.Expected, actual)
+			continue
+		}
+		for name, value := range c.Expected {
+			if actual[name] != value {
+				t.Errorf("%s: Expected %v; Got %v", c.Name, c.Expected, actual)
+				break
+			}
+		}
+	}
+}
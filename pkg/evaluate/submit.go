@@ -9,6 +9,9 @@ import (
 
 func SubmitEvaluateJob(namespace string, submitArgs *types.EvaluateJobArgs) (err error) {
 	evaluateJobChart := util.GetChartsFolder() + "/evaluatejob"
+	if submitArgs.Command != "" && submitArgs.MetricsPath != "" {
+		submitArgs.Command = appendCollectMetricsCommand(submitArgs.Command, submitArgs.MetricsPath)
+	}
 
 	err = workflow.SubmitJobByHelm(submitArgs.Name, string(types.EvaluateJob), namespace, submitArgs, evaluateJobChart, submitArgs.HelmOptions...)
 	if err != nil {
@@ -27,7 +27,7 @@ func formatTime(t time.Time) string {
 	return t.Format(formatLayout)
 }
 
-func buildEvaluateJob(job *batchv1.Job) *types.EvaluateJobInfo {
+func buildEvaluateJob(job *batchv1.Job, pods []*v1.Pod) *types.EvaluateJobInfo {
 	modelName := ""
 	modelVersion := ""
 	modelPath := ""
@@ -73,6 +73,7 @@ func buildEvaluateJob(job *batchv1.Job) *types.EvaluateJobInfo {
 		MetricsPath:       metricsPath,
 		Status:            jobStatus,
 		CreationTimestamp: formatTime(job.CreationTimestamp.Time),
+		Metrics:           getOrStoreEvaluateJobMetrics(job, pods),
 	}
 }
 
@@ -0,0 +1,180 @@
+package exporter
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/kubeflow/arena/pkg/apis/types"
+	"github.com/kubeflow/arena/pkg/serving"
+	"github.com/kubeflow/arena/pkg/topnode"
+	"github.com/kubeflow/arena/pkg/training"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	log "github.com/sirupsen/logrus"
+)
+
+const metricsNamespace = "arena"
+
+var (
+	trainingJobsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "", "training_jobs"),
+		"The count of training jobs.",
+		[]string{"status", "type", "namespace"}, nil,
+	)
+	jobRequestedGPUsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "job", "requested_gpus"),
+		"The gpus requested by the job which is not finished.",
+		[]string{"name", "namespace", "kind", "type"}, nil,
+	)
+	jobPendingSecondsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "job", "pending_seconds"),
+		"The seconds which the pending training job has been waiting for.",
+		[]string{"name", "namespace", "type"}, nil,
+	)
+	servingInstancesAvailableDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "serving", "instances_available"),
+		"The available instances of the serving job.",
+		[]string{"name", "namespace", "type", "version"}, nil,
+	)
+	servingInstancesDesiredDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "serving", "instances_desired"),
+		"The desired instances of the serving job.",
+		[]string{"name", "namespace", "type", "version"}, nil,
+	)
+	nodeGPUsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "node", "gpus"),
+		"The total gpus of the node.",
+		[]string{"node", "gpu_mode"}, nil,
+	)
+	nodeFreeGPUsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "node", "free_gpus"),
+		"The gpus of the node which are neither allocated nor unhealthy.",
+		[]string{"node", "gpu_mode"}, nil,
+	)
+	collectSuccessDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "exporter", "collect_success"),
+		"Whether collecting the target succeeded, 1 means success.",
+		[]string{"target"}, nil,
+	)
+)
+
+// collector collects the state of jobs and nodes when prometheus scrapes, the resources are listed
+// from the informer cache, so the scrape does not hit the api server
+type collector struct{}
+
+// Describe implements prometheus.Collector
+func (c *collector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range []*prometheus.Desc{
+		trainingJobsDesc,
+		jobRequestedGPUsDesc,
+		jobPendingSecondsDesc,
+		servingInstancesAvailableDesc,
+		servingInstancesDesiredDesc,
+		nodeGPUsDesc,
+		nodeFreeGPUsDesc,
+		collectSuccessDesc,
+	} {
+		ch <- desc
+	}
+}
+
+// Collect implements prometheus.Collector
+func (c *collector) Collect(ch chan<- prometheus.Metric) {
+	for _, target := range []struct {
+		name    string
+		collect func(ch chan<- prometheus.Metric) error
+	}{
+		{"training", collectTrainingJobs},
+		{"serving", collectServingJobs},
+		{"nodes", collectNodes},
+	} {
+		success := float64(1)
+		if err := target.collect(ch); err != nil {
+			log.Errorf("failed to collect the metrics of %v: %v", target.name, err)
+			success = 0
+		}
+		ch <- prometheus.MustNewConstMetric(collectSuccessDesc, prometheus.GaugeValue, success, target.name)
+	}
+}
+
+func collectTrainingJobs(ch chan<- prometheus.Metric) error {
+	jobs, err := training.ListTrainingJobs("", true, types.AllTrainingJob)
+	if err != nil {
+		return err
+	}
+	counts := map[[3]string]float64{}
+	for _, job := range jobs {
+		status := job.GetStatus()
+		counts[[3]string{status, string(job.Trainer()), job.Namespace()}]++
+		if status == string(types.TrainingJobSucceeded) || status == string(types.TrainingJobFailed) {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(jobRequestedGPUsDesc, prometheus.GaugeValue,
+			float64(job.RequestedGPU()), job.Name(), job.Namespace(), "training", string(job.Trainer()))
+		if status == string(types.TrainingJobPending) {
+			ch <- prometheus.MustNewConstMetric(jobPendingSecondsDesc, prometheus.GaugeValue,
+				job.Age().Seconds(), job.Name(), job.Namespace(), string(job.Trainer()))
+		}
+	}
+	for labels, count := range counts {
+		ch <- prometheus.MustNewConstMetric(trainingJobsDesc, prometheus.GaugeValue, count, labels[:]...)
+	}
+	return nil
+}
+
+func collectServingJobs(ch chan<- prometheus.Metric) error {
+	jobs, err := serving.ListServingJobs("", true, types.AllServingJob)
+	if err != nil {
+		return err
+	}
+	// the versions of a serving job share the name, so the requested gpus of them are added up
+	requestedGPUs := map[[4]string]float64{}
+	for _, job := range jobs {
+		labels := []string{job.Name(), job.Namespace(), string(job.Type()), job.Version()}
+		ch <- prometheus.MustNewConstMetric(servingInstancesAvailableDesc, prometheus.GaugeValue, float64(job.AvailableInstances()), labels...)
+		ch <- prometheus.MustNewConstMetric(servingInstancesDesiredDesc, prometheus.GaugeValue, float64(job.DesiredInstances()), labels...)
+		requestedGPUs[[4]string{job.Name(), job.Namespace(), "serving", string(job.Type())}] += job.RequestGPUs()
+	}
+	for labels, gpus := range requestedGPUs {
+		ch <- prometheus.MustNewConstMetric(jobRequestedGPUsDesc, prometheus.GaugeValue, gpus, labels[:]...)
+	}
+	return nil
+}
+
+func collectNodes(ch chan<- prometheus.Metric) error {
+	nodes, err := topnode.BuildNodes(nil, types.AllKnownNode, false)
+	if err != nil {
+		return err
+	}
+	for _, node := range nodes {
+		gpuMode := topnode.NodeTypeAlias(node.Type())
+		ch <- prometheus.MustNewConstMetric(nodeGPUsDesc, prometheus.GaugeValue, topnode.NodeTotalGPUs(node), node.Name(), gpuMode)
+		ch <- prometheus.MustNewConstMetric(nodeFreeGPUsDesc, prometheus.GaugeValue, topnode.NodeFreeGPUs(node), node.Name(), gpuMode)
+	}
+	return nil
+}
+
+// RunExporter serves the metrics of arena jobs and nodes on the address, it never returns unless the
+// http server fails
+func RunExporter(address, metricsPath string) error {
+	registry := prometheus.NewRegistry()
+	if err := registry.Register(&collector{}); err != nil {
+		return err
+	}
+	if !strings.HasPrefix(metricsPath, "/") {
+		metricsPath = "/" + metricsPath
+	}
+	mux := http.NewServeMux()
+	mux.Handle(metricsPath, promhttp.HandlerFor(registry, promhttp.HandlerOpts{
+		ErrorHandling: promhttp.ContinueOnError,
+	}))
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	log.Infof("the arena exporter serves metrics on %v%v", address, metricsPath)
+	if err := http.ListenAndServe(address, mux); err != nil {
+		return fmt.Errorf("failed to serve metrics on %v: %v", address, err)
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/kubeflow/arena/pkg/apis/types"
+	"gopkg.in/yaml.v2"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// modelMetric is a metric which is extracted from the results of model job
+type modelMetric struct {
+	name  string
+	value func(results *types.ModelJobResults) (float64, bool)
+}
+
+var benchmarkMetrics = []modelMetric{
+	{"throughput(req/s)", benchmarkValue(func(b *types.ModelBenchmarkResults) float64 { return b.Throughput })},
+	{"requests", benchmarkValue(func(b *types.ModelBenchmarkResults) float64 { return float64(b.Requests) })},
+	{"failed requests", benchmarkValue(func(b *types.ModelBenchmarkResults) float64 { return float64(b.FailedRequests) })},
+	{"latency avg(ms)", benchmarkValue(func(b *types.ModelBenchmarkResults) float64 { return b.Latency.Avg })},
+	{"latency p50(ms)", benchmarkValue(func(b *types.ModelBenchmarkResults) float64 { return b.Latency.P50 })},
+	{"latency p90(ms)", benchmarkValue(func(b *types.ModelBenchmarkResults) float64 { return b.Latency.P90 })},
+	{"latency p95(ms)", benchmarkValue(func(b *types.ModelBenchmarkResults) float64 { return b.Latency.P95 })},
+	{"latency p99(ms)", benchmarkValue(func(b *types.ModelBenchmarkResults) float64 { return b.Latency.P99 })},
+	{"latency max(ms)", benchmarkValue(func(b *types.ModelBenchmarkResults) float64 { return b.Latency.Max })},
+	{"gpu utilization(%)", benchmarkValue(func(b *types.ModelBenchmarkResults) float64 { return b.GPUUtilization })},
+	{"gpu memory used(MiB)", benchmarkValue(func(b *types.ModelBenchmarkResults) float64 { return b.GPUMemoryUsed })},
+}
+
+func benchmarkValue(fn func(b *types.ModelBenchmarkResults) float64) func(results *types.ModelJobResults) (float64, bool) {
+	return func(results *types.ModelJobResults) (float64, bool) {
+		if results == nil || results.Benchmark == nil {
+			return 0, false
+		}
+		return fn(results.Benchmark), true
+	}
+}
+
+func profileMetrics(jobInfos []types.ModelJobInfo) []modelMetric {
+	metrics := []modelMetric{}
+	seen := map[string]bool{}
+	for _, jobInfo := range jobInfos {
+		if jobInfo.Results == nil || jobInfo.Results.Profile == nil {
+			continue
+		}
+		if len(metrics) == 0 {
+			metrics = append(metrics, modelMetric{"profile total time(ms)", func(results *types.ModelJobResults) (float64, bool) {
+				if results == nil || results.Profile == nil {
+					return 0, false
+				}
+				return results.Profile.TotalTime, true
+			}})
+		}
+		for _, layer := range jobInfo.Results.Profile.Layers {
+			if seen[layer.Name] {
+				continue
+			}
+			seen[layer.Name] = true
+			name := layer.Name
+			metrics = append(metrics, modelMetric{fmt.Sprintf("layer %s(ms)", name), func(results *types.ModelJobResults) (float64, bool) {
+				if results == nil || results.Profile == nil {
+					return 0, false
+				}
+				for _, l := range results.Profile.Layers {
+					if l.Name == name {
+						return l.TotalTime, true
+					}
+				}
+				return 0, false
+			}})
+		}
+	}
+	return metrics
+}
+
+// CompareModelJobs collects the results of model jobs and puts their metrics side by side
+func CompareModelJobs(namespace string, names []string) (*types.ModelJobComparison, error) {
+	if len(names) != 2 {
+		return nil, fmt.Errorf("only support to compare 2 model jobs, but got %d", len(names))
+	}
+	jobInfos := []types.ModelJobInfo{}
+	for _, name := range names {
+		job, err := SearchModelJob(namespace, name, types.AllModelJob)
+		if err != nil {
+			return nil, err
+		}
+		jobInfo := job.Convert2JobInfo()
+		if jobInfo.Results == nil {
+			return nil, fmt.Errorf("not found the results of model job %s, please check the job is completed", name)
+		}
+		jobInfos = append(jobInfos, jobInfo)
+	}
+
+	comparison := &types.ModelJobComparison{Jobs: names}
+	metrics := append([]modelMetric{}, benchmarkMetrics...)
+	metrics = append(metrics, profileMetrics(jobInfos)...)
+	for _, metric := range metrics {
+		row := types.ModelMetricComparison{Name: metric.name, Diff: "N/A"}
+		values := []float64{}
+		found := 0
+		for _, jobInfo := range jobInfos {
+			value, ok := metric.value(jobInfo.Results)
+			values = append(values, value)
+			if !ok {
+				row.Values = append(row.Values, "N/A")
+				continue
+			}
+			found++
+			row.Values = append(row.Values, strconv.FormatFloat(value, 'f', -1, 64))
+		}
+		// skip the metric which is not reported by any job
+		if found == 0 || allZero(values) {
+			continue
+		}
+		if found == len(jobInfos) && values[0] != 0 {
+			row.Diff = fmt.Sprintf("%+.2f%%", (values[1]-values[0])/values[0]*100)
+		}
+		comparison.Metrics = append(comparison.Metrics, row)
+	}
+	return comparison, nil
+}
+
+func DisplayModelJobComparison(comparison *types.ModelJobComparison, format types.FormatStyle) {
+	switch format {
+	case types.JsonFormat:
+		data, _ := json.MarshalIndent(comparison, "", "    ")
+		fmt.Printf("%v", string(data))
+		return
+	case types.YamlFormat:
+		data, _ := yaml.Marshal(comparison)
+		fmt.Printf("%v", string(data))
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "METRIC\t%s\t%s\tDIFF\n", comparison.Jobs[0], comparison.Jobs[1])
+	fmt.Fprintf(w, "------\t%s\t%s\t----\n", strings.Repeat("-", len(comparison.Jobs[0])), strings.Repeat("-", len(comparison.Jobs[1])))
+	for _, metric := range comparison.Metrics {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", metric.Name, metric.Values[0], metric.Values[1], metric.Diff)
+	}
+	_ = w.Flush()
+}
+
+func allZero(values []float64) bool {
+	for _, v := range values {
+		if v != 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -1,11 +1,15 @@
 package model
 
 import (
+	"context"
 	"strings"
 
+	"github.com/kubeflow/arena/pkg/apis/config"
 	"github.com/kubeflow/arena/pkg/apis/types"
 	"github.com/kubeflow/arena/pkg/workflow"
 	log "github.com/sirupsen/logrus"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func DeleteModelJob(namespace, name string, jobType types.ModelJobType) error {
@@ -21,6 +25,12 @@ func DeleteModelJob(namespace, name string, jobType types.ModelJobType) error {
 	if err != nil {
 		return err
 	}
+	// the results configmap is created by the job, so it is not deleted with the helm release
+	client := config.GetArenaConfiger().GetClientSet()
+	err = client.CoreV1().ConfigMaps(namespace).Delete(context.TODO(), modelResultsConfigMapName(name), metav1.DeleteOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		log.Warnf("failed to delete the results configmap of model job %s: %v", name, err)
+	}
 	log.Infof("The model job %s has been deleted successfully", job.Name())
 	return nil
 }
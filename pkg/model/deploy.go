@@ -0,0 +1,378 @@
+package model
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/kubeflow/arena/pkg/apis/config"
+	"github.com/kubeflow/arena/pkg/apis/types"
+	"github.com/kubeflow/arena/pkg/serving"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// the status of model deploy pipeline is stored in the labeled configmap <name>-deploy
+const (
+	modelDeployLabelKey        = "arena.kubeflow.org/model-deploy"
+	modelDeployConfigMapSuffix = "-deploy"
+	modelDeployDataKey         = "deploy.json"
+	modelDeployPollInterval    = 10 * time.Second
+)
+
+// DeployModel runs the model deploy pipeline in the foreground, it submits the optimize job and waits
+// for it, then benchmarks the optimized model and creates the serving job only if the benchmark
+// throughput is not less than the min throughput
+func DeployModel(args *types.ModelDeployArgs) (*types.ModelDeployInfo, error) {
+	if err := validateModelDeployArgs(args); err != nil {
+		return nil, err
+	}
+	info := newModelDeployInfo(args)
+	if err := createModelDeploy(info); err != nil {
+		return nil, err
+	}
+
+	// optimize stage
+	optimizeStage := &info.Stages[0]
+	startModelDeployStage(info, optimizeStage)
+	if err := saveModelDeploy(info); err != nil {
+		return info, err
+	}
+	if err := SubmitModelOptimizeJob(args.Namespace, args.OptimizeArgs); err != nil {
+		return info, failModelDeploy(info, optimizeStage, fmt.Errorf("failed to submit the optimize job: %v", err))
+	}
+	if _, err := waitModelJob(args.Namespace, optimizeStage.JobName, types.ModelOptimizeJob, args.Timeout); err != nil {
+		return info, failModelDeploy(info, optimizeStage, err)
+	}
+	finishModelDeployStage(optimizeStage, types.ModelDeploySucceeded, fmt.Sprintf("the optimized model is exported to %s", args.OptimizeArgs.ExportPath))
+	if err := saveModelDeploy(info); err != nil {
+		return info, err
+	}
+
+	// benchmark stage
+	benchmarkStage := &info.Stages[1]
+	if args.Benchmark {
+		startModelDeployStage(info, benchmarkStage)
+		if err := saveModelDeploy(info); err != nil {
+			return info, err
+		}
+		if args.BenchmarkArgs.Command == "" {
+			args.BenchmarkArgs.Command = buildModelDeployBenchmarkCommand(args.OptimizeArgs, args.BenchmarkArgs, args.OptimizedModelPath)
+		}
+		if err := SubmitModelBenchmarkJob(args.Namespace, args.BenchmarkArgs); err != nil {
+			return info, failModelDeploy(info, benchmarkStage, fmt.Errorf("failed to submit the benchmark job: %v", err))
+		}
+		job, err := waitModelJob(args.Namespace, benchmarkStage.JobName, types.ModelBenchmarkJob, args.Timeout)
+		if err != nil {
+			return info, failModelDeploy(info, benchmarkStage, err)
+		}
+		results := job.Results()
+		if results == nil || results.Benchmark == nil {
+			return info, failModelDeploy(info, benchmarkStage, fmt.Errorf("the benchmark job %s has not reported the results", benchmarkStage.JobName))
+		}
+		benchmarkStage.Throughput = results.Benchmark.Throughput
+		if args.MinThroughput > 0 && results.Benchmark.Throughput < args.MinThroughput {
+			err := fmt.Errorf("the throughput %.2f req/s is less than the min throughput %.2f req/s", results.Benchmark.Throughput, args.MinThroughput)
+			return info, failModelDeploy(info, benchmarkStage, err)
+		}
+		finishModelDeployStage(benchmarkStage, types.ModelDeploySucceeded, fmt.Sprintf("the throughput is %.2f req/s", results.Benchmark.Throughput))
+		if err := saveModelDeploy(info); err != nil {
+			return info, err
+		}
+	}
+
+	// serve stage
+	serveStage := &info.Stages[2]
+	if args.ServeType != "" {
+		startModelDeployStage(info, serveStage)
+		if err := saveModelDeploy(info); err != nil {
+			return info, err
+		}
+		if err := serving.SubmitTritonServingJob(args.Namespace, args.TritonArgs); err != nil {
+			return info, failModelDeploy(info, serveStage, fmt.Errorf("failed to submit the serving job: %v", err))
+		}
+		finishModelDeployStage(serveStage, types.ModelDeploySucceeded, fmt.Sprintf("the model repository is %s", args.TritonArgs.ModelRepository))
+	}
+
+	info.Status = types.ModelDeploySucceeded
+	if err := saveModelDeploy(info); err != nil {
+		return info, err
+	}
+	return info, nil
+}
+
+// GetModelDeploy gets the status of model deploy pipeline, the status of running
+// stages is refreshed by the status of their jobs
+func GetModelDeploy(namespace, name string) (*types.ModelDeployInfo, error) {
+	client := config.GetArenaConfiger().GetClientSet()
+	configmap, err := client.CoreV1().ConfigMaps(namespace).Get(context.TODO(), name+modelDeployConfigMapSuffix, metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, fmt.Errorf("not found model deploy %s in namespace %s", name, namespace)
+		}
+		return nil, err
+	}
+	info := &types.ModelDeployInfo{}
+	if err := json.Unmarshal([]byte(configmap.Data[modelDeployDataKey]), info); err != nil {
+		return nil, fmt.Errorf("failed to parse model deploy %s: %v", name, err)
+	}
+	for i := range info.Stages {
+		stage := &info.Stages[i]
+		if stage.Status != types.ModelDeployRunning || stage.Name == types.ModelDeployServeStage {
+			continue
+		}
+		job, err := SearchModelJob(namespace, stage.JobName, types.ModelJobType(stage.JobType))
+		if err != nil {
+			log.Debugf("failed to get the job %s of stage %s: %v", stage.JobName, stage.Name, err)
+			continue
+		}
+		// the pipeline is interrupted if the job is finished but the stage is still running
+		if job.Status() == string(types.ModelJobComplete) || job.Status() == string(types.ModelJobFailed) {
+			stage.Message = fmt.Sprintf("the job is %s, but the pipeline is interrupted", job.Status())
+		}
+	}
+	return info, nil
+}
+
+func validateModelDeployArgs(args *types.ModelDeployArgs) error {
+	if args.Name == "" {
+		return fmt.Errorf("--name must be specified")
+	}
+	if args.OptimizeArgs == nil {
+		return fmt.Errorf("the optimize args must be specified")
+	}
+	if args.Benchmark && args.BenchmarkArgs == nil {
+		return fmt.Errorf("the benchmark args must be specified if --benchmark is enabled")
+	}
+	if !args.Benchmark && args.MinThroughput > 0 {
+		return fmt.Errorf("--min-throughput requires --benchmark")
+	}
+	if args.ServeType != "" && args.ServeType != types.TritonServingJob {
+		return fmt.Errorf("the serve type %s is not supported, only support:[triton]", args.ServeType)
+	}
+	if args.ServeType != "" && args.TritonArgs == nil {
+		return fmt.Errorf("the triton serving args must be specified")
+	}
+	return nil
+}
+
+func newModelDeployInfo(args *types.ModelDeployArgs) *types.ModelDeployInfo {
+	now := time.Now().Format(time.RFC3339)
+	info := &types.ModelDeployInfo{
+		Name:              args.Name,
+		Namespace:         args.Namespace,
+		Status:            types.ModelDeployPending,
+		MinThroughput:     args.MinThroughput,
+		CreationTimestamp: now,
+		UpdateTimestamp:   now,
+		Stages: []types.ModelDeployStageInfo{
+			{
+				Name:    types.ModelDeployOptimizeStage,
+				JobName: args.OptimizeArgs.Name,
+				JobType: string(types.ModelOptimizeJob),
+				Status:  types.ModelDeployPending,
+			},
+			{
+				Name:    types.ModelDeployBenchmarkStage,
+				JobType: string(types.ModelBenchmarkJob),
+				Status:  types.ModelDeploySkipped,
+			},
+			{
+				Name:    types.ModelDeployServeStage,
+				JobType: string(args.ServeType),
+				Status:  types.ModelDeploySkipped,
+			},
+		},
+	}
+	if args.Benchmark {
+		info.Stages[1].JobName = args.BenchmarkArgs.Name
+		info.Stages[1].Status = types.ModelDeployPending
+	}
+	if args.ServeType != "" {
+		info.Stages[2].JobName = args.TritonArgs.Name
+		info.Stages[2].Status = types.ModelDeployPending
+	}
+	return info
+}
+
+// waitModelJob waits until the model job is complete, an error is returned if the job is failed or timeout
+func waitModelJob(namespace, name string, jobType types.ModelJobType, timeout int) (ModelJob, error) {
+	var job ModelJob
+	log.Infof("waiting for the %s job %s to complete", jobType, name)
+	err := wait.PollImmediate(modelDeployPollInterval, time.Duration(timeout)*time.Second, func() (bool, error) {
+		var err error
+		job, err = SearchModelJob(namespace, name, jobType)
+		if err != nil {
+			log.Debugf("failed to get the %s job %s: %v", jobType, name, err)
+			return false, nil
+		}
+		switch job.Status() {
+		case string(types.ModelJobComplete):
+			return true, nil
+		case string(types.ModelJobFailed):
+			return false, fmt.Errorf("the %s job %s is failed, please run `arena model get %s` to check it", jobType, name, name)
+		}
+		return false, nil
+	})
+	if err == wait.ErrWaitTimeout {
+		return nil, fmt.Errorf("timeout to wait for the %s job %s after %ds", jobType, name, timeout)
+	}
+	return job, err
+}
+
+func startModelDeployStage(info *types.ModelDeployInfo, stage *types.ModelDeployStageInfo) {
+	info.Status = types.ModelDeployRunning
+	stage.Status = types.ModelDeployRunning
+	stage.StartTime = time.Now().Format(time.RFC3339)
+	log.Infof("start the %s stage of model deploy %s", stage.Name, info.Name)
+}
+
+func finishModelDeployStage(stage *types.ModelDeployStageInfo, status types.ModelDeployStatus, message string) {
+	stage.Status = status
+	stage.Message = message
+	stage.EndTime = time.Now().Format(time.RFC3339)
+}
+
+// failModelDeploy marks the stage and the pipeline failed, the pending stages are skipped
+func failModelDeploy(info *types.ModelDeployInfo, stage *types.ModelDeployStageInfo, reason error) error {
+	finishModelDeployStage(stage, types.ModelDeployFailed, reason.Error())
+	for i := range info.Stages {
+		if info.Stages[i].Status == types.ModelDeployPending {
+			info.Stages[i].Status = types.ModelDeploySkipped
+			info.Stages[i].Message = fmt.Sprintf("the %s stage is not passed", stage.Name)
+		}
+	}
+	info.Status = types.ModelDeployFailed
+	info.Message = fmt.Sprintf("the %s stage is failed", stage.Name)
+	if err := saveModelDeploy(info); err != nil {
+		log.Warnf("failed to save the status of model deploy %s: %v", info.Name, err)
+	}
+	return reason
+}
+
+func createModelDeploy(info *types.ModelDeployInfo) error {
+	data, err := json.Marshal(info)
+	if err != nil {
+		return err
+	}
+	configmap := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      info.Name + modelDeployConfigMapSuffix,
+			Namespace: info.Namespace,
+			Labels: map[string]string{
+				"createdBy":         "arena",
+				modelDeployLabelKey: "true",
+			},
+		},
+		Data: map[string]string{
+			modelDeployDataKey: string(data),
+		},
+	}
+	client := config.GetArenaConfiger().GetClientSet()
+	_, err = client.CoreV1().ConfigMaps(info.Namespace).Create(context.TODO(), configmap, metav1.CreateOptions{})
+	if k8serrors.IsAlreadyExists(err) {
+		return fmt.Errorf("the model deploy %s already exists, please delete the configmap %s%s and its jobs first", info.Name, info.Name, modelDeployConfigMapSuffix)
+	}
+	return err
+}
+
+func saveModelDeploy(info *types.ModelDeployInfo) error {
+	client := config.GetArenaConfiger().GetClientSet()
+	configmap, err := client.CoreV1().ConfigMaps(info.Namespace).Get(context.TODO(), info.Name+modelDeployConfigMapSuffix, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	info.UpdateTimestamp = time.Now().Format(time.RFC3339)
+	data, err := json.Marshal(info)
+	if err != nil {
+		return err
+	}
+	configmap.Data[modelDeployDataKey] = string(data)
+	_, err = client.CoreV1().ConfigMaps(info.Namespace).Update(context.TODO(), configmap, metav1.UpdateOptions{})
+	return err
+}
+
+// buildModelDeployBenchmarkCommand returns the command of benchmark stage, the benchmark loads the
+// model config file of optimize stage and the optimized model is passed by --model-path
+func buildModelDeployBenchmarkCommand(optimizeArgs *types.ModelOptimizeArgs, benchmarkArgs *types.ModelBenchmarkArgs, optimizedModelPath string) string {
+	benchmarkArgs.ModelConfigFile = optimizeArgs.ModelConfigFile
+	command := fmt.Sprintf("%s --model-path=%s", BuildModelBenchmarkCommand(benchmarkArgs), optimizedModelPath)
+	return appendCollectBenchmarkResultsCommand(command, benchmarkArgs.ReportPath)
+}
+
+var getModelDeployTemplate = `
+Name:               %v
+Namespace:          %v
+Status:             %v
+Message:            %v
+MinThroughput:      %v
+CreationTimestamp:  %v
+UpdateTimestamp:    %v
+
+Stages:
+  STAGE	JOB	STATUS	THROUGHPUT	START_TIME	END_TIME	MESSAGE
+  -----	---	------	----------	----------	--------	-------
+%v
+`
+
+// DisplayModelDeploy prints the status of all stages of model deploy pipeline
+func DisplayModelDeploy(info *types.ModelDeployInfo, format types.FormatStyle) {
+	switch format {
+	case types.JsonFormat:
+		data, _ := json.MarshalIndent(info, "", "    ")
+		fmt.Printf("%v", string(data))
+		return
+	case types.YamlFormat:
+		data, _ := yaml.Marshal(info)
+		fmt.Printf("%v", string(data))
+		return
+	}
+	lines := []string{}
+	for _, stage := range info.Stages {
+		throughput := "N/A"
+		if stage.Throughput > 0 {
+			throughput = fmt.Sprintf("%.2f", stage.Throughput)
+		}
+		lines = append(lines, fmt.Sprintf("  %v\t%v\t%v\t%v\t%v\t%v\t%v",
+			stage.Name,
+			valueOrNone(stage.JobName),
+			stage.Status,
+			throughput,
+			valueOrNone(stage.StartTime),
+			valueOrNone(stage.EndTime),
+			valueOrNone(stage.Message),
+		))
+	}
+	minThroughput := "N/A"
+	if info.MinThroughput > 0 {
+		minThroughput = fmt.Sprintf("%.2f", info.MinThroughput)
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, strings.Trim(getModelDeployTemplate, "\n"),
+		info.Name,
+		info.Namespace,
+		info.Status,
+		valueOrNone(info.Message),
+		minThroughput,
+		info.CreationTimestamp,
+		info.UpdateTimestamp,
+		strings.Join(lines, "\n"),
+	)
+	fmt.Fprintln(w)
+	_ = w.Flush()
+}
+
+func valueOrNone(value string) string {
+	if value == "" {
+		return "N/A"
+	}
+	return value
+}
@@ -48,6 +48,7 @@ func PrintModelJob(job ModelJob, format types.FormatStyle) {
 	for k, v := range jobInfo.Params {
 		lines = append(lines, fmt.Sprintf("\t%s\t%s", k, v))
 	}
+	lines = append(lines, formatModelJobResults(jobInfo.Results)...)
 
 	totalGPUs := float64(0)
 	for _, i := range jobInfo.Instances {
@@ -56,6 +56,7 @@ func (p *modelProcessor) GetModelJob(namespace, name string) (ModelJob, error) {
 		jobType:   p.jobType,
 		job:       job,
 		pods:      pods,
+		results:   getModelJobResults(job.Namespace, job.Name, pods),
 	}, nil
 }
 
@@ -111,6 +112,8 @@ type modelJob struct {
 	jobType   types.ModelJobType
 	pods      []*v1.Pod
 	job       *batchv1.Job
+	// results is only loaded when getting a single model job
+	results *types.ModelJobResults
 }
 
 func (m *modelJob) Uid() string {
@@ -196,16 +199,7 @@ func (m *modelJob) RequestCPUs() int64 {
 }
 
 func (m *modelJob) RequestGPUs() int64 {
-	var podGPUs int64
-	for _, c := range m.job.Spec.Template.Spec.Containers {
-		if val, ok := c.Resources.Limits[v1.ResourceName(types.NvidiaGPUResourceName)]; ok {
-			podGPUs += val.Value()
-		}
-		if val, ok := c.Resources.Limits[v1.ResourceName(types.AliyunGPUResourceName)]; ok {
-			podGPUs += val.Value()
-		}
-	}
-	return podGPUs
+	return utils.GPUCountInContainers(m.job.Spec.Template.Spec.Containers)
 }
 
 func (m *modelJob) RequestGPUMemory() int64 {
@@ -269,6 +263,10 @@ func (m *modelJob) Params() map[string]string {
 	return params
 }
 
+func (m *modelJob) Results() *types.ModelJobResults {
+	return m.results
+}
+
 func (m *modelJob) Convert2JobInfo() types.ModelJobInfo {
 	modelJobType := types.ModelTypeMap[m.jobType].Alias
 
@@ -287,6 +285,7 @@ func (m *modelJob) Convert2JobInfo() types.ModelJobInfo {
 		Instances:         m.Instances(),
 		CreationTimestamp: m.StartTime().Unix(),
 		Params:            m.Params(),
+		Results:           m.Results(),
 	}
 	return servingJobInfo
 }
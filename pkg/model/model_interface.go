@@ -37,6 +37,8 @@ type ModelJob interface {
 	RequestGPUCore() int64
 	// Params return the job parameters
 	Params() map[string]string
+	// Results return the structured results reported by the job
+	Results() *types.ModelJobResults
 	// Convert2JobInfo convert to ModelJobInfo
 	Convert2JobInfo() types.ModelJobInfo
 }
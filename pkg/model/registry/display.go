@@ -0,0 +1,124 @@
+package registry
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/kubeflow/arena/pkg/apis/types"
+	"gopkg.in/yaml.v2"
+)
+
+var getModelTemplate = `
+Name:               %v
+Namespace:          %v
+Version:            %v
+Stage:              %v
+Framework:          %v
+StorageURI:         %v
+Description:        %v
+SourceJob:          %v
+SourceJobType:      %v
+SourceJobUID:       %v
+CreationTimestamp:  %v
+UpdateTimestamp:    %v
+%v
+`
+
+// DisplayModels prints the registered model versions
+func DisplayModels(models []*types.RegisteredModelInfo, allNamespaces bool, format types.FormatStyle) {
+	switch format {
+	case "json":
+		data, _ := json.MarshalIndent(models, "", "    ")
+		fmt.Printf("%v", string(data))
+		return
+	case "yaml":
+		data, _ := yaml.Marshal(models)
+		fmt.Printf("%v", string(data))
+		return
+	case "", "wide":
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		header := []string{"NAME", "VERSION", "STAGE", "FRAMEWORK", "STORAGE_URI", "SOURCE_JOB", "CREATE_TIME"}
+		if allNamespaces {
+			header = append([]string{"NAMESPACE"}, header...)
+		}
+		printLine(w, header...)
+		for _, model := range models {
+			items := []string{
+				model.Name,
+				model.Version,
+				string(model.Stage),
+				valueOrNone(model.Framework),
+				model.StorageURI,
+				valueOrNone(model.SourceJob),
+				model.CreationTimestamp,
+			}
+			if allNamespaces {
+				items = append([]string{model.Namespace}, items...)
+			}
+			printLine(w, items...)
+		}
+		_ = w.Flush()
+		return
+	}
+}
+
+// DisplayModel prints the detail of a registered model version
+func DisplayModel(model *types.RegisteredModelInfo, format types.FormatStyle) {
+	switch format {
+	case "json":
+		data, _ := json.MarshalIndent(model, "", "    ")
+		fmt.Printf("%v", string(data))
+		return
+	case "yaml":
+		data, _ := yaml.Marshal(model)
+		fmt.Printf("%v", string(data))
+		return
+	case "", "wide":
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+		lines := []string{"\nMetrics:", "NAME\tVALUE", "----\t-----"}
+		var names []string
+		for name := range model.Metrics {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			lines = append(lines, fmt.Sprintf("%s\t%s", name, model.Metrics[name]))
+		}
+
+		printLine(w, fmt.Sprintf(strings.Trim(getModelTemplate, "\n"),
+			model.Name,
+			model.Namespace,
+			model.Version,
+			model.Stage,
+			valueOrNone(model.Framework),
+			model.StorageURI,
+			valueOrNone(model.Description),
+			valueOrNone(model.SourceJob),
+			valueOrNone(model.SourceJobType),
+			valueOrNone(model.SourceJobUID),
+			model.CreationTimestamp,
+			model.UpdateTimestamp,
+			strings.Join(lines, "\n"),
+		))
+		_ = w.Flush()
+		return
+	}
+}
+
+func valueOrNone(value string) string {
+	if value == "" {
+		return "N/A"
+	}
+	return value
+}
+
+func printLine(w io.Writer, fields ...string) {
+	buffer := strings.Join(fields, "\t")
+	fmt.Fprintln(w, buffer)
+}
@@ -0,0 +1,383 @@
+package registry
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kubeflow/arena/pkg/apis/config"
+	"github.com/kubeflow/arena/pkg/apis/types"
+	log "github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// the registered model versions are stored in the labeled configmaps,
+// one configmap for one model version
+const (
+	modelRegistryLabelKey = "arena.kubeflow.org/model-registry"
+	modelNameLabelKey     = "arena.kubeflow.org/model-name"
+	modelVersionLabelKey  = "arena.kubeflow.org/model-version"
+	modelStageLabelKey    = "arena.kubeflow.org/model-stage"
+	modelInfoDataKey      = "model.json"
+	// maxModelLabelValueLength is the max length of the label value in kubernetes,
+	// the model name and version are stored in labels
+	maxModelLabelValueLength = 63
+	// modelConfigMapHashLength is the length of the hash suffix in the configmap name
+	modelConfigMapHashLength = 10
+	// latestModelVersion refers to the newest version of the model
+	latestModelVersion = "latest"
+)
+
+var (
+	modelNameRegexp    = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+	modelVersionRegexp = regexp.MustCompile(`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`)
+)
+
+// RegisterModel registers a new version of the model, the version is increased
+// automatically if it is not specified
+func RegisterModel(args *types.RegisterModelArgs) (*types.RegisteredModelInfo, error) {
+	if err := validateModelName(args.Name); err != nil {
+		return nil, err
+	}
+	if args.StorageURI == "" {
+		return nil, fmt.Errorf("--storage-uri must be specified")
+	}
+	if args.Stage == "" {
+		args.Stage = types.ModelStageNone
+	}
+	if err := validateModelStage(args.Stage); err != nil {
+		return nil, err
+	}
+
+	versions, err := ListModelVersions(args.Namespace, args.Name)
+	if err != nil {
+		return nil, err
+	}
+	if args.Version == "" {
+		args.Version = nextModelVersion(versions)
+	}
+	if err := validateModelVersion(args.Version); err != nil {
+		return nil, err
+	}
+	for _, item := range versions {
+		if item.Version == args.Version {
+			return nil, fmt.Errorf("the version %s of model %s is already registered", args.Version, args.Name)
+		}
+	}
+
+	now := time.Now().Format(time.RFC3339)
+	model := &types.RegisteredModelInfo{
+		Name:              args.Name,
+		Namespace:         args.Namespace,
+		Version:           args.Version,
+		StorageURI:        args.StorageURI,
+		Framework:         args.Framework,
+		Stage:             types.ModelStageNone,
+		Metrics:           args.Metrics,
+		Description:       args.Description,
+		SourceJob:         args.SourceJob,
+		SourceJobType:     args.SourceJobType,
+		SourceJobUID:      args.SourceJobUID,
+		CreationTimestamp: now,
+		UpdateTimestamp:   now,
+	}
+	configmap, err := buildModelConfigMap(model)
+	if err != nil {
+		return nil, err
+	}
+	client := config.GetArenaConfiger().GetClientSet()
+	_, err = client.CoreV1().ConfigMaps(args.Namespace).Create(context.TODO(), configmap, metav1.CreateOptions{})
+	if err != nil {
+		if k8serrors.IsAlreadyExists(err) {
+			return nil, fmt.Errorf("the version %s of model %s is already registered", args.Version, args.Name)
+		}
+		return nil, err
+	}
+
+	if args.Stage != types.ModelStageNone {
+		return PromoteModel(args.Namespace, args.Name, args.Version, args.Stage)
+	}
+	return model, nil
+}
+
+// ListModels lists the registered model versions, the model name and stage are optional filters
+func ListModels(namespace string, allNamespaces bool, name string, stage types.ModelStage) ([]*types.RegisteredModelInfo, error) {
+	if allNamespaces {
+		namespace = metav1.NamespaceAll
+	}
+	selector := []string{fmt.Sprintf("%s=true", modelRegistryLabelKey)}
+	if name != "" {
+		selector = append(selector, fmt.Sprintf("%s=%s", modelNameLabelKey, name))
+	}
+	if stage != "" {
+		selector = append(selector, fmt.Sprintf("%s=%s", modelStageLabelKey, stage))
+	}
+	client := config.GetArenaConfiger().GetClientSet()
+	configmaps, err := client.CoreV1().ConfigMaps(namespace).List(context.TODO(), metav1.ListOptions{
+		LabelSelector: strings.Join(selector, ","),
+	})
+	if err != nil {
+		return nil, err
+	}
+	models := []*types.RegisteredModelInfo{}
+	for i := range configmaps.Items {
+		model, err := parseModelConfigMap(&configmaps.Items[i])
+		if err != nil {
+			log.Warnf("skip the invalid model registry configmap %s/%s: %v", configmaps.Items[i].Namespace, configmaps.Items[i].Name, err)
+			continue
+		}
+		models = append(models, model)
+	}
+	sort.SliceStable(models, func(i, j int) bool {
+		if models[i].Namespace != models[j].Namespace {
+			return models[i].Namespace < models[j].Namespace
+		}
+		if models[i].Name != models[j].Name {
+			return models[i].Name < models[j].Name
+		}
+		return lessModelVersion(models[i], models[j])
+	})
+	return models, nil
+}
+
+// ListModelVersions lists all versions of the model, the oldest version is the first one
+func ListModelVersions(namespace, name string) ([]*types.RegisteredModelInfo, error) {
+	return ListModels(namespace, false, name, "")
+}
+
+// GetModel gets the version of model, the version can be a version number, a stage
+// like production or latest, the latest version is returned if it is empty
+func GetModel(namespace, name, version string) (*types.RegisteredModelInfo, error) {
+	if version != "" && version != latestModelVersion && !isModelStage(version) {
+		configmap, err := getModelConfigMap(namespace, name, version)
+		if err != nil {
+			return nil, err
+		}
+		return parseModelConfigMap(configmap)
+	}
+
+	stage := types.ModelStage("")
+	if isModelStage(version) {
+		stage = types.ModelStage(version)
+	}
+	models, err := ListModels(namespace, false, name, stage)
+	if err != nil {
+		return nil, err
+	}
+	if len(models) == 0 {
+		if stage != "" {
+			return nil, fmt.Errorf("not found the %s version of model %s in namespace %s", stage, name, namespace)
+		}
+		return nil, fmt.Errorf("not found model %s in namespace %s", name, namespace)
+	}
+	return models[len(models)-1], nil
+}
+
+// PromoteModel moves the version of model to the stage, only one version of a model can be
+// in the staging or production stage, the version which is in the stage before is archived
+func PromoteModel(namespace, name, version string, stage types.ModelStage) (*types.RegisteredModelInfo, error) {
+	if err := validateModelStage(stage); err != nil {
+		return nil, err
+	}
+	model, err := GetModel(namespace, name, version)
+	if err != nil {
+		return nil, err
+	}
+
+	if stage == types.ModelStageStaging || stage == types.ModelStageProduction {
+		current, err := ListModels(namespace, false, name, stage)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range current {
+			if item.Version == model.Version {
+				continue
+			}
+			if err := updateModelStage(item, types.ModelStageArchived); err != nil {
+				return nil, err
+			}
+			log.Infof("the version %s of model %s is archived", item.Version, name)
+		}
+	}
+
+	if err := updateModelStage(model, stage); err != nil {
+		return nil, err
+	}
+	return model, nil
+}
+
+// ResolveModelURI finds the registered model which the uri refers to, the uri
+// is like registry://<name>@<version>, the version can also be a stage or be omitted
+func ResolveModelURI(namespace, uri string) (*types.RegisteredModelInfo, error) {
+	if !IsModelRegistryURI(uri) {
+		return nil, fmt.Errorf("invalid model uri %s, it should be like %s<name>@<version>", uri, types.ModelRegistryScheme)
+	}
+	reference := strings.TrimPrefix(uri, types.ModelRegistryScheme)
+	name, version := reference, ""
+	if index := strings.LastIndex(reference, "@"); index >= 0 {
+		name, version = reference[:index], reference[index+1:]
+	}
+	if name == "" {
+		return nil, fmt.Errorf("invalid model uri %s, the model name is empty", uri)
+	}
+	return GetModel(namespace, name, version)
+}
+
+// IsModelRegistryURI checks the uri refers to a registered model or not
+func IsModelRegistryURI(uri string) bool {
+	return strings.HasPrefix(uri, types.ModelRegistryScheme)
+}
+
+func updateModelStage(model *types.RegisteredModelInfo, stage types.ModelStage) error {
+	client := config.GetArenaConfiger().GetClientSet()
+	configmap, err := getModelConfigMap(model.Namespace, model.Name, model.Version)
+	if err != nil {
+		return err
+	}
+	model.Stage = stage
+	model.UpdateTimestamp = time.Now().Format(time.RFC3339)
+	data, err := json.Marshal(model)
+	if err != nil {
+		return err
+	}
+	configmap.Labels[modelStageLabelKey] = string(stage)
+	configmap.Data[modelInfoDataKey] = string(data)
+	_, err = client.CoreV1().ConfigMaps(model.Namespace).Update(context.TODO(), configmap, metav1.UpdateOptions{})
+	return err
+}
+
+func buildModelConfigMap(model *types.RegisteredModelInfo) (*v1.ConfigMap, error) {
+	data, err := json.Marshal(model)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      modelConfigMapName(model.Name, model.Version),
+			Namespace: model.Namespace,
+			Labels: map[string]string{
+				"createdBy":           "arena",
+				modelRegistryLabelKey: "true",
+				modelNameLabelKey:     model.Name,
+				modelVersionLabelKey:  model.Version,
+				modelStageLabelKey:    string(model.Stage),
+			},
+		},
+		Data: map[string]string{
+			modelInfoDataKey: string(data),
+		},
+	}, nil
+}
+
+// getModelConfigMap gets the configmap of the model version by its name, the labels of the configmap are checked
+func getModelConfigMap(namespace, name, version string) (*v1.ConfigMap, error) {
+	client := config.GetArenaConfiger().GetClientSet()
+	configmap, err := client.CoreV1().ConfigMaps(namespace).Get(context.TODO(), modelConfigMapName(name, version), metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return nil, fmt.Errorf("not found the version %s of model %s in namespace %s", version, name, namespace)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !isModelConfigMapOf(configmap, name, version) {
+		return nil, fmt.Errorf("the configmap %s/%s is not the version %s of model %s", namespace, configmap.Name, version, name)
+	}
+	return configmap, nil
+}
+
+func isModelConfigMapOf(configmap *v1.ConfigMap, name, version string) bool {
+	return configmap.Labels[modelRegistryLabelKey] == "true" &&
+		configmap.Labels[modelNameLabelKey] == name &&
+		configmap.Labels[modelVersionLabelKey] == version
+}
+
+func parseModelConfigMap(configmap *v1.ConfigMap) (*types.RegisteredModelInfo, error) {
+	data, ok := configmap.Data[modelInfoDataKey]
+	if !ok {
+		return nil, fmt.Errorf("not found key %s", modelInfoDataKey)
+	}
+	model := &types.RegisteredModelInfo{}
+	if err := json.Unmarshal([]byte(data), model); err != nil {
+		return nil, err
+	}
+	if !isModelConfigMapOf(configmap, model.Name, model.Version) {
+		return nil, fmt.Errorf("the labels do not match the version %s of model %s", model.Version, model.Name)
+	}
+	model.Namespace = configmap.Namespace
+	return model, nil
+}
+
+// modelConfigMapName returns the name of configmap which stores the model version, the hash suffix
+// has a fixed length, so the different model names and versions never share the same configmap name
+func modelConfigMapName(name, version string) string {
+	sum := sha256.Sum256([]byte(name + "@" + version))
+	return fmt.Sprintf("model-%s-%s", name, hex.EncodeToString(sum[:])[:modelConfigMapHashLength])
+}
+
+func validateModelName(name string) error {
+	if len(name) > maxModelLabelValueLength {
+		return fmt.Errorf("invalid model name %s, it should be no more than %d characters", name, maxModelLabelValueLength)
+	}
+	if !modelNameRegexp.MatchString(name) {
+		return fmt.Errorf("invalid model name %s, it should be lower case letters, numbers and dashes ONLY", name)
+	}
+	return nil
+}
+
+func validateModelVersion(version string) error {
+	if len(version) > maxModelLabelValueLength {
+		return fmt.Errorf("invalid model version %s, it should be no more than %d characters", version, maxModelLabelValueLength)
+	}
+	if !modelVersionRegexp.MatchString(version) || version == latestModelVersion || isModelStage(version) {
+		return fmt.Errorf("invalid model version %s, it should be lower case letters, numbers, dots and dashes ONLY and not a stage name", version)
+	}
+	return nil
+}
+
+// nextModelVersion returns the max numeric version plus 1
+func nextModelVersion(versions []*types.RegisteredModelInfo) string {
+	next := 1
+	for _, item := range versions {
+		if v, err := strconv.Atoi(item.Version); err == nil && v >= next {
+			next = v + 1
+		}
+	}
+	return strconv.Itoa(next)
+}
+
+// lessModelVersion compares the numeric versions by number, otherwise by the creation time
+func lessModelVersion(a, b *types.RegisteredModelInfo) bool {
+	va, errA := strconv.Atoi(a.Version)
+	vb, errB := strconv.Atoi(b.Version)
+	if errA == nil && errB == nil {
+		return va < vb
+	}
+	if a.CreationTimestamp != b.CreationTimestamp {
+		return a.CreationTimestamp < b.CreationTimestamp
+	}
+	return a.Version < b.Version
+}
+
+func isModelStage(value string) bool {
+	switch types.ModelStage(value) {
+	case types.ModelStageNone, types.ModelStageStaging, types.ModelStageProduction, types.ModelStageArchived:
+		return true
+	}
+	return false
+}
+
+func validateModelStage(stage types.ModelStage) error {
+	if !isModelStage(string(stage)) {
+		return fmt.Errorf("invalid model stage %s, only support:[none|staging|production|archived]", stage)
+	}
+	return nil
+}
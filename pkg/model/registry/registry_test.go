@@ -0,0 +1,103 @@
+package registry
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kubeflow/arena/pkg/apis/types"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestValidateModelName(t *testing.T) {
+	tc := []struct {
+		Name     string
+		Expected bool
+	}{
+		{Name: "bert", Expected: true},
+		{Name: "bert-base-2", Expected: true},
+		{Name: "", Expected: false},
+		{Name: "Bert", Expected: false},
+		{Name: "bert-", Expected: false},
+		{Name: "bert.base", Expected: false},
+		{Name: "bert@1", Expected: false},
+		{Name: strings.Repeat("a", 63), Expected: true},
+		{Name: strings.Repeat("a", 64), Expected: false},
+	}
+	for _, c := range tc {
+		actual := validateModelName(c.Name) == nil
+		if actual != c.Expected {
+			t.Errorf("validateModelName(%q): expected %v; got %v", c.Name, c.Expected, actual)
+		}
+	}
+}
+
+func TestValidateModelVersion(t *testing.T) {
+	tc := []struct {
+		Version  string
+		Expected bool
+	}{
+		{Version: "1", Expected: true},
+		{Version: "1.2.0", Expected: true},
+		{Version: "v1-rc1", Expected: true},
+		{Version: "", Expected: false},
+		{Version: "latest", Expected: false},
+		{Version: "production", Expected: false},
+		{Version: "none", Expected: false},
+		{Version: "1.", Expected: false},
+		{Version: "V1", Expected: false},
+		{Version: strings.Repeat("1", 63), Expected: true},
+		{Version: strings.Repeat("1", 64), Expected: false},
+	}
+	for _, c := range tc {
+		actual := validateModelVersion(c.Version) == nil
+		if actual != c.Expected {
+			t.Errorf("validateModelVersion(%q): expected %v; got %v", c.Version, c.Expected, actual)
+		}
+	}
+}
+
+func TestModelConfigMapName(t *testing.T) {
+	if modelConfigMapName("a-b", "1") == modelConfigMapName("a", "b-1") {
+		t.Errorf("the model a-b@1 and a@b-1 should not share the configmap name %s", modelConfigMapName("a", "b-1"))
+	}
+	if modelConfigMapName("bert", "1") != modelConfigMapName("bert", "1") {
+		t.Errorf("the configmap name of the same model version should be stable")
+	}
+	name := modelConfigMapName(strings.Repeat("a", 63), strings.Repeat("1", 63))
+	if len(name) > 253 {
+		t.Errorf("the configmap name %s is longer than 253 characters", name)
+	}
+}
+
+func TestParseModelConfigMap(t *testing.T) {
+	model := &types.RegisteredModelInfo{Name: "a", Version: "b-1", StorageURI: "pvc://models/a"}
+	configmap, err := buildModelConfigMap(model)
+	if err != nil {
+		t.Fatalf("failed to build the configmap: %v", err)
+	}
+	configmap.Namespace = "default"
+	parsed, err := parseModelConfigMap(configmap)
+	if err != nil {
+		t.Fatalf("failed to parse the configmap: %v", err)
+	}
+	if parsed.Name != "a" || parsed.Version != "b-1" || parsed.Namespace != "default" {
+		t.Errorf("expected the version b-1 of model a in namespace default; got %+v", parsed)
+	}
+
+	// the configmap of another model version is rejected
+	configmap.ObjectMeta = metav1.ObjectMeta{
+		Name: configmap.Name,
+		Labels: map[string]string{
+			modelRegistryLabelKey: "true",
+			modelNameLabelKey:     "a-b",
+			modelVersionLabelKey:  "1",
+		},
+	}
+	if _, err := parseModelConfigMap(configmap); err == nil {
+		t.Errorf("expected the configmap with mismatched labels to be rejected")
+	}
+	if _, err := parseModelConfigMap(&v1.ConfigMap{}); err == nil {
+		t.Errorf("expected the configmap without model info to be rejected")
+	}
+}
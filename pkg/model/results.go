@@ -0,0 +1,202 @@
+package model
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/kubeflow/arena/pkg/apis/config"
+	"github.com/kubeflow/arena/pkg/apis/types"
+	log "github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"path"
+	"sort"
+	"strings"
+)
+
+const (
+	// modelResultsFileEnv is the env of model job container which tells where to write the results json,
+	// the file is the termination message path of the container, so it is limited to 4KB
+	modelResultsFileEnv = "ARENA_MODEL_RESULTS_FILE"
+	// modelResultsDirEnv is the env of model job container which tells the directory to write the results json
+	// which may be larger than 4KB, it is uploaded to the results configmap after the container terminated
+	modelResultsDirEnv = "ARENA_MODEL_RESULTS_DIR"
+	// modelResultsConfigMapSuffix is the suffix of configmap which stores the large results json
+	modelResultsConfigMapSuffix = "-results"
+	// maxTerminationMessageLength is the max length of the termination message, the longer message is truncated
+	maxTerminationMessageLength = 4096
+	modelResultsDataKey         = "results.json"
+	modelJobContainerName       = "modeljob"
+	// maxProfileLayersToShow is the number of the most time-consuming layers to show
+	maxProfileLayersToShow = 10
+)
+
+// appendCollectBenchmarkResultsCommand copies the benchmark result which is the last line of
+// benchmark_result.txt in the report path to the results file after the job command succeeds
+func appendCollectBenchmarkResultsCommand(command, reportPath string) string {
+	resultFile := path.Join(reportPath, "benchmark_result.txt")
+	return fmt.Sprintf("%s && (tail -n 1 %s > $%s || true)", command, resultFile, modelResultsFileEnv)
+}
+
+// appendCollectProfileResultsCommand copies the results.json in the report path to the results file and the
+// results directory after the job command succeeds, the per-layer profile is usually larger than the results file limit
+func appendCollectProfileResultsCommand(command, reportPath string) string {
+	resultFile := path.Join(reportPath, modelResultsDataKey)
+	return fmt.Sprintf("%s && (cp %s $%s || true) && (cp %s $%s/%s || true)",
+		command, resultFile, modelResultsFileEnv, resultFile, modelResultsDirEnv, modelResultsDataKey)
+}
+
+// modelResultsConfigMapName returns the name of configmap which stores the results json of model job
+func modelResultsConfigMapName(jobName string) string {
+	return jobName + modelResultsConfigMapSuffix
+}
+
+// getModelJobResults reads the results json of model job from the results configmap,
+// then from the termination message of the completed pods
+func getModelJobResults(namespace, name string, pods []*v1.Pod) *types.ModelJobResults {
+	client := config.GetArenaConfiger().GetClientSet()
+	configmap, err := client.CoreV1().ConfigMaps(namespace).Get(context.TODO(), modelResultsConfigMapName(name), metav1.GetOptions{})
+	if err == nil {
+		results, err := parseModelJobResults(configmap.Data[modelResultsDataKey])
+		if err == nil {
+			return results
+		}
+		log.Debugf("failed to parse results of configmap %s: %v", modelResultsConfigMapName(name), err)
+	}
+
+	sorted := make([]*v1.Pod, len(pods))
+	copy(sorted, pods)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].CreationTimestamp.After(sorted[j].CreationTimestamp.Time)
+	})
+	for _, pod := range sorted {
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.Name != modelJobContainerName || status.State.Terminated == nil {
+				continue
+			}
+			message := status.State.Terminated.Message
+			results, err := parseModelJobResults(message)
+			if err != nil {
+				if len(message) >= maxTerminationMessageLength {
+					log.Warnf("the results of pod %s is truncated to %d bytes by the termination message limit, "+
+						"write it to $%s/%s to store it in the configmap %s", pod.Name, len(message), modelResultsDirEnv, modelResultsDataKey, modelResultsConfigMapName(name))
+					continue
+				}
+				log.Debugf("failed to parse results of pod %s: %v", pod.Name, err)
+				continue
+			}
+			return results
+		}
+	}
+	return nil
+}
+
+func parseModelJobResults(data string) (*types.ModelJobResults, error) {
+	data = strings.TrimSpace(data)
+	if data == "" {
+		return nil, fmt.Errorf("the results is empty")
+	}
+	results := &types.ModelJobResults{}
+	if err := json.Unmarshal([]byte(data), results); err != nil {
+		return nil, err
+	}
+	if results.Benchmark != nil || results.Profile != nil {
+		return results, nil
+	}
+
+	// the benchmark result of easy_inference is a flat json
+	benchmark := &easyInferenceBenchmarkResult{}
+	if err := json.Unmarshal([]byte(data), benchmark); err != nil {
+		return nil, err
+	}
+	if benchmark.Throughput == 0 && benchmark.MeanLatency == 0 {
+		return nil, fmt.Errorf("not found benchmark or profile results")
+	}
+	results.Benchmark = &types.ModelBenchmarkResults{
+		Throughput: benchmark.Throughput,
+		Latency: types.ModelLatencyResults{
+			Avg: benchmark.MeanLatency,
+			P50: benchmark.MedianLatency,
+			P90: benchmark.P90Latency,
+			P95: benchmark.P95Latency,
+			P99: benchmark.P99Latency,
+			Max: benchmark.MaxLatency,
+		},
+		GPUUtilization: benchmark.GPUUtilization,
+		GPUMemoryUsed:  benchmark.GPUMemoryUsed * 1024,
+	}
+	return results, nil
+}
+
+// easyInferenceBenchmarkResult is the benchmark result of easy_inference, the latency is
+// in milliseconds and the gpu memory is in GiB
+type easyInferenceBenchmarkResult struct {
+	P90Latency     float64 `json:"p90_latency"`
+	P95Latency     float64 `json:"p95_latency"`
+	P99Latency     float64 `json:"p99_latency"`
+	MaxLatency     float64 `json:"max_latency"`
+	MeanLatency    float64 `json:"mean_latency"`
+	MedianLatency  float64 `json:"median_latency"`
+	Throughput     float64 `json:"throughput"`
+	GPUMemoryUsed  float64 `json:"gpu_mem_used"`
+	GPUUtilization float64 `json:"gpu_utilization"`
+}
+
+// formatModelJobResults returns the lines of benchmark results and profile summary
+func formatModelJobResults(results *types.ModelJobResults) []string {
+	lines := []string{}
+	if results == nil {
+		return lines
+	}
+	if b := results.Benchmark; b != nil {
+		lines = append(lines, "", "Benchmark Results:")
+		if b.Concurrency != 0 {
+			lines = append(lines, fmt.Sprintf("  Concurrency:\t%d", b.Concurrency))
+		}
+		if b.Requests != 0 {
+			lines = append(lines, fmt.Sprintf("  Requests:\t%d (failed: %d)", b.Requests, b.FailedRequests))
+		}
+		if b.Duration != 0 {
+			lines = append(lines, fmt.Sprintf("  Duration:\t%.2fs", b.Duration))
+		}
+		lines = append(lines, fmt.Sprintf("  Throughput:\t%.2f req/s", b.Throughput))
+		if b.GPUUtilization != 0 {
+			lines = append(lines, fmt.Sprintf("  GPU Utilization:\t%.1f%%", b.GPUUtilization))
+		}
+		if b.GPUMemoryUsed != 0 {
+			lines = append(lines, fmt.Sprintf("  GPU Memory Used:\t%.0fMiB", b.GPUMemoryUsed))
+		}
+		lines = append(lines, "  Latency(ms):")
+		lines = append(lines, "    AVG\tP50\tP90\tP95\tP99\tMAX")
+		lines = append(lines, "    ---\t---\t---\t---\t---\t---")
+		lines = append(lines, fmt.Sprintf("    %.2f\t%.2f\t%.2f\t%.2f\t%.2f\t%.2f",
+			b.Latency.Avg, b.Latency.P50, b.Latency.P90, b.Latency.P95, b.Latency.P99, b.Latency.Max))
+	}
+	if p := results.Profile; p != nil {
+		layers := make([]types.ModelLayerProfile, len(p.Layers))
+		copy(layers, p.Layers)
+		sort.SliceStable(layers, func(i, j int) bool {
+			return layers[i].TotalTime > layers[j].TotalTime
+		})
+		lines = append(lines, "", "Profile Summary:")
+		lines = append(lines, fmt.Sprintf("  Total Time:\t%.2fms", p.TotalTime))
+		lines = append(lines, fmt.Sprintf("  Top Layers (%d of %d):", minInt(maxProfileLayersToShow, len(layers)), len(layers)))
+		lines = append(lines, "    NAME\tTYPE\tCALLS\tTIME(ms)\tPERCENT")
+		lines = append(lines, "    ----\t----\t-----\t--------\t-------")
+		for i, layer := range layers {
+			if i >= maxProfileLayersToShow {
+				break
+			}
+			lines = append(lines, fmt.Sprintf("    %s\t%s\t%d\t%.3f\t%.1f%%",
+				layer.Name, layer.Type, layer.Calls, layer.TotalTime, layer.Percent))
+		}
+	}
+	return lines
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -13,9 +13,7 @@ func SubmitModelBenchmarkJob(namespace string, args *types.ModelBenchmarkArgs) e
 	args.Namespace = namespace
 
 	if args.Command == "" {
-		args.Command = fmt.Sprintf("python easy_inference/main.py benchmark --model-config-file=%s "+
-			"--report-path=%s --concurrency=%d --requests=%d --duration=%d",
-			args.ModelConfigFile, args.ReportPath, args.Concurrency, args.Requests, args.Duration)
+		args.Command = appendCollectBenchmarkResultsCommand(BuildModelBenchmarkCommand(args), args.ReportPath)
 	}
 
 	modelJobChart := util.GetChartsFolder() + "/modeljob"
@@ -27,3 +25,10 @@ func SubmitModelBenchmarkJob(namespace string, args *types.ModelBenchmarkArgs) e
 	log.Infof("You can run `arena model get %s` to check the job status", args.Name)
 	return nil
 }
+
+// BuildModelBenchmarkCommand returns the default command of model benchmark job
+func BuildModelBenchmarkCommand(args *types.ModelBenchmarkArgs) string {
+	return fmt.Sprintf("python easy_inference/main.py benchmark --model-config-file=%s "+
+		"--report-path=%s --concurrency=%d --requests=%d --duration=%d",
+		args.ModelConfigFile, args.ReportPath, args.Concurrency, args.Requests, args.Duration)
+}
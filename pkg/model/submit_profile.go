@@ -15,7 +15,10 @@ func SubmitModelProfileJob(namespace string, args *types.ModelProfileArgs) error
 	if args.Command == "" {
 		args.Command = fmt.Sprintf("python easy_inference/main.py profile --model-config-file=%s --report-path=%s",
 			args.ModelConfigFile, args.ReportPath)
+		args.Command = appendCollectProfileResultsCommand(args.Command, args.ReportPath)
 	}
+	// the per-layer profile is usually larger than the termination message limit, it is stored in the configmap
+	args.ResultsConfigMap = modelResultsConfigMapName(args.Name)
 
 	modelJobChart := util.GetChartsFolder() + "/modeljob"
 	err := workflow.SubmitJobByHelm(args.Name, string(types.ModelProfileJob), namespace, args, modelJobChart, args.HelmOptions...)
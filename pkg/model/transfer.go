@@ -0,0 +1,448 @@
+package model
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/kubeflow/arena/pkg/apis/config"
+	"github.com/kubeflow/arena/pkg/apis/types"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/rand"
+)
+
+const (
+	// defaultModelTransferImage is the image which runs rclone in the transfer job
+	defaultModelTransferImage   = "rclone/rclone:1.62"
+	defaultModelTransferTimeout = time.Hour
+	modelTransferLabelKey       = "arena.kubeflow.org/model-transfer"
+	modelTransferContainerName  = "transfer"
+	modelTransferPollInterval   = 2 * time.Second
+	// modelTransferTTLAfterFinished is the time to keep the finished transfer job for debugging
+	modelTransferTTLAfterFinished = 24 * time.Hour
+	// the local files are staged in the emptyDir of transfer job, the marker files
+	// coordinate the local transfer with the rclone command in the job
+	modelTransferStagingPath = "/staging"
+	modelTransferDataPath    = "/staging/data"
+	modelTransferReadyFile   = "/staging/.arena-ready"
+	modelTransferCopiedFile  = "/staging/.arena-copied"
+	modelTransferFetchedFile = "/staging/.arena-fetched"
+	// modelTransferS3Remote is the rclone remote of object storage, it is configured by the RCLONE_CONFIG_S3_* envs
+	modelTransferS3Remote = "s3"
+)
+
+// rcloneCheckRegexp matches the summary of rclone check, like "NOTICE: 3 matching files"
+var rcloneCheckRegexp = regexp.MustCompile(`(\d+) matching files`)
+
+type transferEndpointKind string
+
+const (
+	localTransferEndpoint transferEndpointKind = "local"
+	pvcTransferEndpoint   transferEndpointKind = "pvc"
+	s3TransferEndpoint    transferEndpointKind = "s3"
+)
+
+// transferEndpoint is the source or the destination of model transfer
+type transferEndpoint struct {
+	kind transferEndpointKind
+	// path is the local path or the rclone path in the transfer job
+	path       string
+	pvcName    string
+	volumeName string
+	mountPath  string
+}
+
+// TransferModel runs the transfer job which copies the model from the source to the destination
+// with rclone, the files are verified by checksum after they are copied. A local path is staged in the
+// job, it is uploaded to the job before the copy or downloaded from the job after the copy
+func TransferModel(args *types.ModelTransferArgs) (*types.ModelTransferResult, error) {
+	source, destination, err := validateModelTransferArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	if args.Image == "" {
+		args.Image = defaultModelTransferImage
+	}
+	if args.Timeout <= 0 {
+		args.Timeout = defaultModelTransferTimeout
+	}
+	start := time.Now()
+	deadline := start.Add(args.Timeout)
+
+	clientset := config.GetArenaConfiger().GetClientSet()
+	job := buildModelTransferJob(args, source, destination)
+	if _, err := clientset.BatchV1().Jobs(args.Namespace).Create(context.TODO(), job, metav1.CreateOptions{}); err != nil {
+		return nil, fmt.Errorf("failed to create the model transfer job: %v", err)
+	}
+	log.Infof("the model transfer job %s/%s has been created, it copies %s to %s", args.Namespace, job.Name, args.Source, args.Destination)
+
+	result := &types.ModelTransferResult{
+		Type:        args.Type,
+		JobName:     job.Name,
+		Namespace:   args.Namespace,
+		Source:      args.Source,
+		Destination: args.Destination,
+	}
+	if err := runModelTransferJob(args, job.Name, source, destination, deadline, result); err != nil {
+		log.Warnf("the model transfer job %s/%s is kept for debugging until it is cleaned after finished for %v, or delete it by `kubectl delete job %s -n %s`", args.Namespace, job.Name, modelTransferTTLAfterFinished, job.Name, args.Namespace)
+		return nil, err
+	}
+	result.Duration = time.Since(start).Round(time.Second).String()
+
+	propagation := metav1.DeletePropagationBackground
+	if err := clientset.BatchV1().Jobs(args.Namespace).Delete(context.TODO(), job.Name, metav1.DeleteOptions{PropagationPolicy: &propagation}); err != nil {
+		log.Warnf("failed to delete the model transfer job %s/%s: %v", args.Namespace, job.Name, err)
+	}
+	return result, nil
+}
+
+func runModelTransferJob(args *types.ModelTransferArgs, jobName string, source, destination *transferEndpoint, deadline time.Time, result *types.ModelTransferResult) error {
+	podName, err := waitModelTransferPod(args.Namespace, jobName, deadline)
+	if err != nil {
+		return err
+	}
+
+	if source.kind == localTransferEndpoint {
+		files, err := uploadLocalModel(args.Namespace, podName, source.path)
+		if err != nil {
+			return fmt.Errorf("failed to upload %s: %v", source.path, err)
+		}
+		log.Infof("%d files are uploaded and verified by md5 checksum", files)
+		if err := execInModelTransferPod(args.Namespace, podName, []string{"touch", modelTransferReadyFile}, nil, io.Discard); err != nil {
+			return err
+		}
+	}
+
+	logs := &bytes.Buffer{}
+	logsDone := make(chan error, 1)
+	go func() {
+		logsDone <- followModelTransferLogs(args.Namespace, podName, io.MultiWriter(os.Stdout, logs))
+	}()
+
+	if destination.kind == localTransferEndpoint {
+		if err := waitModelTransferFile(args.Namespace, jobName, podName, modelTransferCopiedFile, deadline); err != nil {
+			return err
+		}
+		files, err := downloadLocalModel(args.Namespace, podName, destination.path)
+		if err != nil {
+			return fmt.Errorf("failed to download to %s: %v", destination.path, err)
+		}
+		log.Infof("%d files are downloaded and verified by md5 checksum", files)
+		if err := execInModelTransferPod(args.Namespace, podName, []string{"touch", modelTransferFetchedFile}, nil, io.Discard); err != nil {
+			return err
+		}
+	}
+
+	succeeded, err := waitModelTransferJob(args.Namespace, jobName, deadline)
+	if err != nil {
+		return err
+	}
+	if err := <-logsDone; err != nil {
+		log.Debugf("failed to follow the logs of model transfer job %s: %v", jobName, err)
+	}
+	if !succeeded {
+		return fmt.Errorf("the model transfer job %s/%s is failed, please check the logs above", args.Namespace, jobName)
+	}
+	if matches := rcloneCheckRegexp.FindAllStringSubmatch(logs.String(), -1); len(matches) != 0 {
+		result.Files, _ = strconv.Atoi(matches[len(matches)-1][1])
+	}
+	return nil
+}
+
+func validateModelTransferArgs(args *types.ModelTransferArgs) (*transferEndpoint, *transferEndpoint, error) {
+	if args.Source == "" {
+		return nil, nil, fmt.Errorf("the source path must be specified")
+	}
+	if args.Destination == "" {
+		return nil, nil, fmt.Errorf("--to must be specified")
+	}
+	source, err := parseTransferEndpoint(args.Source, "source")
+	if err != nil {
+		return nil, nil, err
+	}
+	destination, err := parseTransferEndpoint(args.Destination, "destination")
+	if err != nil {
+		return nil, nil, err
+	}
+	switch args.Type {
+	case types.ModelPushTransfer:
+		if source.kind == s3TransferEndpoint {
+			return nil, nil, fmt.Errorf("the source of push should be a local path or %s<pvc_name>/<path>, please use `arena model pull` to import the model from the object storage", types.PVCStorageScheme)
+		}
+		if destination.kind == localTransferEndpoint {
+			return nil, nil, fmt.Errorf("the destination of push should be %s<bucket>/<path> or %s<pvc_name>/<path>", types.S3StorageScheme, types.PVCStorageScheme)
+		}
+	case types.ModelPullTransfer:
+		if source.kind == localTransferEndpoint {
+			return nil, nil, fmt.Errorf("the source of pull should be %s<bucket>/<path> or %s<pvc_name>/<path>", types.S3StorageScheme, types.PVCStorageScheme)
+		}
+	default:
+		return nil, nil, fmt.Errorf("unknown model transfer type %s", args.Type)
+	}
+	if source.kind == localTransferEndpoint {
+		if _, err := os.Stat(source.path); err != nil {
+			return nil, nil, err
+		}
+	}
+	if (source.kind == s3TransferEndpoint || destination.kind == s3TransferEndpoint) && args.Secret == "" {
+		return nil, nil, fmt.Errorf("--secret must be specified to access the object storage")
+	}
+	return source, destination, nil
+}
+
+// parseTransferEndpoint parses the uri like pvc://<pvc_name>/<path>, s3://<bucket>/<path> or a local path
+func parseTransferEndpoint(uri, volumeName string) (*transferEndpoint, error) {
+	switch {
+	case strings.HasPrefix(uri, types.PVCStorageScheme):
+		items := strings.SplitN(strings.TrimPrefix(uri, types.PVCStorageScheme), "/", 2)
+		if items[0] == "" {
+			return nil, fmt.Errorf("invalid pvc uri %s, it should be like %s<pvc_name>/<path>", uri, types.PVCStorageScheme)
+		}
+		subPath := ""
+		if len(items) == 2 {
+			subPath = items[1]
+		}
+		mountPath := path.Join("/mnt", volumeName)
+		return &transferEndpoint{
+			kind:       pvcTransferEndpoint,
+			path:       path.Join(mountPath, subPath),
+			pvcName:    items[0],
+			volumeName: volumeName,
+			mountPath:  mountPath,
+		}, nil
+	case strings.HasPrefix(uri, types.S3StorageScheme):
+		bucketPath := strings.Trim(strings.TrimPrefix(uri, types.S3StorageScheme), "/")
+		if bucketPath == "" {
+			return nil, fmt.Errorf("invalid s3 uri %s, it should be like %s<bucket>/<path>", uri, types.S3StorageScheme)
+		}
+		return &transferEndpoint{
+			kind: s3TransferEndpoint,
+			path: fmt.Sprintf("%s:%s", modelTransferS3Remote, bucketPath),
+		}, nil
+	case strings.Contains(uri, "://"):
+		return nil, fmt.Errorf("unsupported uri %s, only support:[%s|%s|local path]", uri, types.PVCStorageScheme, types.S3StorageScheme)
+	}
+	return &transferEndpoint{
+		kind: localTransferEndpoint,
+		path: uri,
+	}, nil
+}
+
+// buildModelTransferCommand returns the command of transfer job, the source and destination paths are
+// passed to the shell script as the positional arguments $1 and $2, so they are never parsed by the shell
+func buildModelTransferCommand(source, destination *transferEndpoint) []string {
+	sourcePath, destinationPath := source.path, destination.path
+	lines := []string{"set -e"}
+	if source.kind == localTransferEndpoint || destination.kind == localTransferEndpoint {
+		lines = append(lines, fmt.Sprintf("mkdir -p %s", modelTransferDataPath))
+	}
+	if source.kind == localTransferEndpoint {
+		sourcePath = modelTransferDataPath
+		lines = append(lines, fmt.Sprintf("while [ ! -f %s ]; do sleep 1; done", modelTransferReadyFile))
+	}
+	if destination.kind == localTransferEndpoint {
+		destinationPath = modelTransferDataPath
+	}
+	lines = append(lines,
+		`rclone copy "$1" "$2" --checksum --stats 5s --stats-one-line -v`,
+		`rclone check "$1" "$2" --one-way`,
+	)
+	if destination.kind == localTransferEndpoint {
+		lines = append(lines,
+			fmt.Sprintf("touch %s", modelTransferCopiedFile),
+			fmt.Sprintf("while [ ! -f %s ]; do sleep 1; done", modelTransferFetchedFile),
+		)
+	}
+	// the argument after the script is $0 of the shell
+	return []string{"sh", "-c", strings.Join(lines, "\n"), "sh", sourcePath, destinationPath}
+}
+
+func buildModelTransferJob(args *types.ModelTransferArgs, source, destination *transferEndpoint) *batchv1.Job {
+	name := fmt.Sprintf("model-%s-%s", args.Type, rand.String(5))
+	labels := map[string]string{
+		"createdBy":           "arena",
+		modelTransferLabelKey: string(args.Type),
+	}
+	container := v1.Container{
+		Name:    modelTransferContainerName,
+		Image:   args.Image,
+		Command: buildModelTransferCommand(source, destination),
+	}
+	var volumes []v1.Volume
+	if source.kind == localTransferEndpoint || destination.kind == localTransferEndpoint {
+		volumes = append(volumes, v1.Volume{
+			Name:         "staging",
+			VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}},
+		})
+		container.VolumeMounts = append(container.VolumeMounts, v1.VolumeMount{Name: "staging", MountPath: modelTransferStagingPath})
+	}
+	for _, endpoint := range []*transferEndpoint{source, destination} {
+		if endpoint.kind != pvcTransferEndpoint {
+			continue
+		}
+		volumes = append(volumes, v1.Volume{
+			Name: endpoint.volumeName,
+			VolumeSource: v1.VolumeSource{
+				PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: endpoint.pvcName},
+			},
+		})
+		container.VolumeMounts = append(container.VolumeMounts, v1.VolumeMount{Name: endpoint.volumeName, MountPath: endpoint.mountPath})
+	}
+	if source.kind == s3TransferEndpoint || destination.kind == s3TransferEndpoint {
+		remote := strings.ToUpper(modelTransferS3Remote)
+		container.Env = []v1.EnvVar{
+			{Name: fmt.Sprintf("RCLONE_CONFIG_%s_TYPE", remote), Value: "s3"},
+			{Name: fmt.Sprintf("RCLONE_CONFIG_%s_PROVIDER", remote), Value: "Other"},
+			// read AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY from the envs
+			{Name: fmt.Sprintf("RCLONE_CONFIG_%s_ENV_AUTH", remote), Value: "true"},
+		}
+		if args.Endpoint != "" {
+			container.Env = append(container.Env, v1.EnvVar{Name: fmt.Sprintf("RCLONE_CONFIG_%s_ENDPOINT", remote), Value: args.Endpoint})
+		}
+		if args.Region != "" {
+			container.Env = append(container.Env, v1.EnvVar{Name: fmt.Sprintf("RCLONE_CONFIG_%s_REGION", remote), Value: args.Region})
+		}
+		container.EnvFrom = []v1.EnvFromSource{
+			{SecretRef: &v1.SecretEnvSource{LocalObjectReference: v1.LocalObjectReference{Name: args.Secret}}},
+		}
+	}
+	backoffLimit := int32(0)
+	// the pod waits for the marker files of local transfer, so it is killed if arena exits before the timeout
+	activeDeadlineSeconds := int64(math.Ceil(args.Timeout.Seconds()))
+	ttlSecondsAfterFinished := int32(modelTransferTTLAfterFinished.Seconds())
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: args.Namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:            &backoffLimit,
+			ActiveDeadlineSeconds:   &activeDeadlineSeconds,
+			TTLSecondsAfterFinished: &ttlSecondsAfterFinished,
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: v1.PodSpec{
+					RestartPolicy: v1.RestartPolicyNever,
+					Containers:    []v1.Container{container},
+					Volumes:       volumes,
+				},
+			},
+		},
+	}
+}
+
+// waitModelTransferPod waits until the pod of transfer job is running or finished
+func waitModelTransferPod(namespace, jobName string, deadline time.Time) (string, error) {
+	clientset := config.GetArenaConfiger().GetClientSet()
+	reason := ""
+	for time.Now().Before(deadline) {
+		pods, err := clientset.CoreV1().Pods(namespace).List(context.TODO(), metav1.ListOptions{LabelSelector: fmt.Sprintf("job-name=%s", jobName)})
+		if err != nil {
+			return "", err
+		}
+		for _, pod := range pods.Items {
+			switch pod.Status.Phase {
+			case v1.PodRunning, v1.PodSucceeded:
+				return pod.Name, nil
+			case v1.PodFailed:
+				return "", fmt.Errorf("the pod %s of model transfer job is failed: %s", pod.Name, pod.Status.Message)
+			}
+			for _, status := range pod.Status.ContainerStatuses {
+				if status.State.Waiting != nil {
+					reason = status.State.Waiting.Reason
+				}
+			}
+		}
+		time.Sleep(modelTransferPollInterval)
+	}
+	return "", fmt.Errorf("timeout to wait for the pod of model transfer job %s/%s to run, the last waiting reason: %s", namespace, jobName, reason)
+}
+
+// waitModelTransferFile waits until the file is created in the pod of transfer job
+func waitModelTransferFile(namespace, jobName, podName, file string, deadline time.Time) error {
+	clientset := config.GetArenaConfiger().GetClientSet()
+	for time.Now().Before(deadline) {
+		if err := execInModelTransferPod(namespace, podName, []string{"test", "-f", file}, nil, io.Discard); err == nil {
+			return nil
+		}
+		job, err := clientset.BatchV1().Jobs(namespace).Get(context.TODO(), jobName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if job.Status.Failed > 0 {
+			return fmt.Errorf("the model transfer job %s/%s is failed, please check the logs above", namespace, jobName)
+		}
+		time.Sleep(modelTransferPollInterval)
+	}
+	return fmt.Errorf("timeout to wait for the model transfer job %s/%s", namespace, jobName)
+}
+
+func waitModelTransferJob(namespace, name string, deadline time.Time) (bool, error) {
+	clientset := config.GetArenaConfiger().GetClientSet()
+	for time.Now().Before(deadline) {
+		job, err := clientset.BatchV1().Jobs(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		if job.Status.Succeeded > 0 {
+			return true, nil
+		}
+		if job.Status.Failed > 0 {
+			return false, nil
+		}
+		time.Sleep(modelTransferPollInterval)
+	}
+	return false, fmt.Errorf("timeout to wait for the model transfer job %s/%s", namespace, name)
+}
+
+// followModelTransferLogs prints the progress of rclone until the container exits
+func followModelTransferLogs(namespace, podName string, out io.Writer) error {
+	clientset := config.GetArenaConfiger().GetClientSet()
+	stream, err := clientset.CoreV1().Pods(namespace).GetLogs(podName, &v1.PodLogOptions{
+		Container: modelTransferContainerName,
+		Follow:    true,
+	}).Stream(context.TODO())
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+	_, err = io.Copy(out, stream)
+	return err
+}
+
+// DisplayModelTransferResult prints the result of model transfer
+func DisplayModelTransferResult(result *types.ModelTransferResult, format types.FormatStyle) {
+	switch format {
+	case types.JsonFormat:
+		data, _ := json.MarshalIndent(result, "", "    ")
+		fmt.Printf("%v", string(data))
+		return
+	case types.YamlFormat:
+		data, _ := yaml.Marshal(result)
+		fmt.Printf("%v", string(data))
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Source:\t%v\n", result.Source)
+	fmt.Fprintf(w, "Destination:\t%v\n", result.Destination)
+	fmt.Fprintf(w, "VerifiedFiles:\t%v\n", result.Files)
+	fmt.Fprintf(w, "Duration:\t%v\n", result.Duration)
+	_ = w.Flush()
+}
@@ -0,0 +1,329 @@
+package model
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/kubeflow/arena/pkg/apis/config"
+	log "github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+const modelTransferProgressInterval = 5 * time.Second
+
+// uploadLocalModel uploads the local file or directory to the staging directory of transfer job
+// by a tar stream, and returns the number of files which are verified by md5 checksum
+func uploadLocalModel(namespace, podName, localPath string) (int, error) {
+	expected, err := localModelChecksums(localPath, nil)
+	if err != nil {
+		return 0, err
+	}
+	reader, writer := io.Pipe()
+	go func() {
+		writer.CloseWithError(writeModelTarball(localPath, writer))
+	}()
+	var transferred int64
+	stop := reportModelTransferProgress("uploaded", &transferred)
+	err = execInModelTransferPod(namespace, podName, []string{"tar", "xf", "-", "-C", modelTransferDataPath}, &countingReader{reader: reader, count: &transferred}, io.Discard)
+	close(stop)
+	if err != nil {
+		return 0, err
+	}
+	actual, err := remoteModelChecksums(namespace, podName)
+	if err != nil {
+		return 0, err
+	}
+	return compareModelChecksums(expected, actual)
+}
+
+// downloadLocalModel downloads the staging directory of transfer job to the local directory
+// by a tar stream, and returns the number of files which are verified by md5 checksum
+func downloadLocalModel(namespace, podName, localPath string) (int, error) {
+	expected, err := remoteModelChecksums(namespace, podName)
+	if err != nil {
+		return 0, err
+	}
+	if err := os.MkdirAll(localPath, 0755); err != nil {
+		return 0, err
+	}
+	reader, writer := io.Pipe()
+	var transferred int64
+	stop := reportModelTransferProgress("downloaded", &transferred)
+	done := make(chan error, 1)
+	go func() {
+		done <- readModelTarball(localPath, reader)
+		// drain the stream to avoid blocking the exec if the tarball is invalid
+		_, _ = io.Copy(io.Discard, reader)
+	}()
+	err = execInModelTransferPod(namespace, podName, []string{"tar", "cf", "-", "-C", modelTransferDataPath, "."}, nil, &countingWriter{writer: writer, count: &transferred})
+	writer.CloseWithError(err)
+	close(stop)
+	if err != nil {
+		return 0, err
+	}
+	if err := <-done; err != nil {
+		return 0, err
+	}
+	files := make([]string, 0, len(expected))
+	for name := range expected {
+		files = append(files, name)
+	}
+	actual, err := localModelChecksums(localPath, files)
+	if err != nil {
+		return 0, err
+	}
+	return compareModelChecksums(expected, actual)
+}
+
+func execInModelTransferPod(namespace, podName string, command []string, stdin io.Reader, stdout io.Writer) error {
+	clientset := config.GetArenaConfiger().GetClientSet()
+	req := clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Name(podName).
+		Namespace(namespace).
+		SubResource("exec").
+		VersionedParams(&v1.PodExecOptions{
+			Container: modelTransferContainerName,
+			Command:   command,
+			Stdin:     stdin != nil,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+	executor, err := remotecommand.NewSPDYExecutor(config.GetArenaConfiger().GetRestConfig(), "POST", req.URL())
+	if err != nil {
+		return err
+	}
+	stderr := &bytes.Buffer{}
+	err = executor.StreamWithContext(context.TODO(), remotecommand.StreamOptions{
+		Stdin:  stdin,
+		Stdout: stdout,
+		Stderr: stderr,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to execute %s: %v %s", strings.Join(command, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+// remoteModelChecksums returns the md5 checksums of files in the staging directory of transfer job
+func remoteModelChecksums(namespace, podName string) (map[string]string, error) {
+	stdout := &bytes.Buffer{}
+	command := []string{"sh", "-c", fmt.Sprintf("cd %s && find . -type f -exec md5sum {} +", modelTransferDataPath)}
+	if err := execInModelTransferPod(namespace, podName, command, nil, stdout); err != nil {
+		return nil, err
+	}
+	checksums := map[string]string{}
+	scanner := bufio.NewScanner(stdout)
+	for scanner.Scan() {
+		items := strings.SplitN(scanner.Text(), "  ", 2)
+		if len(items) != 2 {
+			continue
+		}
+		checksums[filepath.ToSlash(filepath.Clean(items[1]))] = items[0]
+	}
+	return checksums, scanner.Err()
+}
+
+// localModelChecksums returns the md5 checksums of the local file or the files in the local directory,
+// only the given files are computed if they are specified
+func localModelChecksums(localPath string, files []string) (map[string]string, error) {
+	checksums := map[string]string{}
+	if files != nil {
+		for _, name := range files {
+			checksum, err := md5File(filepath.Join(localPath, filepath.FromSlash(name)))
+			if err != nil {
+				return nil, err
+			}
+			checksums[name] = checksum
+		}
+		return checksums, nil
+	}
+	err := walkModelFiles(localPath, func(file, name string) error {
+		checksum, err := md5File(file)
+		if err != nil {
+			return err
+		}
+		checksums[name] = checksum
+		return nil
+	})
+	return checksums, err
+}
+
+func compareModelChecksums(expected, actual map[string]string) (int, error) {
+	for name, checksum := range expected {
+		if actual[name] == "" {
+			return 0, fmt.Errorf("the file %s is missing after transfer", name)
+		}
+		if actual[name] != checksum {
+			return 0, fmt.Errorf("the md5 checksum of file %s is mismatched, expected %s but got %s", name, checksum, actual[name])
+		}
+	}
+	return len(expected), nil
+}
+
+// walkModelFiles walks the regular files of the local file or directory, the name
+// of file is the slash separated path relative to the directory
+func walkModelFiles(localPath string, fn func(file, name string) error) error {
+	info, err := os.Stat(localPath)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fn(localPath, filepath.Base(localPath))
+	}
+	return filepath.Walk(localPath, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			if !info.IsDir() {
+				log.Warnf("skip %s which is not a regular file", file)
+			}
+			return nil
+		}
+		name, err := filepath.Rel(localPath, file)
+		if err != nil {
+			return err
+		}
+		return fn(file, filepath.ToSlash(name))
+	})
+}
+
+func writeModelTarball(localPath string, writer io.Writer) error {
+	tw := tar.NewWriter(writer)
+	err := walkModelFiles(localPath, func(file, name string) error {
+		info, err := os.Stat(file)
+		if err != nil {
+			return err
+		}
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = name
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return tw.Close()
+}
+
+func readModelTarball(localPath string, reader io.Reader) error {
+	tr := tar.NewReader(reader)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		name := filepath.Clean(filepath.FromSlash(header.Name))
+		if name == "." {
+			continue
+		}
+		if filepath.IsAbs(name) || name == ".." || strings.HasPrefix(name, ".."+string(filepath.Separator)) {
+			return fmt.Errorf("invalid file path %s in the tarball", header.Name)
+		}
+		target := filepath.Join(localPath, name)
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			f, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, os.FileMode(header.Mode).Perm())
+			if err != nil {
+				return err
+			}
+			_, err = io.Copy(f, tr)
+			f.Close()
+			if err != nil {
+				return err
+			}
+		default:
+			log.Warnf("skip %s which is not a regular file", header.Name)
+		}
+	}
+}
+
+func md5File(file string) (string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	hash := md5.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// reportModelTransferProgress prints the transferred bytes periodically until the returned channel is closed
+func reportModelTransferProgress(action string, transferred *int64) chan struct{} {
+	stop := make(chan struct{})
+	start := time.Now()
+	go func() {
+		ticker := time.NewTicker(modelTransferProgressInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				log.Infof("%s %.2f MiB in %v", action, float64(atomic.LoadInt64(transferred))/1024/1024, time.Since(start).Round(time.Second))
+				return
+			case <-ticker.C:
+				log.Infof("%s %.2f MiB", action, float64(atomic.LoadInt64(transferred))/1024/1024)
+			}
+		}
+	}()
+	return stop
+}
+
+type countingReader struct {
+	reader io.Reader
+	count  *int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	atomic.AddInt64(r.count, int64(n))
+	return n, err
+}
+
+type countingWriter struct {
+	writer io.Writer
+	count  *int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.writer.Write(p)
+	atomic.AddInt64(w.count, int64(n))
+	return n, err
+}
@@ -0,0 +1,61 @@
+package model
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBuildModelTransferCommand(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh is not found")
+	}
+	// the fake rclone records its arguments one per line
+	dir := t.TempDir()
+	record := filepath.Join(dir, "args")
+	fake := "#!/bin/sh\nfor arg in \"$@\"; do echo \"$arg\" >> " + record + "; done\n"
+	if err := os.WriteFile(filepath.Join(dir, "rclone"), []byte(fake), 0755); err != nil {
+		t.Fatalf("failed to write the fake rclone: %v", err)
+	}
+
+	tc := []struct {
+		Source      string
+		Destination string
+	}{
+		{Source: "/mnt/models/my model", Destination: "s3:bucket/my model"},
+		{Source: "/mnt/models/a;touch " + filepath.Join(dir, "injected"), Destination: "s3:bucket/$(id)`id`"},
+	}
+	for _, c := range tc {
+		_ = os.Remove(record)
+		source, err := parseTransferEndpoint("pvc://models", "source")
+		if err != nil {
+			t.Fatalf("failed to parse the source: %v", err)
+		}
+		source.path = c.Source
+		destination := &transferEndpoint{kind: s3TransferEndpoint, path: c.Destination}
+		command := buildModelTransferCommand(source, destination)
+		if strings.Contains(command[2], c.Source) || strings.Contains(command[2], c.Destination) {
+			t.Errorf("Expected the paths to be passed as arguments; Got the script %q", command[2])
+		}
+
+		cmd := exec.Command(command[0], command[1:]...)
+		cmd.Env = append(os.Environ(), "PATH="+dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("failed to run the transfer command: %v %s", err, output)
+		}
+		data, err := os.ReadFile(record)
+		if err != nil {
+			t.Fatalf("failed to read the arguments of rclone: %v", err)
+		}
+		args := strings.Split(strings.TrimSpace(string(data)), "\n")
+		// rclone copy <source> <destination> ... and rclone check <source> <destination> ...
+		if len(args) < 3 || args[0] != "copy" || args[1] != c.Source || args[2] != c.Destination {
+			t.Errorf("Expected rclone copy %q %q; Got %q", c.Source, c.Destination, args)
+		}
+		if _, err := os.Stat(filepath.Join(dir, "injected")); err == nil {
+			t.Errorf("the path %q runs commands in the transfer job", c.Source)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package prometheus
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kubeflow/arena/pkg/apis/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// xidErrorsMaxSamples limits the samples returned by the range query of xid errors
+	xidErrorsMaxSamples = 200
+	xidErrorsMinStep    = 15 * time.Second
+)
+
+// GetNodeGPUHealthMetrics returns the xid errors, uncorrectable ecc errors and thermal throttling of gpus in the
+// time window which ends at now, only the gpus which have problems are returned
+func GetNodeGPUHealthMetrics(client *kubernetes.Clientset, nodeNames []string, window time.Duration) (map[string]types.NodeGPUHealthMetrics, error) {
+	if GetPrometheusClient() == nil && getPrometheusServer(client) == nil {
+		return nil, fmt.Errorf("the prometheus is not installed")
+	}
+	profile := GetGPUMetricsProfile(client)
+	if profile.XIDErrorMetric == "" && profile.ECCErrorMetric == "" && profile.ThermalViolationMetric == "" {
+		return nil, fmt.Errorf("the gpu metrics profile %v does not define the gpu health metrics", profile.Name)
+	}
+	result := map[string]types.NodeGPUHealthMetrics{}
+	selector := fmt.Sprintf(`%s=~"%s"`, profile.Labels.Node, strings.Join(nodeNames, "|"))
+	seconds := int64(window.Seconds())
+	for _, item := range []struct {
+		metric string
+		query  string
+		apply  func(device *types.GPUDeviceHealth, value float64)
+	}{
+		{
+			// the xid metric is a gauge holding the last xid error, it keeps the value after the fault,
+			// so only the gpus whose xid changed in the window are regarded as having new xid errors
+			metric: profile.XIDErrorMetric,
+			query:  `(max_over_time(%[1]s{%[2]s}[%[3]ds]) > 0) and (changes(%[1]s{%[2]s}[%[3]ds]) > 0)`,
+			apply:  func(device *types.GPUDeviceHealth, value float64) { device.XID = int(value) },
+		},
+		{
+			// the ecc errors metric is a counter, the errors before the window are not counted
+			metric: profile.ECCErrorMetric,
+			query:  `increase(%s{%s}[%ds]) > 0`,
+			apply:  func(device *types.GPUDeviceHealth, value float64) { device.ECCErrors = value },
+		},
+		{
+			metric: profile.ThermalViolationMetric,
+			query:  `increase(%s{%s}[%ds]) > 0`,
+			apply:  func(device *types.GPUDeviceHealth, value float64) { device.ThermalThrottled = true },
+		},
+	} {
+		if item.metric == "" {
+			continue
+		}
+		metrics, err := QueryPrometheusMetrics(client, fmt.Sprintf(item.query, item.metric, selector, seconds))
+		if err != nil {
+			return nil, err
+		}
+		for _, metric := range metrics {
+			applyGPUMetricsLabels(profile, &metric)
+			value, err := strconv.ParseFloat(metric.Value, 64)
+			if err != nil || metric.NodeName == "" {
+				continue
+			}
+			if result[metric.NodeName] == nil {
+				result[metric.NodeName] = types.NodeGPUHealthMetrics{}
+			}
+			device, ok := result[metric.NodeName][metric.Id]
+			if !ok {
+				device = &types.GPUDeviceHealth{Id: metric.Id}
+				result[metric.NodeName][metric.Id] = device
+			}
+			item.apply(device, value)
+		}
+	}
+	return result, nil
+}
+
+// GetNodeXIDErrors returns the new xid errors reported by the gpus of nodes in the time range, the xid
+// metric holds the last xid error, so a xid error is new only if the value changes from the previous sample,
+// the sample just before the time range is the baseline. Each xid error of a gpu is returned once with
+// the first time it is found
+func GetNodeXIDErrors(client *kubernetes.Clientset, nodeNames []string, start, end time.Time) ([]types.GPUXIDError, error) {
+	profile := GetGPUMetricsProfile(client)
+	xidErrors := []types.GPUXIDError{}
+	if profile.XIDErrorMetric == "" || len(nodeNames) == 0 {
+		return xidErrors, nil
+	}
+	step := (end.Sub(start) / xidErrorsMaxSamples).Round(time.Second)
+	if step < xidErrorsMinStep {
+		step = xidErrorsMinStep
+	}
+	query := fmt.Sprintf(`%s{%s=~"%s"}`,
+		profile.XIDErrorMetric,
+		profile.Labels.Node,
+		strings.Join(nodeNames, "|"),
+	)
+	series, err := queryPrometheusRangeMetrics(client, query, start.Add(-step), end, step)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range series {
+		applyGPUMetricsLabels(profile, &s.info)
+		for _, xidError := range newXIDErrors(s.times, s.values) {
+			xidError.NodeName = s.info.NodeName
+			xidError.GPUId = s.info.Id
+			xidErrors = append(xidErrors, xidError)
+		}
+	}
+	sort.Slice(xidErrors, func(i, j int) bool {
+		return xidErrors[i].Time < xidErrors[j].Time
+	})
+	return xidErrors, nil
+}
+
+// newXIDErrors returns the xid errors which the samples change to, the first sample is the baseline
+// which is reported before, so it is not a new xid error
+func newXIDErrors(times, values []float64) []types.GPUXIDError {
+	xidErrors := []types.GPUXIDError{}
+	found := map[int]bool{}
+	for i := 1; i < len(values) && i < len(times); i++ {
+		xid := int(values[i])
+		if xid <= 0 || values[i] == values[i-1] || found[xid] {
+			continue
+		}
+		found[xid] = true
+		xidErrors = append(xidErrors, types.GPUXIDError{
+			XID:  xid,
+			Time: int64(times[i]),
+		})
+	}
+	return xidErrors
+}
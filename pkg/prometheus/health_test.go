@@ -0,0 +1,34 @@
+package prometheus
+
+import "testing"
+
+func TestNewXIDErrors(t *testing.T) {
+	tc := []struct {
+		Name     string
+		Values   []float64
+		Expected []int
+	}{
+		{Name: "no xid", Values: []float64{0, 0, 0}, Expected: []int{}},
+		{Name: "xid before the range", Values: []float64{79, 79, 79}, Expected: []int{}},
+		{Name: "new xid", Values: []float64{0, 0, 79, 79}, Expected: []int{79}},
+		{Name: "xid changes", Values: []float64{13, 13, 79, 48}, Expected: []int{79, 48}},
+		{Name: "xid reported twice", Values: []float64{0, 79, 0, 79}, Expected: []int{79}},
+		{Name: "single sample", Values: []float64{79}, Expected: []int{}},
+	}
+	for _, c := range tc {
+		times := []float64{}
+		for i := range c.Values {
+			times = append(times, float64(i*15))
+		}
+		actual := newXIDErrors(times, c.Values)
+		if len(actual) != len(c.Expected) {
+			t.Errorf("%s: expected xid errors %v; got %+v", c.Name, c.Expected, actual)
+			continue
+		}
+		for i, xid := range c.Expected {
+			if actual[i].XID != xid {
+				t.Errorf("%s: expected xid errors %v; got %+v", c.Name, c.Expected, actual)
+			}
+		}
+	}
+}
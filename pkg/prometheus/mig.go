@@ -0,0 +1,42 @@
+package prometheus
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kubeflow/arena/pkg/apis/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+// GetNodeMIGDevices returns the mig gpu instances of nodes, they are detected by the gpu memory used metric
+// which has the mig profile label. An empty result is returned if the profile does not support mig
+func GetNodeMIGDevices(client *kubernetes.Clientset, nodeNames []string) (map[string][]types.MIGDeviceMetric, error) {
+	profile := GetGPUMetricsProfile(client)
+	result := map[string][]types.MIGDeviceMetric{}
+	if profile.Labels.MIGProfile == "" {
+		return result, nil
+	}
+	query := fmt.Sprintf(`%s{%s=~"%s", %s!=""}`,
+		profile.MemoryUsedMetric,
+		profile.Labels.Node,
+		strings.Join(nodeNames, "|"),
+		profile.Labels.MIGProfile,
+	)
+	metrics, err := QueryPrometheusMetrics(client, query)
+	if err != nil {
+		return nil, err
+	}
+	for _, metric := range metrics {
+		applyGPUMetricsLabels(profile, &metric)
+		device := types.MIGDeviceMetric{
+			NodeName:     metric.NodeName,
+			GPUId:        metric.Id,
+			Profile:      metric.Labels[profile.Labels.MIGProfile],
+			InstanceId:   metric.Labels[profile.Labels.MIGInstance],
+			PodName:      metric.PodName,
+			PodNamespace: metric.PodNamespace,
+		}
+		result[device.NodeName] = append(result[device.NodeName], device)
+	}
+	return result, nil
+}
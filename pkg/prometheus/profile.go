@@ -0,0 +1,337 @@
+package prometheus
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/kubeflow/arena/pkg/apis/config"
+	"github.com/kubeflow/arena/pkg/apis/types"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+	"k8s.io/client-go/kubernetes"
+)
+
+// the gpu metric names used by arena, the metrics of other exporters are renamed to them
+const (
+	gpuDutyCycleMetric   = "nvidia_gpu_duty_cycle"
+	gpuMemoryUsedMetric  = "nvidia_gpu_memory_used_bytes"
+	gpuMemoryTotalMetric = "nvidia_gpu_memory_total_bytes"
+)
+
+// gpuMetricsProfile caches the selected gpu metrics profile, it is not cached when failed to detect
+// the profile from prometheus, so the detection is retried on the next call
+var gpuMetricsProfile *types.GPUMetricsProfile
+var gpuMetricsProfileLock sync.Mutex
+
+// legacyGPUMetricsProfile is the profile of the gpu exporter which arena supported at first
+var legacyGPUMetricsProfile = &types.GPUMetricsProfile{
+	Name:              "legacy",
+	DutyCycleMetric:   gpuDutyCycleMetric,
+	MemoryUsedMetric:  gpuMemoryUsedMetric,
+	MemoryTotalMetric: gpuMemoryTotalMetric,
+	Labels: types.GPUMetricsLabels{
+		Pod:          "pod_name",
+		Namespace:    "namespace_name",
+		Container:    "container_name",
+		Node:         "node_name",
+		GPUID:        "minor_number",
+		UUID:         "uuid",
+		AllocateMode: "allocate_mode",
+	},
+}
+
+// dcgmGPUMetricsProfile is the profile of nvidia dcgm-exporter, the gpu memory is reported in MiB
+// and the total gpu memory is not reported
+var dcgmGPUMetricsProfile = &types.GPUMetricsProfile{
+	Name:             "dcgm",
+	DutyCycleMetric:  "DCGM_FI_DEV_GPU_UTIL",
+	MemoryUsedMetric: "DCGM_FI_DEV_FB_USED",
+	MemoryFreeMetric: "DCGM_FI_DEV_FB_FREE",
+	MemoryUnit:       "MiB",
+	// the xid errors are reported by the default counters of dcgm-exporter, the ecc errors and
+	// thermal violation require the counters to be enabled
+	XIDErrorMetric:         "DCGM_FI_DEV_XID_ERRORS",
+	ECCErrorMetric:         "DCGM_FI_DEV_ECC_DBE_VOL_TOTAL",
+	ThermalViolationMetric: "DCGM_FI_DEV_THERMAL_VIOLATION",
+	Labels: types.GPUMetricsLabels{
+		Pod:         "pod",
+		Namespace:   "namespace",
+		Container:   "container",
+		Node:        "Hostname",
+		GPUID:       "gpu",
+		UUID:        "UUID",
+		MIGProfile:  "GPU_I_PROFILE",
+		MIGInstance: "GPU_I_ID",
+	},
+}
+
+// GetGPUMetricsProfile returns the gpu metrics profile which is selected by the global configmap arena-config,
+// or detected from the metrics in prometheus
+func GetGPUMetricsProfile(client *kubernetes.Clientset) *types.GPUMetricsProfile {
+	gpuMetricsProfileLock.Lock()
+	defer gpuMetricsProfileLock.Unlock()
+	if gpuMetricsProfile != nil {
+		return gpuMetricsProfile
+	}
+	profile, err := selectGPUMetricsProfile(client, config.GetArenaConfiger().GetGlobalConfigs())
+	if err != nil {
+		log.Debugf("failed to detect the gpu metrics profile,use the %v profile,reason: %v", profile.Name, err)
+		return profile
+	}
+	log.Debugf("use the gpu metrics profile %v", profile.Name)
+	gpuMetricsProfile = profile
+	return gpuMetricsProfile
+}
+
+// getGPUMetricsProfilesInUse returns the selected gpu metrics profile and the profiles referenced by the
+// registered accelerators, the metrics of accelerators may be reported by other exporters
+func getGPUMetricsProfilesInUse(client *kubernetes.Clientset) []*types.GPUMetricsProfile {
+	selected := GetGPUMetricsProfile(client)
+	profiles := []*types.GPUMetricsProfile{selected}
+	names := map[string]bool{selected.Name: true}
+	var allProfiles []*types.GPUMetricsProfile
+	for _, accelerator := range config.GetAccelerators() {
+		if accelerator.MetricsProfile == "" || names[accelerator.MetricsProfile] {
+			continue
+		}
+		if allProfiles == nil {
+			allProfiles = loadGPUMetricsProfiles(config.GetArenaConfiger().GetGlobalConfigs())
+		}
+		found := false
+		for _, profile := range allProfiles {
+			if profile.Name == accelerator.MetricsProfile {
+				profiles = append(profiles, profile)
+				found = true
+				break
+			}
+		}
+		if !found {
+			log.Warnf("not found the gpu metrics profile %v of accelerator %v", accelerator.MetricsProfile, accelerator.ResourceName)
+		}
+		names[accelerator.MetricsProfile] = true
+	}
+	return profiles
+}
+
+// queryGPUMetrics queries the gpu metrics whose label matches one of the values with the profiles in use,
+// the metrics are normalized. Only the failure of querying the selected profile is returned
+func queryGPUMetrics(client *kubernetes.Clientset, label func(labels types.GPUMetricsLabels) string, values []string) ([]types.GpuMetricInfo, error) {
+	result := []types.GpuMetricInfo{}
+	for i, profile := range getGPUMetricsProfilesInUse(client) {
+		query := gpuMetricsQuery(profile, label(profile.Labels), values)
+		metrics, err := QueryPrometheusMetrics(client, query)
+		if err == nil && metrics == nil {
+			err = fmt.Errorf("the result of querying is null")
+		}
+		if err != nil {
+			if i == 0 {
+				return nil, err
+			}
+			log.Debugf("failed to query the gpu metrics of profile %v,reason: %v", profile.Name, err)
+			continue
+		}
+		result = append(result, normalizeGPUMetrics(profile, metrics)...)
+	}
+	return result, nil
+}
+
+// selectGPUMetricsProfile returns the profile selected by the configs or detected from prometheus,
+// the legacy profile is returned with the error when failed to detect the profile
+func selectGPUMetricsProfile(client *kubernetes.Clientset, configs map[string]string) (*types.GPUMetricsProfile, error) {
+	profiles := loadGPUMetricsProfiles(configs)
+	name := strings.TrimSpace(configs[types.GPUMetricsProfileConfigKey])
+	if name != "" && name != types.AutoGPUMetricsProfile {
+		for _, profile := range profiles {
+			if profile.Name == name {
+				return profile, nil
+			}
+		}
+		log.Warnf("not found the gpu metrics profile %v,detect it from prometheus", name)
+	}
+	return detectGPUMetricsProfile(client, profiles)
+}
+
+// loadGPUMetricsProfiles returns the custom profiles defined in the global configmap and the builtin profiles,
+// the custom profiles take precedence over the builtin profiles
+func loadGPUMetricsProfiles(configs map[string]string) []*types.GPUMetricsProfile {
+	profiles := []*types.GPUMetricsProfile{}
+	names := map[string]bool{}
+	if content, ok := configs[types.GPUMetricsProfilesConfigKey]; ok {
+		customProfiles := []*types.GPUMetricsProfile{}
+		if err := yaml.Unmarshal([]byte(content), &customProfiles); err != nil {
+			log.Warnf("failed to parse the gpu metrics profiles in configmap %v,reason: %v", config.GlobalConfigmapName, err)
+		}
+		for _, profile := range customProfiles {
+			if profile.Name == "" || profile.DutyCycleMetric == "" || profile.MemoryUsedMetric == "" {
+				log.Warnf("skip the invalid gpu metrics profile %v,the name, dutyCycleMetric and memoryUsedMetric are required", profile.Name)
+				continue
+			}
+			setDefaultGPUMetricsLabels(&profile.Labels)
+			profiles = append(profiles, profile)
+			names[profile.Name] = true
+		}
+	}
+	for _, profile := range []*types.GPUMetricsProfile{legacyGPUMetricsProfile, dcgmGPUMetricsProfile} {
+		if !names[profile.Name] {
+			profiles = append(profiles, profile)
+		}
+	}
+	return profiles
+}
+
+func setDefaultGPUMetricsLabels(labels *types.GPUMetricsLabels) {
+	defaults := legacyGPUMetricsProfile.Labels
+	for _, item := range []struct {
+		value        *string
+		defaultValue string
+	}{
+		{&labels.Pod, defaults.Pod},
+		{&labels.Namespace, defaults.Namespace},
+		{&labels.Container, defaults.Container},
+		{&labels.Node, defaults.Node},
+		{&labels.GPUID, defaults.GPUID},
+		{&labels.UUID, defaults.UUID},
+	} {
+		if *item.value == "" {
+			*item.value = item.defaultValue
+		}
+	}
+}
+
+// detectGPUMetricsProfile returns the first profile whose gpu duty cycle metric exists in prometheus,
+// the legacy profile is returned if none of them is found or failed to query prometheus
+func detectGPUMetricsProfile(client *kubernetes.Clientset, profiles []*types.GPUMetricsProfile) (*types.GPUMetricsProfile, error) {
+	metricNames := []string{}
+	for _, profile := range profiles {
+		metricNames = append(metricNames, profile.DutyCycleMetric)
+	}
+	query := fmt.Sprintf(`count by (__name__) ({__name__=~"%s"})`, strings.Join(metricNames, "|"))
+	metrics, err := QueryPrometheusMetrics(client, query)
+	if err != nil {
+		return legacyGPUMetricsProfile, err
+	}
+	existed := map[string]bool{}
+	for _, metric := range metrics {
+		existed[metric.MetricName] = true
+	}
+	for _, profile := range profiles {
+		if existed[profile.DutyCycleMetric] {
+			return profile, nil
+		}
+	}
+	return legacyGPUMetricsProfile, nil
+}
+
+// gpuMetricsQuery returns the query of gpu metrics whose label matches one of the values and the extra label matchers
+func gpuMetricsQuery(profile *types.GPUMetricsProfile, label string, values []string, matchers ...string) string {
+	metricNames := []string{}
+	for _, name := range []string{profile.DutyCycleMetric, profile.MemoryUsedMetric, profile.MemoryTotalMetric, profile.MemoryFreeMetric} {
+		if name != "" {
+			metricNames = append(metricNames, name)
+		}
+	}
+	matchers = append([]string{fmt.Sprintf(`%s=~"%s"`, label, strings.Join(values, "|"))}, matchers...)
+	return fmt.Sprintf(`{__name__=~"%s", %s}`, strings.Join(metricNames, "|"), strings.Join(matchers, ", "))
+}
+
+// normalizeGPUMetrics renames the gpu metrics of profile to the ones used by arena, the labels are mapped
+// and the gpu memory is converted to bytes
+func normalizeGPUMetrics(profile *types.GPUMetricsProfile, metrics []types.GpuMetricInfo) []types.GpuMetricInfo {
+	scale := gpuMemoryScale(profile.MemoryUnit)
+	result := []types.GpuMetricInfo{}
+	usedMetrics := map[string]types.GpuMetricInfo{}
+	freeMetrics := map[string]types.GpuMetricInfo{}
+	for _, metric := range metrics {
+		applyGPUMetricsLabels(profile, &metric)
+		key := strings.Join([]string{metric.NodeName, metric.Id, metric.GPUUID, metric.PodNamespace, metric.PodName}, "/")
+		switch metric.MetricName {
+		case profile.DutyCycleMetric:
+			metric.MetricName = gpuDutyCycleMetric
+		case profile.MemoryUsedMetric:
+			metric.MetricName = gpuMemoryUsedMetric
+			metric.Value = scaleGPUMetricValue(metric.Value, scale)
+			usedMetrics[key] = metric
+		case profile.MemoryTotalMetric:
+			metric.MetricName = gpuMemoryTotalMetric
+			metric.Value = scaleGPUMetricValue(metric.Value, scale)
+		case profile.MemoryFreeMetric:
+			metric.Value = scaleGPUMetricValue(metric.Value, scale)
+			freeMetrics[key] = metric
+			continue
+		default:
+			continue
+		}
+		result = append(result, metric)
+	}
+	if profile.MemoryTotalMetric != "" {
+		return result
+	}
+	for key, used := range usedMetrics {
+		free, ok := freeMetrics[key]
+		if !ok {
+			continue
+		}
+		usedValue, err1 := strconv.ParseFloat(used.Value, 64)
+		freeValue, err2 := strconv.ParseFloat(free.Value, 64)
+		if err1 != nil || err2 != nil {
+			continue
+		}
+		total := used
+		total.MetricName = gpuMemoryTotalMetric
+		total.Value = strconv.FormatFloat(usedValue+freeValue, 'f', -1, 64)
+		result = append(result, total)
+	}
+	return result
+}
+
+// applyGPUMetricsLabels sets the fields of metric by the labels of profile, the fields
+// are not changed if the metric does not have the labels
+func applyGPUMetricsLabels(profile *types.GPUMetricsProfile, metric *types.GpuMetricInfo) {
+	for _, item := range []struct {
+		field *string
+		label string
+	}{
+		{&metric.PodName, profile.Labels.Pod},
+		{&metric.PodNamespace, profile.Labels.Namespace},
+		{&metric.ContainerName, profile.Labels.Container},
+		{&metric.NodeName, profile.Labels.Node},
+		{&metric.Id, profile.Labels.GPUID},
+		{&metric.GPUUID, profile.Labels.UUID},
+		{&metric.AllocateMode, profile.Labels.AllocateMode},
+	} {
+		if item.label == "" {
+			continue
+		}
+		if value, ok := metric.Labels[item.label]; ok {
+			*item.field = value
+		}
+	}
+}
+
+func gpuMemoryScale(unit string) float64 {
+	switch strings.ToLower(unit) {
+	case "", "b", "bytes":
+		return 1
+	case "kib":
+		return 1024
+	case "mib":
+		return 1024 * 1024
+	case "gib":
+		return 1024 * 1024 * 1024
+	}
+	log.Warnf("unknown gpu memory unit %v,only support [B|KiB|MiB|GiB],treat it as bytes", unit)
+	return 1
+}
+
+func scaleGPUMetricValue(value string, scale float64) string {
+	if scale == 1 {
+		return value
+	}
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return value
+	}
+	return strconv.FormatFloat(v*scale, 'f', -1, 64)
+}
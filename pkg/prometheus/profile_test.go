@@ -0,0 +1,84 @@
+package prometheus
+
+import (
+	"testing"
+
+	"github.com/kubeflow/arena/pkg/apis/types"
+)
+
+func TestGPUMemoryScale(t *testing.T) {
+	tc := []struct {
+		Unit     string
+		Expected float64
+	}{
+		{Unit: "", Expected: 1},
+		{Unit: "B", Expected: 1},
+		{Unit: "KiB", Expected: 1024},
+		{Unit: "MiB", Expected: 1024 * 1024},
+		{Unit: "gib", Expected: 1024 * 1024 * 1024},
+		{Unit: "MB", Expected: 1},
+	}
+	for _, c := range tc {
+		actual := gpuMemoryScale(c.Unit)
+		if actual != c.Expected {
+			t.Errorf("unit %q: Expected %v; Got %v", c.Unit, c.Expected, actual)
+		}
+	}
+}
+
+func newDCGMMetric(name, gpu, value string) types.GpuMetricInfo {
+	return types.GpuMetricInfo{
+		MetricName: name,
+		Value:      value,
+		Labels: map[string]string{
+			"__name__":  name,
+			"Hostname":  "node-1",
+			"gpu":       gpu,
+			"namespace": "default",
+			"pod":       "tf-worker-0",
+		},
+	}
+}
+
+func TestNormalizeGPUMetrics(t *testing.T) {
+	metrics := []types.GpuMetricInfo{
+		newDCGMMetric("DCGM_FI_DEV_GPU_UTIL", "0", "85"),
+		newDCGMMetric("DCGM_FI_DEV_FB_USED", "0", "1024"),
+		newDCGMMetric("DCGM_FI_DEV_FB_FREE", "0", "3072"),
+		// the gpu 1 has no free memory metric, so its total memory is unknown
+		newDCGMMetric("DCGM_FI_DEV_FB_USED", "1", "512"),
+		newDCGMMetric("DCGM_FI_DEV_SM_CLOCK", "0", "1410"),
+	}
+	result := normalizeGPUMetrics(dcgmGPUMetricsProfile, metrics)
+	values := map[string]string{}
+	for _, m := range result {
+		if m.NodeName != "node-1" || m.PodNamespace != "default" || m.PodName != "tf-worker-0" {
+			t.Errorf("Expected the labels of dcgm-exporter to be mapped; Got %+v", m)
+		}
+		values[m.MetricName+"/"+m.Id] = m.Value
+	}
+	expected := map[string]string{
+		gpuDutyCycleMetric + "/0":   "85",
+		gpuMemoryUsedMetric + "/0":  "1073741824",
+		gpuMemoryTotalMetric + "/0": "4294967296",
+		gpuMemoryUsedMetric + "/1":  "536870912",
+	}
+	if len(values) != len(expected) {
+		t.Errorf("Expected %v; Got %v", expected, values)
+	}
+	for key, value := range expected {
+		if values[key] != value {
+			t.Errorf("%v: Expected %v; Got %v", key, value, values[key])
+		}
+	}
+
+	// the total memory reported by the exporter is used directly and the values in bytes are not scaled
+	legacy := []types.GpuMetricInfo{
+		{MetricName: gpuMemoryUsedMetric, Value: "1024", Labels: map[string]string{"minor_number": "0"}},
+		{MetricName: gpuMemoryTotalMetric, Value: "4096", Labels: map[string]string{"minor_number": "0"}},
+	}
+	result = normalizeGPUMetrics(legacyGPUMetricsProfile, legacy)
+	if len(result) != 2 || result[0].Value != "1024" || result[1].Value != "4096" {
+		t.Errorf("Expected the used memory 1024 and total memory 4096; Got %+v", result)
+	}
+}
@@ -29,11 +29,11 @@ func (m *JobGpuMetric) SetPodMetric(metric types.GpuMetricInfo) {
 	}
 	podGPUMetric := podMetric[metric.Id]
 	switch metric.MetricName {
-	case "nvidia_gpu_duty_cycle":
+	case gpuDutyCycleMetric:
 		podGPUMetric.GpuDutyCycle = v
-	case "nvidia_gpu_memory_used_bytes":
+	case gpuMemoryUsedMetric:
 		podGPUMetric.GpuMemoryUsed = v
-	case "nvidia_gpu_memory_total_bytes":
+	case gpuMemoryTotalMetric:
 		v = math.Trunc(v/(1024*1024*1024)) * (1024 * 1024 * 1024)
 		podGPUMetric.GpuMemoryTotal = v
 	}
@@ -49,13 +49,9 @@ func (m JobGpuMetric) GetPodMetrics(podName string) types.PodGpuMetric {
 
 func GetPodsGpuInfo(client *kubernetes.Clientset, podNames []string) (JobGpuMetric, error) {
 	jobMetric := &JobGpuMetric{}
-	query := fmt.Sprintf(types.POD_METRIC_TMP, strings.Join(types.GPU_METRIC_LIST, "|"), strings.Join(podNames, "|"))
-	gpuMetrics, err := QueryPrometheusMetrics(client, query)
+	gpuMetrics, err := queryGPUMetrics(client, func(labels types.GPUMetricsLabels) string { return labels.Pod }, podNames)
 	if err != nil {
-		return nil, err
-	}
-	if gpuMetrics == nil {
-		return nil, fmt.Errorf("failed to get gpu metrics,because the result of querying is null")
+		return nil, fmt.Errorf("failed to get gpu metrics,reason: %v", err)
 	}
 	for _, metric := range gpuMetrics {
 		jobMetric.SetPodMetric(metric)
@@ -63,6 +59,44 @@ func GetPodsGpuInfo(client *kubernetes.Clientset, podNames []string) (JobGpuMetr
 	return *jobMetric, nil
 }
 
+// GetPodsResourceMetrics returns the cpu and memory usage of pods,the key of map is pod name
+func GetPodsResourceMetrics(client *kubernetes.Clientset, namespace string, podNames []string) (map[string]*types.PodResourceMetric, error) {
+	podMetrics := map[string]*types.PodResourceMetric{}
+	if len(podNames) == 0 {
+		return podMetrics, nil
+	}
+	pods := strings.Join(podNames, "|")
+	cpuMetrics, err := QueryPrometheusMetrics(client, fmt.Sprintf(types.POD_CPU_USAGE_TMP, namespace, pods))
+	if err != nil {
+		return nil, err
+	}
+	memoryMetrics, err := QueryPrometheusMetrics(client, fmt.Sprintf(types.POD_MEMORY_USAGE_TMP, namespace, pods))
+	if err != nil {
+		return nil, err
+	}
+	getPodMetric := func(podName string) *types.PodResourceMetric {
+		if _, ok := podMetrics[podName]; !ok {
+			podMetrics[podName] = &types.PodResourceMetric{}
+		}
+		return podMetrics[podName]
+	}
+	for _, metric := range cpuMetrics {
+		v, err := strconv.ParseFloat(metric.Value, 64)
+		if err != nil || metric.PodName == "" {
+			continue
+		}
+		getPodMetric(metric.PodName).CPUUsage = v
+	}
+	for _, metric := range memoryMetrics {
+		v, err := strconv.ParseFloat(metric.Value, 64)
+		if err != nil || metric.PodName == "" {
+			continue
+		}
+		getPodMetric(metric.PodName).MemoryUsage = v
+	}
+	return podMetrics, nil
+}
+
 func getMetricAverage(metrics []types.GpuMetricInfo) float64 {
 	var result float64
 	result = 0
@@ -80,14 +114,9 @@ func getMetricAverage(metrics []types.GpuMetricInfo) float64 {
 // {__name__=~"nvidia_gpu_duty_cycle|nvidia_gpu_memory_used_bytes|nvidia_gpu_memory_total_bytes", pod_name=~"tf-distributed-test-ps-0|tf-distributed-test-worker-0"}
 
 func GetNodeGPUMetrics(client *kubernetes.Clientset, nodeNames []string) (map[string]types.NodeGpuMetric, error) {
-
-	query := fmt.Sprintf(types.NODE_METRIC_TMP, strings.Join(types.GPU_METRIC_LIST, "|"), strings.Join(nodeNames, "|"))
-	gpuMetrics, err := QueryPrometheusMetrics(client, query)
+	gpuMetrics, err := queryGPUMetrics(client, func(labels types.GPUMetricsLabels) string { return labels.Node }, nodeNames)
 	if err != nil {
-		return nil, err
-	}
-	if gpuMetrics == nil {
-		return nil, fmt.Errorf("failed to get node gpu metrics,because the result of querying is null")
+		return nil, fmt.Errorf("failed to get node gpu metrics,reason: %v", err)
 	}
 	return generateNodeGPUMetrics(gpuMetrics), nil
 }
@@ -122,20 +151,22 @@ func generateNodeGPUMetrics(metrics []types.GpuMetricInfo) map[string]types.Node
 			shareModeUsedGPUMemory[metric.NodeName][metric.Id] = []float64{}
 		}
 		switch metric.MetricName {
-		case "nvidia_gpu_duty_cycle":
+		case gpuDutyCycleMetric:
 			nodeMetrics[metric.NodeName][metric.Id].GpuDutyCycle = v
-		case "nvidia_gpu_memory_used_bytes":
+		case gpuMemoryUsedMetric:
 			nodeMetrics[metric.NodeName][metric.Id].GpuMemoryUsed = v
 			if metric.AllocateMode == "share" {
 				shareModeUsedGPUMemory[metric.NodeName][metric.Id] = append(shareModeUsedGPUMemory[metric.NodeName][metric.Id], v)
 			}
-		case "nvidia_gpu_memory_total_bytes":
+		case gpuMemoryTotalMetric:
 			v = math.Trunc(v/(1024*1024*1024)) * (1024 * 1024 * 1024)
 			nodeMetrics[metric.NodeName][metric.Id].GpuMemoryTotal = v
 		}
 		if metric.PodNamespace != "" && metric.PodName != "" {
 			podName := fmt.Sprintf("%v/%v", metric.PodNamespace, metric.PodName)
-			nodeMetrics[metric.NodeName][metric.Id].PodNames = append(nodeMetrics[metric.NodeName][metric.Id].PodNames, podName)
+			if !containsPodName(nodeMetrics[metric.NodeName][metric.Id].PodNames, podName) {
+				nodeMetrics[metric.NodeName][metric.Id].PodNames = append(nodeMetrics[metric.NodeName][metric.Id].PodNames, podName)
+			}
 		}
 	}
 	for nodeName, allUsedGPUMemory := range shareModeUsedGPUMemory {
@@ -151,3 +182,12 @@ func generateNodeGPUMetrics(metrics []types.GpuMetricInfo) map[string]types.Node
 	}
 	return nodeMetrics
 }
+
+func containsPodName(podNames []string, podName string) bool {
+	for _, name := range podNames {
+		if name == podName {
+			return true
+		}
+	}
+	return false
+}
@@ -127,19 +127,31 @@ func queryPrometheusMetricsByAddress(query string) ([]types.GpuMetricInfo, error
 		vectorVal := result.(model.Vector)
 		for _, v := range vectorVal {
 			gpuMetric := types.GpuMetricInfo{
-				Time:  float64(v.Timestamp),
-				Value: v.Value.String(),
+				Time:   float64(v.Timestamp),
+				Value:  v.Value.String(),
+				Labels: map[string]string{},
 			}
 			for labelKey, labelVal := range v.Metric {
+				gpuMetric.Labels[string(labelKey)] = string(labelVal)
 				switch string(labelKey) {
 				case "__name__":
 					gpuMetric.MetricName = string(labelVal)
 				case "namespace_name":
 					gpuMetric.PodNamespace = string(labelVal)
+				case "namespace":
+					// the label is used by cadvisor,namespace_name takes precedence over it
+					if gpuMetric.PodNamespace == "" {
+						gpuMetric.PodNamespace = string(labelVal)
+					}
 				case "node_name":
 					gpuMetric.NodeName = string(labelVal)
 				case "pod_name":
 					gpuMetric.PodName = string(labelVal)
+				case "pod":
+					// the label is used by cadvisor,pod_name takes precedence over it
+					if gpuMetric.PodName == "" {
+						gpuMetric.PodName = string(labelVal)
+					}
 				case "container_name":
 					gpuMetric.ContainerName = string(labelVal)
 				case "uuid":
@@ -199,20 +211,31 @@ func queryPrometheusMetricsProxyByAPIServer(client *kubernetes.Clientset, query
 	for _, m := range metricResponse.Data.Result {
 		gpuMetric = append(gpuMetric, types.GpuMetricInfo{
 			MetricName:    m.Metric["__name__"],
-			PodNamespace:  m.Metric["namespace_name"],
+			PodNamespace:  firstNonEmptyLabel(m.Metric, "namespace_name", "namespace"),
 			NodeName:      m.Metric["node_name"],
-			PodName:       m.Metric["pod_name"],
+			PodName:       firstNonEmptyLabel(m.Metric, "pod_name", "pod"),
 			ContainerName: m.Metric["container_name"],
 			GPUUID:        m.Metric["uuid"],
 			Id:            m.Metric["minor_number"],
 			AllocateMode:  m.Metric["allocate_mode"],
 			Value:         m.Value[1].(string),
 			Time:          m.Value[0].(float64),
+			Labels:        m.Metric,
 		})
 	}
 	return gpuMetric, nil
 }
 
+// firstNonEmptyLabel returns the value of first label which is not empty
+func firstNonEmptyLabel(labels map[string]string, keys ...string) string {
+	for _, key := range keys {
+		if labels[key] != "" {
+			return labels[key]
+		}
+	}
+	return ""
+}
+
 func prometheusInstalled(client *kubernetes.Clientset) bool {
 	server := getPrometheusServer(client)
 	if server == nil {
@@ -0,0 +1,285 @@
+package prometheus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kubeflow/arena/pkg/apis/types"
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+	log "github.com/sirupsen/logrus"
+	"k8s.io/client-go/kubernetes"
+)
+
+// gpuMetricSeries is the samples of a metric returned by the range query
+type gpuMetricSeries struct {
+	info   types.GpuMetricInfo
+	times  []float64
+	values []float64
+}
+
+// PodKey returns the key of pod in the gpu history, the pods in different namespaces may share the name
+func PodKey(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+// GetPodsGpuHistory returns the gpu metric samples of pods in the namespaces in the time range, the pod names
+// can be regular expressions, the key of map is PodKey and the key of inner map is gpu id
+func GetPodsGpuHistory(client *kubernetes.Clientset, namespaces, podNames []string, start, end time.Time, step time.Duration) (map[string]map[string]*types.GpuMetricSamples, error) {
+	profile := GetGPUMetricsProfile(client)
+	matchers := []string{}
+	if profile.Labels.Namespace != "" {
+		matchers = append(matchers, fmt.Sprintf(`%s=~"%s"`, profile.Labels.Namespace, strings.Join(namespaces, "|")))
+	}
+	query := gpuMetricsQuery(profile, profile.Labels.Pod, podNames, matchers...)
+	series, err := queryPrometheusRangeMetrics(client, query, start, end, step)
+	if err != nil {
+		return nil, err
+	}
+	scale := gpuMemoryScale(profile.MemoryUnit)
+	history := map[string]map[string]*types.GpuMetricSamples{}
+	getSamples := func(info types.GpuMetricInfo) *types.GpuMetricSamples {
+		key := PodKey(info.PodNamespace, info.PodName)
+		if history[key] == nil {
+			history[key] = map[string]*types.GpuMetricSamples{}
+		}
+		if history[key][info.Id] == nil {
+			history[key][info.Id] = &types.GpuMetricSamples{}
+		}
+		return history[key][info.Id]
+	}
+	usedSeries := map[string]gpuMetricSeries{}
+	freeSeries := map[string]gpuMetricSeries{}
+	for _, s := range series {
+		applyGPUMetricsLabels(profile, &s.info)
+		if s.info.PodName == "" {
+			continue
+		}
+		// the profile without the namespace label can only tell the namespace if one namespace is queried
+		if s.info.PodNamespace == "" && len(namespaces) == 1 {
+			s.info.PodNamespace = namespaces[0]
+		}
+		key := PodKey(s.info.PodNamespace, s.info.PodName) + "/" + s.info.Id
+		switch s.info.MetricName {
+		case profile.DutyCycleMetric:
+			samples := getSamples(s.info)
+			samples.DutyCycle = append(samples.DutyCycle, s.values...)
+		case profile.MemoryUsedMetric:
+			s.values = scaleGPUMetricValues(s.values, scale)
+			samples := getSamples(s.info)
+			samples.MemoryUsed = append(samples.MemoryUsed, s.values...)
+			usedSeries[key] = s
+		case profile.MemoryTotalMetric:
+			samples := getSamples(s.info)
+			samples.MemoryTotal = append(samples.MemoryTotal, scaleGPUMetricValues(s.values, scale)...)
+		case profile.MemoryFreeMetric:
+			s.values = scaleGPUMetricValues(s.values, scale)
+			freeSeries[key] = s
+		}
+	}
+	if profile.MemoryTotalMetric != "" {
+		return history, nil
+	}
+	// the total gpu memory is the sum of used and free memory at the same time
+	for key, used := range usedSeries {
+		free, ok := freeSeries[key]
+		if !ok {
+			continue
+		}
+		freeValues := map[float64]float64{}
+		for i, t := range free.times {
+			freeValues[t] = free.values[i]
+		}
+		samples := getSamples(used.info)
+		for i, t := range used.times {
+			if v, ok := freeValues[t]; ok {
+				samples.MemoryTotal = append(samples.MemoryTotal, used.values[i]+v)
+			}
+		}
+	}
+	return history, nil
+}
+
+// queryPrometheusRangeMetrics queries the samples of metrics in the time range
+func queryPrometheusRangeMetrics(client *kubernetes.Clientset, query string, start, end time.Time, step time.Duration) ([]gpuMetricSeries, error) {
+	v1api := GetPrometheusClient()
+	if v1api != nil {
+		return queryPrometheusRangeMetricsByAddress(v1api, query, start, end, step)
+	}
+	return queryPrometheusRangeMetricsProxyByAPIServer(client, query, start, end, step)
+}
+
+func queryPrometheusRangeMetricsByAddress(v1api promv1.API, query string, start, end time.Time, step time.Duration) ([]gpuMetricSeries, error) {
+	log.Debugf("the prom sql is %v, range: [%v, %v], step: %v", query, start, end, step)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	result, warnings, err := v1api.QueryRange(ctx, query, promv1.Range{Start: start, End: end, Step: step})
+	if err != nil {
+		log.Debugf("Error querying Prometheus by %v: %v\n", query, err)
+		return nil, err
+	}
+	if len(warnings) > 0 {
+		log.Debugf("Warnings: %v", warnings)
+	}
+	matrix, ok := result.(model.Matrix)
+	if !ok {
+		return nil, fmt.Errorf("failed to get metrics, unknown metric type %v,we want model.Matrix", result.Type())
+	}
+	series := []gpuMetricSeries{}
+	for _, stream := range matrix {
+		labels := map[string]string{}
+		for key, value := range stream.Metric {
+			labels[string(key)] = string(value)
+		}
+		s := gpuMetricSeries{info: gpuMetricInfoFromLabels(labels)}
+		for _, pair := range stream.Values {
+			s.times = append(s.times, float64(pair.Timestamp.Unix()))
+			s.values = append(s.values, float64(pair.Value))
+		}
+		series = append(series, s)
+	}
+	return series, nil
+}
+
+func queryPrometheusRangeMetricsProxyByAPIServer(client *kubernetes.Clientset, query string, start, end time.Time, step time.Duration) ([]gpuMetricSeries, error) {
+	series := []gpuMetricSeries{}
+	server := getPrometheusServer(client)
+	if server == nil {
+		log.Debugf("the prometheus is not installed,skip to get the gpu metrics")
+		return series, nil
+	}
+	log.Debugf("query: %v, range: [%v, %v], step: %v", query, start, end, step)
+	req := client.CoreV1().Services(server.Service.Namespace).ProxyGet(server.Protocol, server.Service.Name, server.Port, server.Path+"_range", map[string]string{
+		"query": query,
+		"start": strconv.FormatInt(start.Unix(), 10),
+		"end":   strconv.FormatInt(end.Unix(), 10),
+		"step":  strconv.FormatInt(int64(step.Seconds()), 10),
+	})
+	content, err := req.DoRaw(context.TODO())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query prometheus: %v %v", err, string(content))
+	}
+	var metricResponse *types.PrometheusMetric
+	if err := json.Unmarshal(content, &metricResponse); err != nil {
+		return nil, fmt.Errorf("failed to unmarshall prometheus response: %v", err)
+	}
+	if metricResponse.Status != "success" {
+		return nil, fmt.Errorf("failed to query prometheus, status: %s", metricResponse.Status)
+	}
+	for _, m := range metricResponse.Data.Result {
+		s := gpuMetricSeries{info: gpuMetricInfoFromLabels(m.Metric)}
+		for _, pair := range m.Values {
+			if len(pair) != 2 {
+				continue
+			}
+			t, ok1 := pair[0].(float64)
+			value, ok2 := pair[1].(string)
+			if !ok1 || !ok2 {
+				continue
+			}
+			v, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			s.times = append(s.times, t)
+			s.values = append(s.values, v)
+		}
+		series = append(series, s)
+	}
+	return series, nil
+}
+
+// gpuMetricInfoFromLabels builds the metric info from labels, the same as the instant query
+func gpuMetricInfoFromLabels(labels map[string]string) types.GpuMetricInfo {
+	return types.GpuMetricInfo{
+		MetricName:    labels["__name__"],
+		PodNamespace:  firstNonEmptyLabel(labels, "namespace_name", "namespace"),
+		NodeName:      labels["node_name"],
+		PodName:       firstNonEmptyLabel(labels, "pod_name", "pod"),
+		ContainerName: labels["container_name"],
+		GPUUID:        labels["uuid"],
+		Id:            labels["minor_number"],
+		AllocateMode:  labels["allocate_mode"],
+		Labels:        labels,
+	}
+}
+
+func scaleGPUMetricValues(values []float64, scale float64) []float64 {
+	if scale == 1 {
+		return values
+	}
+	scaled := make([]float64, len(values))
+	for i, v := range values {
+		scaled[i] = v * scale
+	}
+	return scaled
+}
+
+// SummarizeGPUMetric returns the average, 95th percentile and maximum of the samples
+func SummarizeGPUMetric(values []float64) types.GPUMetricStats {
+	stats := types.GPUMetricStats{}
+	if len(values) == 0 {
+		return stats
+	}
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+	total := float64(0)
+	for _, v := range sorted {
+		total += v
+	}
+	stats.Avg = total / float64(len(sorted))
+	stats.Max = sorted[len(sorted)-1]
+	// nearest-rank percentile
+	rank := int(0.95*float64(len(sorted))+0.5) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	stats.P95 = sorted[rank]
+	return stats
+}
+
+// DownsampleGPUMetric averages the samples into at most width buckets
+func DownsampleGPUMetric(values []float64, width int) []float64 {
+	if len(values) <= width {
+		return values
+	}
+	result := make([]float64, 0, width)
+	for i := 0; i < width; i++ {
+		from := i * len(values) / width
+		to := (i + 1) * len(values) / width
+		total := float64(0)
+		for _, v := range values[from:to] {
+			total += v
+		}
+		result = append(result, total/float64(to-from))
+	}
+	return result
+}
+
+// sparklineTicks are the characters from low to high
+var sparklineTicks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline renders the values in range [0,max] as a line of block characters
+func Sparkline(values []float64, max float64) string {
+	if max <= 0 {
+		return ""
+	}
+	var builder strings.Builder
+	for _, v := range values {
+		index := int(v / max * float64(len(sparklineTicks)-1))
+		if index < 0 {
+			index = 0
+		}
+		if index >= len(sparklineTicks) {
+			index = len(sparklineTicks) - 1
+		}
+		builder.WriteRune(sparklineTicks[index])
+	}
+	return builder.String()
+}
@@ -0,0 +1,398 @@
+package serving
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	kservev1beta1 "github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubeflow/arena/pkg/apis/config"
+	"github.com/kubeflow/arena/pkg/apis/types"
+	"github.com/kubeflow/arena/pkg/util"
+)
+
+const (
+	// maxServingRevisionHistory is the max count of revisions kept for a serving job
+	maxServingRevisionHistory = 10
+	servingHistoryLabelKey    = "servingHistory"
+	changeCauseInitial        = "initial"
+	changeCauseUpdate         = "update"
+	changeCauseRollback       = "rollback to revision %v"
+)
+
+// deploymentRevisionSpec is the part of deployment which can be restored by rollback
+type deploymentRevisionSpec struct {
+	Replicas *int32             `json:"replicas,omitempty"`
+	Template v1.PodTemplateSpec `json:"template"`
+}
+
+// GetServingJobHistory returns the revisions of the serving job, the oldest one is the first
+func GetServingJobHistory(namespace, name, version string, jobType types.ServingJobType) ([]*types.ServingRevision, error) {
+	job, err := SearchServingJob(namespace, name, version, jobType)
+	if err != nil {
+		return nil, err
+	}
+	return listServingRevisions(job.Namespace(), servingHistoryName(job.Name(), job.Version(), job.Type()))
+}
+
+// PrintServingJobHistory displays the revisions of serving job and the diffs between them
+func PrintServingJobHistory(revisions []*types.ServingRevision, format types.FormatStyle) {
+	infos := []types.ServingRevisionInfo{}
+	for i, r := range revisions {
+		info := types.ServingRevisionInfo{ServingRevision: *r, Diffs: []types.ServingRevisionDiff{}}
+		if i > 0 {
+			info.Diffs = diffServingRevisions(revisions[i-1], r)
+		}
+		infos = append(infos, info)
+	}
+	switch format {
+	case types.JsonFormat:
+		data, _ := json.MarshalIndent(infos, "", "    ")
+		fmt.Printf("%v", string(data))
+		return
+	case types.YamlFormat:
+		data, _ := yaml.Marshal(infos)
+		fmt.Printf("%v", string(data))
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	PrintLine(w, "REVISION", "VERSION", "CREATED", "CHANGE-CAUSE")
+	for _, info := range infos {
+		version := info.Version
+		if version == "" {
+			version = "N/A"
+		}
+		PrintLine(w,
+			fmt.Sprintf("%v", info.Revision),
+			version,
+			util.GetFormatTime(info.CreationTimestamp),
+			info.ChangeCause,
+		)
+		for _, d := range info.Diffs {
+			PrintLine(w, fmt.Sprintf("  %v: %v -> %v", d.Field, d.From, d.To))
+		}
+	}
+	_ = w.Flush()
+}
+
+// RollbackServingJob restores the spec of serving job with the target revision
+func RollbackServingJob(namespace, name, version string, jobType types.ServingJobType, toRevision int) error {
+	job, err := SearchServingJob(namespace, name, version, jobType)
+	if err != nil {
+		return err
+	}
+	historyName := servingHistoryName(job.Name(), job.Version(), job.Type())
+	revisions, err := listServingRevisions(job.Namespace(), historyName)
+	if err != nil {
+		return err
+	}
+	if len(revisions) == 0 {
+		return fmt.Errorf("no revision history found for serving job %v, only the jobs updated by 'arena serve update' have revisions", job.Name())
+	}
+	var target *types.ServingRevision
+	if toRevision <= 0 {
+		// rollback to the previous revision by default
+		if len(revisions) < 2 {
+			return fmt.Errorf("serving job %v has no previous revision", job.Name())
+		}
+		target = revisions[len(revisions)-2]
+	} else {
+		for _, r := range revisions {
+			if r.Revision == toRevision {
+				target = r
+				break
+			}
+		}
+	}
+	if target == nil {
+		return fmt.Errorf("not found revision %v of serving job %v, please check it with 'arena serve history %v'", toRevision, job.Name(), job.Name())
+	}
+	cause := fmt.Sprintf(changeCauseRollback, target.Revision)
+	if ksjob, ok := job.(*kserveJob); ok {
+		inferenceService := ksjob.inferenceService.DeepCopy()
+		predictor := kservev1beta1.PredictorSpec{}
+		if err := json.Unmarshal([]byte(target.Spec), &predictor); err != nil {
+			return fmt.Errorf("failed to parse spec of revision %v: %v", target.Revision, err)
+		}
+		inferenceService.Spec.Predictor = predictor
+		return updateInferenceServiceWithCause(job.Name(), job.Version(), inferenceService, cause)
+	}
+	if job.Deployment() == nil {
+		return fmt.Errorf("the serving job type %v does not support rollback", job.Type())
+	}
+	deploy := job.Deployment().DeepCopy()
+	spec := deploymentRevisionSpec{}
+	if err := json.Unmarshal([]byte(target.Spec), &spec); err != nil {
+		return fmt.Errorf("failed to parse spec of revision %v: %v", target.Revision, err)
+	}
+	deploy.Spec.Template = spec.Template
+	if spec.Replicas != nil {
+		deploy.Spec.Replicas = spec.Replicas
+	}
+	return updateDeploymentWithCause(job.Name(), job.Version(), deploy, cause)
+}
+
+// recordDeploymentRevision saves the deployment spec as a new revision,
+// the spec before updating is saved as the first revision if the history is empty
+func recordDeploymentRevision(previous, current *appsv1.Deployment, cause string) error {
+	labels := current.Labels
+	historyName := servingHistoryName(labels[servingNameLabelKey], labels[servingVersionLabelKey], types.ServingJobType(labels[servingTypeLabelKey]))
+	owner := metav1.OwnerReference{
+		APIVersion: "apps/v1",
+		Kind:       "Deployment",
+		Name:       current.Name,
+		UID:        current.UID,
+	}
+	prev, err := buildDeploymentRevision(previous, changeCauseInitial)
+	if err != nil {
+		return err
+	}
+	next, err := buildDeploymentRevision(current, cause)
+	if err != nil {
+		return err
+	}
+	return saveServingRevisions(current.Namespace, historyName, labels, owner, prev, next)
+}
+
+// recordInferenceServiceRevision saves the predictor spec of inference service as a new revision
+func recordInferenceServiceRevision(previous, current *kservev1beta1.InferenceService, cause string) error {
+	labels := current.Labels
+	historyName := servingHistoryName(labels[servingNameLabelKey], "", types.KServeJob)
+	owner := metav1.OwnerReference{
+		APIVersion: kservev1beta1.SchemeGroupVersion.String(),
+		Kind:       "InferenceService",
+		Name:       current.Name,
+		UID:        current.UID,
+	}
+	prev, err := buildInferenceServiceRevision(previous, changeCauseInitial)
+	if err != nil {
+		return err
+	}
+	next, err := buildInferenceServiceRevision(current, cause)
+	if err != nil {
+		return err
+	}
+	return saveServingRevisions(current.Namespace, historyName, labels, owner, prev, next)
+}
+
+func buildDeploymentRevision(deploy *appsv1.Deployment, cause string) (*types.ServingRevision, error) {
+	spec, err := json.Marshal(deploymentRevisionSpec{
+		Replicas: deploy.Spec.Replicas,
+		Template: deploy.Spec.Template,
+	})
+	if err != nil {
+		return nil, err
+	}
+	revision := &types.ServingRevision{
+		Version:     deploy.Labels[servingVersionLabelKey],
+		ChangeCause: cause,
+		Spec:        string(spec),
+	}
+	if deploy.Spec.Replicas != nil {
+		revision.Replicas = *deploy.Spec.Replicas
+	}
+	if len(deploy.Spec.Template.Spec.Containers) != 0 {
+		setRevisionContainer(revision, deploy.Spec.Template.Spec.Containers[0])
+	}
+	return revision, nil
+}
+
+func buildInferenceServiceRevision(inferenceService *kservev1beta1.InferenceService, cause string) (*types.ServingRevision, error) {
+	spec, err := json.Marshal(inferenceService.Spec.Predictor)
+	if err != nil {
+		return nil, err
+	}
+	revision := &types.ServingRevision{
+		ChangeCause: cause,
+		Spec:        string(spec),
+	}
+	if inferenceService.Spec.Predictor.MinReplicas != nil {
+		revision.Replicas = int32(*inferenceService.Spec.Predictor.MinReplicas)
+	}
+	if inferenceService.Spec.Predictor.Model != nil {
+		setRevisionContainer(revision, inferenceService.Spec.Predictor.Model.Container)
+		if inferenceService.Spec.Predictor.Model.StorageURI != nil {
+			revision.StorageUri = *inferenceService.Spec.Predictor.Model.StorageURI
+		}
+	} else if len(inferenceService.Spec.Predictor.Containers) != 0 {
+		setRevisionContainer(revision, inferenceService.Spec.Predictor.Containers[0])
+	}
+	return revision, nil
+}
+
+func setRevisionContainer(revision *types.ServingRevision, container v1.Container) {
+	revision.Image = container.Image
+	revision.Command = container.Command
+	revision.Args = container.Args
+	revision.Envs = map[string]string{}
+	for _, env := range container.Env {
+		revision.Envs[env.Name] = env.Value
+	}
+	revision.Resources = map[string]string{}
+	for name, quantity := range container.Resources.Limits {
+		revision.Resources[string(name)] = quantity.String()
+	}
+}
+
+// saveServingRevisions appends the revisions to history configmap,the first revision
+// is only saved when the history is empty
+func saveServingRevisions(namespace, historyName string, jobLabels map[string]string, owner metav1.OwnerReference, first, next *types.ServingRevision) error {
+	client := config.GetArenaConfiger().GetClientSet()
+	configmap, err := client.CoreV1().ConfigMaps(namespace).Get(context.TODO(), historyName, metav1.GetOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+	create := false
+	if k8serrors.IsNotFound(err) {
+		create = true
+		configmap = &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      historyName,
+				Namespace: namespace,
+				Labels: map[string]string{
+					"createdBy":            "arena",
+					servingHistoryLabelKey: "true",
+					servingNameLabelKey:    jobLabels[servingNameLabelKey],
+					servingTypeLabelKey:    jobLabels[servingTypeLabelKey],
+				},
+				OwnerReferences: []metav1.OwnerReference{owner},
+			},
+			Data: map[string]string{},
+		}
+		if jobLabels[types.UserNameIdLabel] != "" {
+			configmap.Labels[types.UserNameIdLabel] = jobLabels[types.UserNameIdLabel]
+		}
+	}
+	if configmap.Data == nil {
+		configmap.Data = map[string]string{}
+	}
+	revisions, err := parseServingRevisions(configmap.Data)
+	if err != nil {
+		return err
+	}
+	toSave := []*types.ServingRevision{}
+	if len(revisions) == 0 {
+		toSave = append(toSave, first)
+	}
+	toSave = append(toSave, next)
+	latest := 0
+	if len(revisions) != 0 {
+		latest = revisions[len(revisions)-1].Revision
+	}
+	for _, r := range toSave {
+		latest++
+		r.Revision = latest
+		r.CreationTimestamp = time.Now().Unix()
+		data, err := json.Marshal(r)
+		if err != nil {
+			return err
+		}
+		configmap.Data[strconv.Itoa(r.Revision)] = string(data)
+		revisions = append(revisions, r)
+	}
+	// drop the oldest revisions
+	for len(revisions) > maxServingRevisionHistory {
+		delete(configmap.Data, strconv.Itoa(revisions[0].Revision))
+		revisions = revisions[1:]
+	}
+	if create {
+		_, err = client.CoreV1().ConfigMaps(namespace).Create(context.TODO(), configmap, metav1.CreateOptions{})
+		return err
+	}
+	_, err = client.CoreV1().ConfigMaps(namespace).Update(context.TODO(), configmap, metav1.UpdateOptions{})
+	return err
+}
+
+func listServingRevisions(namespace, historyName string) ([]*types.ServingRevision, error) {
+	client := config.GetArenaConfiger().GetClientSet()
+	configmap, err := client.CoreV1().ConfigMaps(namespace).Get(context.TODO(), historyName, metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return []*types.ServingRevision{}, nil
+		}
+		return nil, err
+	}
+	return parseServingRevisions(configmap.Data)
+}
+
+func parseServingRevisions(data map[string]string) ([]*types.ServingRevision, error) {
+	revisions := []*types.ServingRevision{}
+	for key, value := range data {
+		revision := &types.ServingRevision{}
+		if err := json.Unmarshal([]byte(value), revision); err != nil {
+			log.Debugf("failed to parse revision %v,reason: %v", key, err)
+			continue
+		}
+		revisions = append(revisions, revision)
+	}
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].Revision < revisions[j].Revision
+	})
+	return revisions, nil
+}
+
+// servingHistoryName returns the name of configmap which stores the revisions,
+// kserve jobs have no fixed version,so the version is ignored
+func servingHistoryName(name, version string, jobType types.ServingJobType) string {
+	if jobType == types.KServeJob || version == "" {
+		return fmt.Sprintf("%v-%v-history", name, jobType)
+	}
+	return fmt.Sprintf("%v-%v-%v-history", name, version, jobType)
+}
+
+func diffServingRevisions(prev, cur *types.ServingRevision) []types.ServingRevisionDiff {
+	diffs := []types.ServingRevisionDiff{}
+	addDiff := func(field, from, to string) {
+		if from == to {
+			return
+		}
+		if from == "" {
+			from = "<none>"
+		}
+		if to == "" {
+			to = "<none>"
+		}
+		diffs = append(diffs, types.ServingRevisionDiff{Field: field, From: from, To: to})
+	}
+	addDiff("image", prev.Image, cur.Image)
+	addDiff("storageUri", prev.StorageUri, cur.StorageUri)
+	addDiff("command", strings.Join(prev.Command, " "), strings.Join(cur.Command, " "))
+	addDiff("args", strings.Join(prev.Args, " "), strings.Join(cur.Args, " "))
+	addDiff("replicas", fmt.Sprintf("%v", prev.Replicas), fmt.Sprintf("%v", cur.Replicas))
+	for _, key := range mergeMapKeys(prev.Envs, cur.Envs) {
+		addDiff("env."+key, prev.Envs[key], cur.Envs[key])
+	}
+	for _, key := range mergeMapKeys(prev.Resources, cur.Resources) {
+		addDiff("resources."+key, prev.Resources[key], cur.Resources[key])
+	}
+	return diffs
+}
+
+func mergeMapKeys(maps ...map[string]string) []string {
+	keys := []string{}
+	exist := map[string]bool{}
+	for _, m := range maps {
+		for k := range m {
+			if exist[k] {
+				continue
+			}
+			exist[k] = true
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
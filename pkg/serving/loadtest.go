@@ -0,0 +1,604 @@
+package serving
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/kubeflow/arena/pkg/apis/config"
+	"github.com/kubeflow/arena/pkg/apis/types"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/rand"
+)
+
+const (
+	// defaultLoadTestImage is the image which runs the load test in the job mode
+	defaultLoadTestImage = "fortio/fortio:latest"
+	// loadTestReportImage prints the json report which fortio writes into the shared volume,
+	// the fortio image has no shell
+	loadTestReportImage  = "busybox:1.36"
+	loadTestReportFile   = "report.json"
+	loadTestReportPath   = "/var/arena/loadtest"
+	loadTestLabelKey     = "servingLoadTest"
+	loadTestPayloadFile  = "payload.json"
+	loadTestPayloadPath  = "/etc/arena/loadtest"
+	loadTestPollInterval = 5 * time.Second
+	// loadTestJobTimeout is the extra time to wait for the load test job besides the duration
+	loadTestJobTimeout = 5 * time.Minute
+	// networkErrorCode counts the requests which failed without responses
+	networkErrorCode = "network"
+	// maxLoadTestQPS bounds the qps, the interval between two requests is computed in nanoseconds
+	maxLoadTestQPS = 100000
+)
+
+// loadTestBuckets are the upper bounds(ms) of the latency histogram
+var loadTestBuckets = []float64{1, 2, 5, 10, 20, 50, 100, 200, 500, 1000, 2000, 5000, 10000}
+
+type loadTestRecord struct {
+	// latency is the request latency in milliseconds
+	latency float64
+	// code is empty if the request succeeded
+	code string
+}
+
+// LoadTestServingJob sends requests to the serving job with the given qps and concurrency,
+// and returns the report of latency and errors
+func LoadTestServingJob(args *types.ServingLoadTestArgs) (*types.ServingLoadTestResult, error) {
+	if args.Concurrency <= 0 {
+		return nil, fmt.Errorf("the concurrency should be greater than 0")
+	}
+	if args.Duration <= 0 {
+		return nil, fmt.Errorf("the duration should be greater than 0")
+	}
+	if args.QPS < 0 {
+		return nil, fmt.Errorf("the qps should not be less than 0")
+	}
+	if args.QPS > maxLoadTestQPS {
+		return nil, fmt.Errorf("the qps should not be greater than %v", maxLoadTestQPS)
+	}
+	job, err := SearchServingJob(args.Namespace, args.Name, args.Version, args.Type)
+	if err != nil {
+		return nil, err
+	}
+	var result *types.ServingLoadTestResult
+	switch args.Mode {
+	case types.LocalLoadTestMode:
+		result, err = runLocalLoadTest(job, args)
+	case types.JobLoadTestMode:
+		result, err = runJobLoadTest(job, args)
+	default:
+		return nil, fmt.Errorf("unknown load test mode %v,only support: [%v|%v]", args.Mode, types.LocalLoadTestMode, types.JobLoadTestMode)
+	}
+	if err != nil {
+		return nil, err
+	}
+	result.Name = job.Name()
+	result.Namespace = job.Namespace()
+	result.Version = job.Version()
+	result.Type = string(job.Type())
+	result.Mode = args.Mode
+	result.QPS = args.QPS
+	result.Concurrency = args.Concurrency
+	return result, nil
+}
+
+// PrintLoadTestResult prints the report of a load test
+func PrintLoadTestResult(result *types.ServingLoadTestResult, format types.FormatStyle) {
+	switch format {
+	case types.JsonFormat:
+		data, _ := json.MarshalIndent(result, "", "    ")
+		fmt.Printf("%v", string(data))
+		return
+	case types.YamlFormat:
+		data, _ := yaml.Marshal(result)
+		fmt.Printf("%v", string(data))
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	PrintLine(w, "Name:", result.Name)
+	PrintLine(w, "Namespace:", result.Namespace)
+	PrintLine(w, "Type:", result.Type)
+	PrintLine(w, "Version:", result.Version)
+	PrintLine(w, "Mode:", string(result.Mode))
+	PrintLine(w, "URL:", result.URL)
+	PrintLine(w, "Duration:", fmt.Sprintf("%.2fs", result.Duration))
+	PrintLine(w, "Requests:", strconv.Itoa(result.Requests))
+	PrintLine(w, "Errors:", strconv.Itoa(result.Errors))
+	PrintLine(w, "Throughput:", fmt.Sprintf("%.2f req/s", result.Throughput))
+	PrintLine(w, "")
+	PrintLine(w, "Latency(ms):")
+	PrintLine(w, "  MIN", "MEAN", "P50", "P90", "P99", "MAX")
+	PrintLine(w, "  ---", "----", "---", "---", "---", "---")
+	latency := result.Latency
+	PrintLine(w,
+		fmt.Sprintf("  %.2f", latency.Min),
+		fmt.Sprintf("%.2f", latency.Mean),
+		fmt.Sprintf("%.2f", latency.P50),
+		fmt.Sprintf("%.2f", latency.P90),
+		fmt.Sprintf("%.2f", latency.P99),
+		fmt.Sprintf("%.2f", latency.Max),
+	)
+	if len(result.Histogram) != 0 {
+		PrintLine(w, "")
+		PrintLine(w, "Histogram:")
+		PrintLine(w, "  LATENCY(ms)", "COUNT", "PERCENT")
+		PrintLine(w, "  -----------", "-----", "-------")
+		lower := 0.0
+		for _, bucket := range result.Histogram {
+			bound := fmt.Sprintf("  %v - %v", lower, bucket.UpperBound)
+			if bucket.UpperBound == 0 {
+				bound = fmt.Sprintf("  > %v", lower)
+			}
+			percent := 0.0
+			if result.Requests != 0 {
+				percent = float64(bucket.Count) * 100 / float64(result.Requests)
+			}
+			PrintLine(w, bound, strconv.Itoa(bucket.Count), fmt.Sprintf("%.2f%%", percent))
+			lower = bucket.UpperBound
+		}
+	}
+	if len(result.ErrorCodes) != 0 {
+		codes := []string{}
+		for code := range result.ErrorCodes {
+			codes = append(codes, code)
+		}
+		sort.Strings(codes)
+		PrintLine(w, "")
+		PrintLine(w, "Error Codes:")
+		PrintLine(w, "  CODE", "COUNT")
+		PrintLine(w, "  ----", "-----")
+		for _, code := range codes {
+			PrintLine(w, "  "+code, strconv.Itoa(result.ErrorCodes[code]))
+		}
+	}
+	w.Flush()
+}
+
+// runLocalLoadTest sends requests from local through port-forwarding
+func runLocalLoadTest(job ServingJob, args *types.ServingLoadTestArgs) (*types.ServingLoadTestResult, error) {
+	target, err := resolveJobPredictTarget(job, &args.ServingPredictArgs)
+	if err != nil {
+		return nil, err
+	}
+	defer target.Close()
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConnsPerHost = args.Concurrency
+	client := &http.Client{
+		Timeout:   predictTimeout(&args.ServingPredictArgs),
+		Transport: transport,
+	}
+	log.Infof("start to send requests to %v for %v with concurrency %v", target.url, args.Duration, args.Concurrency)
+	begin := time.Now()
+	deadline := begin.Add(args.Duration)
+	// if qps is set,workers send a request when they receive a token
+	tokens := make(chan struct{}, args.Concurrency)
+	if args.QPS > 0 {
+		go func() {
+			defer close(tokens)
+			ticker := time.NewTicker(time.Second / time.Duration(args.QPS))
+			defer ticker.Stop()
+			for now := range ticker.C {
+				if now.After(deadline) {
+					return
+				}
+				select {
+				case tokens <- struct{}{}:
+				case <-time.After(time.Until(deadline)):
+					return
+				}
+			}
+		}()
+	}
+	records := make([][]loadTestRecord, args.Concurrency)
+	wg := sync.WaitGroup{}
+	for i := 0; i < args.Concurrency; i++ {
+		wg.Add(1)
+		go func(index int) {
+			defer wg.Done()
+			for {
+				if args.QPS > 0 {
+					if _, ok := <-tokens; !ok {
+						return
+					}
+				}
+				// the buffered tokens are not used after the deadline
+				if time.Now().After(deadline) {
+					return
+				}
+				start := time.Now()
+				record := loadTestRecord{}
+				result, err := sendPredictRequest(client, target.url, args.Data, args.Headers)
+				if err != nil {
+					log.Debugf("failed to send request: %v", err)
+					record.code = networkErrorCode
+					record.latency = float64(time.Since(start).Microseconds()) / 1000
+				} else {
+					record.latency = result.Latency
+					if result.StatusCode < http.StatusOK || result.StatusCode >= http.StatusMultipleChoices {
+						record.code = strconv.Itoa(result.StatusCode)
+					}
+				}
+				records[index] = append(records[index], record)
+			}
+		}(i)
+	}
+	wg.Wait()
+	all := []loadTestRecord{}
+	for _, r := range records {
+		all = append(all, r...)
+	}
+	return summarizeLoadTest(target.url, all, time.Since(begin)), nil
+}
+
+func summarizeLoadTest(url string, records []loadTestRecord, elapsed time.Duration) *types.ServingLoadTestResult {
+	result := &types.ServingLoadTestResult{
+		URL:        url,
+		Duration:   elapsed.Seconds(),
+		Requests:   len(records),
+		ErrorCodes: map[string]int{},
+	}
+	if len(records) == 0 {
+		return result
+	}
+	latencies := []float64{}
+	sum := 0.0
+	counts := make([]int, len(loadTestBuckets)+1)
+	for _, r := range records {
+		if r.code != "" {
+			result.Errors++
+			result.ErrorCodes[r.code]++
+		}
+		latencies = append(latencies, r.latency)
+		sum += r.latency
+		counts[loadTestBucketIndex(r.latency)]++
+	}
+	sort.Float64s(latencies)
+	result.Throughput = float64(len(records)) / elapsed.Seconds()
+	result.Latency = types.ServingLatencyStats{
+		Min:  latencies[0],
+		Mean: sum / float64(len(latencies)),
+		Max:  latencies[len(latencies)-1],
+		P50:  latencyPercentile(latencies, 50),
+		P90:  latencyPercentile(latencies, 90),
+		P99:  latencyPercentile(latencies, 99),
+	}
+	result.Histogram = buildLatencyHistogram(counts)
+	return result
+}
+
+// latencyPercentile returns the percentile of sorted latencies by nearest rank
+func latencyPercentile(sorted []float64, percentile float64) float64 {
+	index := int(math.Ceil(percentile/100*float64(len(sorted)))) - 1
+	if index < 0 {
+		index = 0
+	}
+	return sorted[index]
+}
+
+func loadTestBucketIndex(latency float64) int {
+	for i, bound := range loadTestBuckets {
+		if latency <= bound {
+			return i
+		}
+	}
+	return len(loadTestBuckets)
+}
+
+// buildLatencyHistogram converts the bucket counts to histogram,
+// the buckets before the first non-empty one and after the last non-empty one are skipped
+func buildLatencyHistogram(counts []int) []types.ServingLatencyBucket {
+	first, last := -1, -1
+	for i, c := range counts {
+		if c == 0 {
+			continue
+		}
+		if first == -1 {
+			first = i
+		}
+		last = i
+	}
+	histogram := []types.ServingLatencyBucket{}
+	if first == -1 {
+		return histogram
+	}
+	for i := first; i <= last; i++ {
+		bucket := types.ServingLatencyBucket{Count: counts[i]}
+		if i < len(loadTestBuckets) {
+			bucket.UpperBound = loadTestBuckets[i]
+		}
+		histogram = append(histogram, bucket)
+	}
+	return histogram
+}
+
+// runJobLoadTest creates a job in the cluster to send requests to the service of serving job,
+// waits for it to finish and parses the report from its logs
+func runJobLoadTest(job ServingJob, args *types.ServingLoadTestArgs) (*types.ServingLoadTestResult, error) {
+	url, err := buildInClusterPredictURL(job, &args.ServingPredictArgs)
+	if err != nil {
+		return nil, err
+	}
+	ctx := context.TODO()
+	clientset := config.GetArenaConfiger().GetClientSet()
+	namespace := job.Namespace()
+	name := fmt.Sprintf("%v-loadtest-%v", job.Name(), rand.String(5))
+	labels := map[string]string{
+		"createdBy":         "arena",
+		servingNameLabelKey: job.Name(),
+		servingTypeLabelKey: string(job.Type()),
+		loadTestLabelKey:    "true",
+	}
+	configMap := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Data: map[string]string{
+			loadTestPayloadFile: string(args.Data),
+		},
+	}
+	if _, err := clientset.CoreV1().ConfigMaps(namespace).Create(ctx, configMap, metav1.CreateOptions{}); err != nil {
+		return nil, fmt.Errorf("failed to create the payload configmap of load test: %v", err)
+	}
+	defer func() {
+		if err := clientset.CoreV1().ConfigMaps(namespace).Delete(ctx, name, metav1.DeleteOptions{}); err != nil {
+			log.Warnf("failed to delete configmap %v/%v: %v", namespace, name, err)
+		}
+	}()
+	image := args.Image
+	if image == "" {
+		image = defaultLoadTestImage
+	}
+	command := []string{
+		"fortio", "load",
+		"-qps", strconv.Itoa(args.QPS),
+		"-c", strconv.Itoa(args.Concurrency),
+		"-t", args.Duration.String(),
+		"-p", "50,90,99",
+		"-timeout", predictTimeout(&args.ServingPredictArgs).String(),
+		"-content-type", "application/json",
+		"-payload-file", fmt.Sprintf("%v/%v", loadTestPayloadPath, loadTestPayloadFile),
+	}
+	for k, v := range args.Headers {
+		command = append(command, "-H", fmt.Sprintf("%v: %v", k, v))
+	}
+	command = append(command, "-json", fmt.Sprintf("%v/%v", loadTestReportPath, loadTestReportFile), url)
+	backoffLimit := int32(0)
+	loadTestJob := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: v1.PodSpec{
+					RestartPolicy: v1.RestartPolicyNever,
+					// fortio runs in the init container and writes the report into the shared volume,
+					// then the report container prints the report as its logs
+					InitContainers: []v1.Container{
+						{
+							Name:    "loadtest",
+							Image:   image,
+							Command: command,
+							VolumeMounts: []v1.VolumeMount{
+								{Name: "payload", MountPath: loadTestPayloadPath},
+								{Name: "report", MountPath: loadTestReportPath},
+							},
+						},
+					},
+					Containers: []v1.Container{
+						{
+							Name:    "report",
+							Image:   loadTestReportImage,
+							Command: []string{"cat", fmt.Sprintf("%v/%v", loadTestReportPath, loadTestReportFile)},
+							VolumeMounts: []v1.VolumeMount{
+								{Name: "report", MountPath: loadTestReportPath},
+							},
+						},
+					},
+					Volumes: []v1.Volume{
+						{
+							Name: "payload",
+							VolumeSource: v1.VolumeSource{
+								ConfigMap: &v1.ConfigMapVolumeSource{
+									LocalObjectReference: v1.LocalObjectReference{Name: name},
+								},
+							},
+						},
+						{
+							Name: "report",
+							VolumeSource: v1.VolumeSource{
+								EmptyDir: &v1.EmptyDirVolumeSource{},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	if _, err := clientset.BatchV1().Jobs(namespace).Create(ctx, loadTestJob, metav1.CreateOptions{}); err != nil {
+		return nil, fmt.Errorf("failed to create load test job: %v", err)
+	}
+	defer func() {
+		propagation := metav1.DeletePropagationBackground
+		if err := clientset.BatchV1().Jobs(namespace).Delete(ctx, name, metav1.DeleteOptions{PropagationPolicy: &propagation}); err != nil {
+			log.Warnf("failed to delete load test job %v/%v: %v", namespace, name, err)
+		}
+	}()
+	log.Infof("the load test job %v/%v has been created,it sends requests to %v for %v", namespace, name, url, args.Duration)
+	succeeded, err := waitLoadTestJob(namespace, name, args.Duration+loadTestJobTimeout)
+	if err != nil {
+		return nil, err
+	}
+	pods, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: fmt.Sprintf("job-name=%v", name)})
+	if err != nil {
+		return nil, err
+	}
+	if len(pods.Items) == 0 {
+		return nil, fmt.Errorf("not found the pod of load test job %v/%v", namespace, name)
+	}
+	if !succeeded {
+		logs, err := clientset.CoreV1().Pods(namespace).GetLogs(pods.Items[0].Name, &v1.PodLogOptions{Container: "loadtest"}).DoRaw(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("the load test job %v/%v failed, and failed to get its logs: %v", namespace, name, err)
+		}
+		return nil, fmt.Errorf("the load test job %v/%v failed, logs:\n%v", namespace, name, string(logs))
+	}
+	report, err := clientset.CoreV1().Pods(namespace).GetLogs(pods.Items[0].Name, &v1.PodLogOptions{Container: "report"}).DoRaw(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the report of load test job %v/%v: %v", namespace, name, err)
+	}
+	return parseFortioReport(string(report))
+}
+
+func waitLoadTestJob(namespace, name string, timeout time.Duration) (bool, error) {
+	clientset := config.GetArenaConfiger().GetClientSet()
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		job, err := clientset.BatchV1().Jobs(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		if job.Status.Succeeded > 0 {
+			return true, nil
+		}
+		if job.Status.Failed > 0 {
+			return false, nil
+		}
+		time.Sleep(loadTestPollInterval)
+	}
+	return false, fmt.Errorf("timeout to wait for the load test job %v/%v", namespace, name)
+}
+
+// buildInClusterPredictURL returns the url of serving job service which can be accessed in the cluster
+func buildInClusterPredictURL(job ServingJob, args *types.ServingPredictArgs) (string, error) {
+	path, err := buildJobPredictPath(job, args)
+	if err != nil {
+		return "", err
+	}
+	if args.Endpoint != "" {
+		return normalizeEndpoint(args.Endpoint) + path, nil
+	}
+	pod, err := pickPredictInstance(job, args.Instance)
+	if err != nil {
+		return "", err
+	}
+	port := args.Port
+	if port == 0 {
+		port = detectPredictPort(job, pod)
+	}
+	portName := ""
+	for _, c := range pod.Spec.Containers {
+		for _, p := range c.Ports {
+			if int(p.ContainerPort) == port {
+				portName = p.Name
+			}
+		}
+	}
+	for _, svc := range job.Services() {
+		for _, p := range svc.Spec.Ports {
+			matched := false
+			switch {
+			case p.TargetPort.Type == intstr.String:
+				matched = portName != "" && p.TargetPort.StrVal == portName
+			case p.TargetPort.IntValue() == 0:
+				matched = int(p.Port) == port
+			default:
+				matched = p.TargetPort.IntValue() == port
+			}
+			if matched {
+				return fmt.Sprintf("http://%v.%v:%v%v", svc.Name, svc.Namespace, p.Port, path), nil
+			}
+		}
+	}
+	return "", fmt.Errorf("not found the service of serving job %v which exposes port %v,please use '--endpoint' to specify the address", job.Name(), port)
+}
+
+// fortioReport is the json report of fortio,the durations are in seconds
+type fortioReport struct {
+	URL               string         `json:"URL"`
+	ActualQPS         float64        `json:"ActualQPS"`
+	ActualDuration    int64          `json:"ActualDuration"`
+	RetCodes          map[string]int `json:"RetCodes"`
+	DurationHistogram struct {
+		Count int     `json:"Count"`
+		Min   float64 `json:"Min"`
+		Max   float64 `json:"Max"`
+		Avg   float64 `json:"Avg"`
+		Data  []struct {
+			End   float64 `json:"End"`
+			Count int     `json:"Count"`
+		} `json:"Data"`
+		Percentiles []struct {
+			Percentile float64 `json:"Percentile"`
+			Value      float64 `json:"Value"`
+		} `json:"Percentiles"`
+	} `json:"DurationHistogram"`
+}
+
+// parseFortioReport parses the json report which fortio writes into the report file
+func parseFortioReport(data string) (*types.ServingLoadTestResult, error) {
+	report := &fortioReport{}
+	if err := json.Unmarshal([]byte(data), report); err != nil {
+		return nil, fmt.Errorf("failed to parse the report of load test job: %v", err)
+	}
+	histogram := report.DurationHistogram
+	result := &types.ServingLoadTestResult{
+		URL:        report.URL,
+		Duration:   time.Duration(report.ActualDuration).Seconds(),
+		Requests:   histogram.Count,
+		Throughput: report.ActualQPS,
+		ErrorCodes: map[string]int{},
+		Latency: types.ServingLatencyStats{
+			Min:  histogram.Min * 1000,
+			Mean: histogram.Avg * 1000,
+			Max:  histogram.Max * 1000,
+		},
+	}
+	for code, count := range report.RetCodes {
+		if code == strconv.Itoa(http.StatusOK) {
+			continue
+		}
+		// fortio uses -1 as the code of requests which failed without responses
+		if code == "-1" {
+			code = networkErrorCode
+		}
+		result.Errors += count
+		result.ErrorCodes[code] += count
+	}
+	for _, p := range histogram.Percentiles {
+		switch p.Percentile {
+		case 50:
+			result.Latency.P50 = p.Value * 1000
+		case 90:
+			result.Latency.P90 = p.Value * 1000
+		case 99:
+			result.Latency.P99 = p.Value * 1000
+		}
+	}
+	counts := make([]int, len(loadTestBuckets)+1)
+	for _, d := range histogram.Data {
+		counts[loadTestBucketIndex(d.End*1000)] += d.Count
+	}
+	result.Histogram = buildLatencyHistogram(counts)
+	return result, nil
+}
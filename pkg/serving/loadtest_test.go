@@ -0,0 +1,108 @@
+package serving
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLatencyPercentile(t *testing.T) {
+	sorted := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	tc := []struct {
+		Percentile float64
+		Expected   float64
+	}{
+		{Percentile: 0, Expected: 1},
+		{Percentile: 10, Expected: 1},
+		{Percentile: 50, Expected: 5},
+		{Percentile: 90, Expected: 9},
+		{Percentile: 99, Expected: 10},
+		{Percentile: 100, Expected: 10},
+	}
+	for _, c := range tc {
+		actual := latencyPercentile(sorted, c.Percentile)
+		if actual != c.Expected {
+			t.Errorf("P%v: Expected %v; Got %v", c.Percentile, c.Expected, actual)
+		}
+	}
+	if actual := latencyPercentile([]float64{7}, 99); actual != 7 {
+		t.Errorf("P99 of one sample: Expected 7; Got %v", actual)
+	}
+}
+
+func TestSummarizeLoadTest(t *testing.T) {
+	records := []loadTestRecord{
+		{latency: 3},
+		{latency: 1},
+		{latency: 8, code: "503"},
+		{latency: 30, code: networkErrorCode},
+	}
+	result := summarizeLoadTest("http://svc:8080", records, 2*time.Second)
+	if result.Requests != 4 || result.Errors != 2 {
+		t.Errorf("Expected 4 requests and 2 errors; Got %v requests and %v errors", result.Requests, result.Errors)
+	}
+	if result.ErrorCodes["503"] != 1 || result.ErrorCodes[networkErrorCode] != 1 {
+		t.Errorf("Expected one 503 error and one network error; Got %v", result.ErrorCodes)
+	}
+	if result.Throughput != 2 {
+		t.Errorf("Expected throughput 2; Got %v", result.Throughput)
+	}
+	latency := result.Latency
+	if latency.Min != 1 || latency.Max != 30 || latency.Mean != 10.5 || latency.P50 != 3 || latency.P99 != 30 {
+		t.Errorf("Expected min 1, max 30, mean 10.5, p50 3 and p99 30; Got %+v", latency)
+	}
+	// the buckets (0,1],(1,2],(2,5],(5,10],(10,20],(20,50] are kept
+	total := 0
+	for _, b := range result.Histogram {
+		total += b.Count
+	}
+	if len(result.Histogram) != 6 || total != 4 {
+		t.Errorf("Expected 6 buckets with 4 requests; Got %+v", result.Histogram)
+	}
+
+	empty := summarizeLoadTest("http://svc:8080", nil, time.Second)
+	if empty.Requests != 0 || empty.Throughput != 0 {
+		t.Errorf("Expected an empty result; Got %+v", empty)
+	}
+}
+
+func TestParseFortioReport(t *testing.T) {
+	report := `{
+  "URL": "http://svc.default:8080/v1/models/mnist:predict",
+  "ActualQPS": 10,
+  "ActualDuration": 10000000000,
+  "RetCodes": {"200": 97, "503": 2, "-1": 1},
+  "DurationHistogram": {
+    "Count": 100,
+    "Min": 0.001,
+    "Max": 0.05,
+    "Avg": 0.004,
+    "Data": [{"End": 0.002, "Count": 60}, {"End": 0.005, "Count": 39}, {"End": 0.05, "Count": 1}],
+    "Percentiles": [{"Percentile": 50, "Value": 0.002}, {"Percentile": 90, "Value": 0.004}, {"Percentile": 99, "Value": 0.02}]
+  }
+}`
+	result, err := parseFortioReport(report)
+	if err != nil {
+		t.Fatalf("failed to parse the fortio report: %v", err)
+	}
+	if result.Requests != 100 || result.Throughput != 10 || result.Duration != 10 {
+		t.Errorf("Expected 100 requests in 10s with throughput 10; Got %+v", result)
+	}
+	if result.Errors != 3 || result.ErrorCodes["503"] != 2 || result.ErrorCodes[networkErrorCode] != 1 {
+		t.Errorf("Expected two 503 errors and one network error; Got %v", result.ErrorCodes)
+	}
+	latency := result.Latency
+	if latency.Min != 1 || latency.Max != 50 || latency.Mean != 4 || latency.P50 != 2 || latency.P90 != 4 || latency.P99 != 20 {
+		t.Errorf("Expected the latency in milliseconds; Got %+v", latency)
+	}
+	total := 0
+	for _, b := range result.Histogram {
+		total += b.Count
+	}
+	if total != 100 {
+		t.Errorf("Expected 100 requests in histogram; Got %+v", result.Histogram)
+	}
+
+	if _, err := parseFortioReport(""); err == nil {
+		t.Errorf("Expected an error for the empty report")
+	}
+}
@@ -0,0 +1,335 @@
+package serving
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kubeflow/arena/pkg/apis/types"
+	"github.com/kubeflow/arena/pkg/util/kubectl"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	// defaultPredictTimeout is the default timeout(seconds) of a predict request
+	defaultPredictTimeout = 60
+	// seldonExecutorContainerName is the seldon executor which accepts the requests
+	seldonExecutorContainerName = "seldon-container-engine"
+	// kserveContainerName is the container name of kserve predictor
+	kserveContainerName = "kserve-container"
+	// kserveDefaultHttpPort is the default http port of kserve predictor
+	kserveDefaultHttpPort = 8080
+)
+
+// predictPortNames are the container port names which serve http requests, ordered by priority
+var predictPortNames = []string{"http-serving", "restful", "http", "user-port", "http1"}
+
+// predictTarget is the resolved address of a serving job which accepts predict requests
+type predictTarget struct {
+	url       string
+	forwarder *kubectl.PortForwarder
+}
+
+func (t *predictTarget) Close() {
+	if t.forwarder != nil {
+		t.forwarder.Close()
+	}
+}
+
+// PredictServingJob sends a predict request to the serving job and returns the response
+func PredictServingJob(args *types.ServingPredictArgs) (*types.ServingPredictResult, error) {
+	target, err := resolvePredictTarget(args)
+	if err != nil {
+		return nil, err
+	}
+	defer target.Close()
+	client := &http.Client{Timeout: predictTimeout(args)}
+	return sendPredictRequest(client, target.url, args.Data, args.Headers)
+}
+
+// PrintPredictResult prints the result of a predict request
+func PrintPredictResult(result *types.ServingPredictResult, format types.FormatStyle) {
+	switch format {
+	case types.JsonFormat:
+		data, _ := json.MarshalIndent(result, "", "    ")
+		fmt.Printf("%v", string(data))
+		return
+	case types.YamlFormat:
+		data, _ := yaml.Marshal(result)
+		fmt.Printf("%v", string(data))
+		return
+	}
+	body := result.Body
+	var out bytes.Buffer
+	if err := json.Indent(&out, []byte(body), "", "  "); err == nil {
+		body = out.String()
+	}
+	fmt.Printf("URL:       %v\n", result.URL)
+	fmt.Printf("Status:    %v\n", result.Status)
+	fmt.Printf("Latency:   %.2fms\n", result.Latency)
+	fmt.Printf("Response:\n%v\n", body)
+}
+
+// resolvePredictTarget finds the serving job and builds the url which accepts predict requests,
+// if the endpoint is not specified,a local port is forwarded to the serving instance
+func resolvePredictTarget(args *types.ServingPredictArgs) (*predictTarget, error) {
+	job, err := SearchServingJob(args.Namespace, args.Name, args.Version, args.Type)
+	if err != nil {
+		return nil, err
+	}
+	return resolveJobPredictTarget(job, args)
+}
+
+// buildJobPredictPath returns the request path of the serving job
+func buildJobPredictPath(job ServingJob, args *types.ServingPredictArgs) (string, error) {
+	var err error
+	protocol := args.Protocol
+	if protocol == types.UnknownPredictProtocol {
+		protocol = detectPredictProtocol(job)
+	}
+	path := args.Path
+	if path == "" {
+		if protocol == types.UnknownPredictProtocol {
+			return "", fmt.Errorf("failed to detect the predict protocol of serving job %v with type %v,please use '--protocol' or '--path' to specify it", job.Name(), job.Type())
+		}
+		modelName := args.ModelName
+		if modelName == "" {
+			modelName = detectModelName(job)
+		}
+		path, err = buildPredictPath(protocol, modelName)
+		if err != nil {
+			return "", err
+		}
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return path, nil
+}
+
+func resolveJobPredictTarget(job ServingJob, args *types.ServingPredictArgs) (*predictTarget, error) {
+	path, err := buildJobPredictPath(job, args)
+	if err != nil {
+		return nil, err
+	}
+	if args.Endpoint != "" {
+		return &predictTarget{url: normalizeEndpoint(args.Endpoint) + path}, nil
+	}
+	pod, err := pickPredictInstance(job, args.Instance)
+	if err != nil {
+		return nil, err
+	}
+	port := args.Port
+	if port == 0 {
+		port = detectPredictPort(job, pod)
+	}
+	if port == 0 {
+		return nil, fmt.Errorf("failed to detect the http port of instance %v,please use '--port' to specify it", pod.Name)
+	}
+	forwarder, err := kubectl.ForwardPodPort(pod.Namespace, pod.Name, port)
+	if err != nil {
+		return nil, err
+	}
+	return &predictTarget{
+		url:       fmt.Sprintf("http://127.0.0.1:%v%v", forwarder.LocalPort, path),
+		forwarder: forwarder,
+	}, nil
+}
+
+func normalizeEndpoint(endpoint string) string {
+	endpoint = strings.TrimSuffix(endpoint, "/")
+	if !strings.HasPrefix(endpoint, "http://") && !strings.HasPrefix(endpoint, "https://") {
+		endpoint = "http://" + endpoint
+	}
+	return endpoint
+}
+
+// sendPredictRequest posts the data to the url and records the latency
+func sendPredictRequest(client *http.Client, url string, data []byte, headers map[string]string) (*types.ServingPredictResult, error) {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send predict request to %v: %v", url, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read predict response from %v: %v", url, err)
+	}
+	return &types.ServingPredictResult{
+		URL:        url,
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Latency:    float64(time.Since(start).Microseconds()) / 1000,
+		Body:       string(body),
+	}, nil
+}
+
+func predictTimeout(args *types.ServingPredictArgs) time.Duration {
+	if args.Timeout <= 0 {
+		return defaultPredictTimeout * time.Second
+	}
+	return time.Duration(args.Timeout) * time.Second
+}
+
+func detectPredictProtocol(job ServingJob) types.ServingPredictProtocol {
+	switch job.Type() {
+	case types.TFServingJob, types.KFServingJob:
+		return types.V1PredictProtocol
+	case types.TritonServingJob, types.TRTServingJob:
+		return types.V2PredictProtocol
+	case types.SeldonServingJob:
+		return types.SeldonPredictProtocol
+	case types.TorchServeServingJob:
+		return types.TorchServePredictProtocol
+	case types.ONNXRuntimeServingJob:
+		return types.ONNXRuntimePredictProtocol
+	case types.KServeJob:
+		kjob, ok := job.(*kserveJob)
+		if !ok {
+			return types.V1PredictProtocol
+		}
+		predictor := kjob.inferenceService.Spec.Predictor
+		var protocolVersion *constants.InferenceServiceProtocol
+		if predictor.Model != nil {
+			protocolVersion = predictor.Model.ProtocolVersion
+		}
+		if protocolVersion != nil && *protocolVersion == constants.ProtocolV2 {
+			return types.V2PredictProtocol
+		}
+		return types.V1PredictProtocol
+	}
+	return types.UnknownPredictProtocol
+}
+
+// detectModelName returns the model name which is set by '--model_name' of tensorflow serving
+// or the first model of '--models' of torchserve,otherwise the job name is used
+func detectModelName(job ServingJob) string {
+	if (job.Type() == types.TFServingJob || job.Type() == types.TorchServeServingJob) && job.Deployment() != nil {
+		for _, c := range job.Deployment().Spec.Template.Spec.Containers {
+			items := []string{}
+			items = append(items, c.Command...)
+			items = append(items, c.Args...)
+			for _, item := range items {
+				fields := strings.Fields(item)
+				for i, field := range fields {
+					if strings.HasPrefix(field, "--model_name=") {
+						return strings.TrimPrefix(field, "--model_name=")
+					}
+					// torchserve models are specified as '--models <name>=<name>.mar ...'
+					if field == "--models" && i+1 < len(fields) && fields[i+1] != "all" {
+						return strings.SplitN(fields[i+1], "=", 2)[0]
+					}
+				}
+			}
+		}
+	}
+	return job.Name()
+}
+
+func buildPredictPath(protocol types.ServingPredictProtocol, modelName string) (string, error) {
+	switch protocol {
+	case types.V1PredictProtocol:
+		return fmt.Sprintf("/v1/models/%v:predict", modelName), nil
+	case types.V2PredictProtocol:
+		return fmt.Sprintf("/v2/models/%v/infer", modelName), nil
+	case types.SeldonPredictProtocol:
+		return "/api/v1.0/predictions", nil
+	case types.OpenAIPredictProtocol:
+		return "/v1/chat/completions", nil
+	case types.TorchServePredictProtocol:
+		return fmt.Sprintf("/predictions/%v", modelName), nil
+	case types.ONNXRuntimePredictProtocol:
+		return fmt.Sprintf("/v1/models/%v/versions/1:predict", modelName), nil
+	}
+	return "", fmt.Errorf("unknown predict protocol %v,only support: [%v|%v|%v|%v|%v|%v]", protocol,
+		types.V1PredictProtocol,
+		types.V2PredictProtocol,
+		types.SeldonPredictProtocol,
+		types.OpenAIPredictProtocol,
+		types.TorchServePredictProtocol,
+		types.ONNXRuntimePredictProtocol,
+	)
+}
+
+// pickPredictInstance returns the target instance,if the instance is not specified,
+// the first running and ready instance is picked
+func pickPredictInstance(job ServingJob, instance string) (*v1.Pod, error) {
+	for _, pod := range job.Pods() {
+		if instance != "" {
+			if pod.Name == instance {
+				return pod, nil
+			}
+			continue
+		}
+		if pod.Status.Phase == v1.PodRunning && isPodReady(pod) {
+			return pod, nil
+		}
+	}
+	if instance != "" {
+		return nil, fmt.Errorf("invalid instance name %v of serving job %v,please use 'arena serve get %v' to get instance names.", instance, job.Name(), job.Name())
+	}
+	return nil, fmt.Errorf("not found ready instances of serving job %v,please use 'arena serve get %v' to get job information", job.Name(), job.Name())
+}
+
+func isPodReady(pod *v1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == v1.PodReady {
+			return condition.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// detectPredictPort returns the container port which serves http requests
+func detectPredictPort(job ServingJob, pod *v1.Pod) int {
+	containers := pod.Spec.Containers
+	for _, c := range containers {
+		preferred := ""
+		switch job.Type() {
+		case types.SeldonServingJob:
+			preferred = seldonExecutorContainerName
+		case types.KServeJob:
+			preferred = kserveContainerName
+		}
+		if preferred != "" && c.Name == preferred && len(c.Ports) != 0 {
+			return portByNames(c.Ports, int(c.Ports[0].ContainerPort))
+		}
+	}
+	for _, c := range containers {
+		if port := portByNames(c.Ports, 0); port != 0 {
+			return port
+		}
+	}
+	if job.Type() == types.KServeJob {
+		return kserveDefaultHttpPort
+	}
+	log.Debugf("not found http port of pod %v", pod.Name)
+	return 0
+}
+
+func portByNames(ports []v1.ContainerPort, defaultPort int) int {
+	for _, name := range predictPortNames {
+		for _, p := range ports {
+			if p.Name == name {
+				return int(p.ContainerPort)
+			}
+		}
+	}
+	return defaultPort
+}
@@ -55,6 +55,8 @@ func GetAllProcesser() map[types.ServingJobType]Processer {
 			NewTensorrtServingProcesser,
 			NewSeldonServingProcesser,
 			NewTritonServingProcesser,
+			NewTorchServeProcesser,
+			NewONNXRuntimeServingProcesser,
 		}
 		var wg sync.WaitGroup
 		for _, initFunc := range processerInits {
@@ -229,16 +231,8 @@ func (s *servingJob) RequestCPUs() float64 {
 
 func (s *servingJob) RequestGPUs() float64 {
 	replicas := *s.deployment.Spec.Replicas
-	podGPUs := 0
-	for _, c := range s.deployment.Spec.Template.Spec.Containers {
-		if val, ok := c.Resources.Limits[v1.ResourceName(types.NvidiaGPUResourceName)]; ok {
-			podGPUs += int(val.Value())
-		}
-		if val, ok := c.Resources.Limits[v1.ResourceName(types.AliyunGPUResourceName)]; ok {
-			podGPUs += int(val.Value())
-		}
-	}
-	return float64(replicas * int32(podGPUs))
+	podGPUs := utils.GPUCountInContainers(s.deployment.Spec.Template.Spec.Containers)
+	return float64(int64(replicas) * podGPUs)
 }
 
 func (s *servingJob) RequestGPUMemory() int {
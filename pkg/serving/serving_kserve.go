@@ -16,6 +16,7 @@ import (
 
 	"github.com/kubeflow/arena/pkg/apis/config"
 	"github.com/kubeflow/arena/pkg/apis/types"
+	"github.com/kubeflow/arena/pkg/apis/utils"
 	"github.com/kubeflow/arena/pkg/util"
 	"github.com/kubeflow/arena/pkg/workflow"
 )
@@ -209,16 +210,8 @@ func (s *kserveJob) RequestGPUs() float64 {
 	var result float64
 	for _, dp := range s.inferenceDeployments {
 		replicas := *dp.Spec.Replicas
-		podGPUs := 0
-		for _, c := range dp.Spec.Template.Spec.Containers {
-			if val, ok := c.Resources.Limits[v1.ResourceName(types.NvidiaGPUResourceName)]; ok {
-				podGPUs += int(val.Value())
-			}
-			if val, ok := c.Resources.Limits[v1.ResourceName(types.AliyunGPUResourceName)]; ok {
-				podGPUs += int(val.Value())
-			}
-		}
-		result = result + float64(replicas*int32(podGPUs))
+		podGPUs := utils.GPUCountInContainers(dp.Spec.Template.Spec.Containers)
+		result = result + float64(int64(replicas)*podGPUs)
 	}
 	return result
 }
@@ -0,0 +1,53 @@
+package serving
+
+import (
+	"fmt"
+
+	"github.com/kubeflow/arena/pkg/apis/config"
+	"github.com/kubeflow/arena/pkg/apis/types"
+	"github.com/kubeflow/arena/pkg/util"
+	"github.com/kubeflow/arena/pkg/workflow"
+	log "github.com/sirupsen/logrus"
+)
+
+// ONNXRuntimeServingProcesser use the default processer
+type ONNXRuntimeServingProcesser struct {
+	*processer
+}
+
+func NewONNXRuntimeServingProcesser() Processer {
+	p := &processer{
+		processerType:   types.ONNXRuntimeServingJob,
+		client:          config.GetArenaConfiger().GetClientSet(),
+		enable:          true,
+		useIstioGateway: false,
+	}
+	return &ONNXRuntimeServingProcesser{
+		processer: p,
+	}
+}
+
+func SubmitONNXRuntimeServingJob(namespace string, args *types.ONNXRuntimeServingArgs) (err error) {
+	nameWithVersion := fmt.Sprintf("%v-%v", args.Name, args.Version)
+	args.Namespace = namespace
+	processers := GetAllProcesser()
+	processer, ok := processers[args.Type]
+	if !ok {
+		return fmt.Errorf("not found processer whose type is %v", args.Type)
+	}
+	jobs, err := processer.GetServingJobs(args.Namespace, args.Name, args.Version)
+	if err != nil {
+		return err
+	}
+	if err := ValidateJobsBeforeSubmiting(jobs, args.Name); err != nil {
+		return err
+	}
+	chart := util.GetChartsFolder() + "/onnxruntime"
+	err = workflow.SubmitJobByHelm(nameWithVersion, string(types.ONNXRuntimeServingJob), namespace, args, chart, args.HelmOptions...)
+	if err != nil {
+		return err
+	}
+	log.Infof("The Job %s has been submitted successfully", args.Name)
+	log.Infof("You can run `arena serve get %s --type %s -n %s` to check the job status", args.Name, args.Type, args.Namespace)
+	return nil
+}
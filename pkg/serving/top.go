@@ -0,0 +1,503 @@
+package serving
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/kubeflow/arena/pkg/apis/config"
+	"github.com/kubeflow/arena/pkg/apis/types"
+	"github.com/kubeflow/arena/pkg/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+	v1 "k8s.io/api/core/v1"
+)
+
+// servingMetricsPortNames are the container port names which expose the metrics of serving,
+// the custom serving names the port metrics and the tensorrt serving names it metrics-server
+var servingMetricsPortNames = []string{"metrics", "metrics-server"}
+
+const (
+	// servingMetricsSampleInterval is the interval between two scrapes which are used to compute the request rate
+	servingMetricsSampleInterval = 2 * time.Second
+)
+
+// servingRequestCounter defines the counters of a serving framework which record the requests and latency
+type servingRequestCounter struct {
+	requests     []string
+	latency      string
+	latencyCount string
+	// latencyUnit converts the latency to milliseconds
+	latencyUnit float64
+}
+
+var servingRequestCounters = []servingRequestCounter{
+	// triton inference server
+	{
+		requests:     []string{"nv_inference_request_success", "nv_inference_request_failure"},
+		latency:      "nv_inference_request_duration_us",
+		latencyCount: "nv_inference_request_success",
+		latencyUnit:  0.001,
+	},
+	// torchserve
+	{
+		requests:     []string{"ts_inference_requests_total"},
+		latency:      "ts_inference_latency_microseconds",
+		latencyCount: "ts_inference_requests_total",
+		latencyUnit:  0.001,
+	},
+}
+
+// requestLatencyMetricRegex matches the histograms or summaries which record the request latency,
+// eg: vllm:e2e_request_latency_seconds
+var requestLatencyMetricRegex = regexp.MustCompile(`(?i)(request|inference).*(latency|duration)|(latency|duration).*(request|inference)`)
+
+// servingRequestSnapshot is the accumulated request metrics at a time
+type servingRequestSnapshot struct {
+	requests     float64
+	latencySum   float64
+	latencyCount float64
+	time         time.Time
+}
+
+func TopServingJobs(namespace string, allNamespaces bool, name, version string, jobType types.ServingJobType, instanceName string, notStop bool, format types.FormatStyle) error {
+	if name == "" && notStop {
+		return fmt.Errorf("You must specify the job name when using `-r` flag")
+	}
+	if !notStop {
+		return topServingJobs(namespace, allNamespaces, name, version, jobType, instanceName, format)
+	}
+	for {
+		err := topServingJobs(namespace, allNamespaces, name, version, jobType, instanceName, format)
+		if err != nil {
+			log.Errorf("%v", err)
+		}
+		t := time.Now()
+
+		line := "------------------------------------------- %v ----------------------------------------------------"
+		fmt.Printf(line+"\n", t.Format("2006-01-02 15:04:05"))
+		time.Sleep(2 * time.Second)
+	}
+}
+
+func topServingJobs(namespace string, allNamespaces bool, name, version string, jobType types.ServingJobType, instanceName string, format types.FormatStyle) error {
+	if format == types.UnknownFormat {
+		return fmt.Errorf("Unknown output format,only support:[wide|json|yaml]")
+	}
+	jobs := []ServingJob{}
+	if name != "" {
+		job, err := SearchServingJob(namespace, name, version, jobType)
+		if err != nil {
+			return err
+		}
+		jobs = append(jobs, job)
+	} else {
+		allJobs, err := ListServingJobs(namespace, allNamespaces, jobType)
+		if err != nil {
+			return err
+		}
+		jobs = allJobs
+	}
+	topInfos := BuildServingJobTopInfos(jobs)
+	switch format {
+	case types.JsonFormat:
+		outBytes, err := json.MarshalIndent(topInfos, "", "    ")
+		if err != nil {
+			return err
+		}
+		fmt.Printf(string(outBytes))
+		return nil
+	case types.YamlFormat:
+		outBytes, err := yaml.Marshal(topInfos)
+		if err != nil {
+			return err
+		}
+		fmt.Printf(string(outBytes))
+		return nil
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	if name != "" {
+		displayServingJobsWithInstances(w, topInfos, instanceName)
+	} else {
+		displayServingJobsSummary(w, topInfos, allNamespaces)
+	}
+	_ = w.Flush()
+	return nil
+}
+
+// BuildServingJobTopInfos collects the resource usage from prometheus and the request metrics
+// from the metrics port of serving instances
+func BuildServingJobTopInfos(jobs []ServingJob) []types.ServingJobTopInfo {
+	client := config.GetArenaConfiger().GetClientSet()
+	podNames := []string{}
+	namespacePods := map[string][]string{}
+	pods := []*v1.Pod{}
+	for _, job := range jobs {
+		for _, pod := range job.Pods() {
+			podNames = append(podNames, pod.Name)
+			namespacePods[pod.Namespace] = append(namespacePods[pod.Namespace], pod.Name)
+			pods = append(pods, pod)
+		}
+	}
+	gpuMetrics := prometheus.JobGpuMetric{}
+	if len(podNames) != 0 {
+		metrics, err := prometheus.GetPodsGpuInfo(client, podNames)
+		if err != nil {
+			log.Debugf("failed to get gpu metrics of serving instances: %v", err)
+		} else {
+			gpuMetrics = metrics
+		}
+	}
+	resourceMetrics := map[string]*types.PodResourceMetric{}
+	for namespace, names := range namespacePods {
+		metrics, err := prometheus.GetPodsResourceMetrics(client, namespace, names)
+		if err != nil {
+			log.Debugf("failed to get resource metrics of serving instances in namespace %v: %v", namespace, err)
+			continue
+		}
+		for podName, m := range metrics {
+			resourceMetrics[fmt.Sprintf("%v/%v", namespace, podName)] = m
+		}
+	}
+	requestMetrics := collectServingRequestMetrics(pods)
+	topInfos := []types.ServingJobTopInfo{}
+	for _, job := range jobs {
+		jobInfo := job.Convert2JobInfo()
+		topInfo := types.ServingJobTopInfo{
+			Name:               jobInfo.Name,
+			Namespace:          jobInfo.Namespace,
+			Type:               jobInfo.Type,
+			Version:            jobInfo.Version,
+			Age:                jobInfo.Age,
+			DesiredInstances:   jobInfo.Desired,
+			AvailableInstances: jobInfo.Available,
+			RequestGPUs:        jobInfo.RequestGPUs,
+			Instances:          []types.ServingInstanceTopInfo{},
+		}
+		for _, instance := range jobInfo.Instances {
+			instanceInfo := types.ServingInstanceTopInfo{
+				Name:        instance.Name,
+				Status:      instance.Status,
+				NodeIP:      instance.NodeIP,
+				RequestGPUs: instance.RequestGPUs,
+				GPUMetrics:  map[string]types.GpuMetric{},
+			}
+			for gpuId, m := range gpuMetrics.GetPodMetrics(instance.Name) {
+				instanceInfo.GPUMetrics[gpuId] = *m
+			}
+			if m, ok := resourceMetrics[fmt.Sprintf("%v/%v", jobInfo.Namespace, instance.Name)]; ok {
+				instanceInfo.CPUUsage = m.CPUUsage
+				instanceInfo.MemoryUsage = m.MemoryUsage
+			}
+			if m, ok := requestMetrics[fmt.Sprintf("%v/%v", jobInfo.Namespace, instance.Name)]; ok {
+				instanceInfo.HasRequestMetrics = true
+				instanceInfo.QPS = m.QPS
+				instanceInfo.Latency = m.Latency
+			}
+			topInfo.Instances = append(topInfo.Instances, instanceInfo)
+		}
+		topInfos = append(topInfos, topInfo)
+	}
+	return topInfos
+}
+
+// collectServingRequestMetrics scrapes the metrics port of pods twice and computes the request rate and latency,
+// the key of returned map is 'namespace/pod_name'
+func collectServingRequestMetrics(pods []*v1.Pod) map[string]*types.ServingInstanceTopInfo {
+	result := map[string]*types.ServingInstanceTopInfo{}
+	first := map[string]*servingRequestSnapshot{}
+	for _, pod := range pods {
+		snapshot := scrapeServingRequestSnapshot(pod)
+		if snapshot != nil {
+			first[fmt.Sprintf("%v/%v", pod.Namespace, pod.Name)] = snapshot
+		}
+	}
+	if len(first) == 0 {
+		return result
+	}
+	time.Sleep(servingMetricsSampleInterval)
+	for _, pod := range pods {
+		key := fmt.Sprintf("%v/%v", pod.Namespace, pod.Name)
+		previous, ok := first[key]
+		if !ok {
+			continue
+		}
+		current := scrapeServingRequestSnapshot(pod)
+		if current == nil {
+			continue
+		}
+		info := &types.ServingInstanceTopInfo{}
+		elapsed := current.time.Sub(previous.time).Seconds()
+		if elapsed > 0 && current.requests >= previous.requests {
+			info.QPS = (current.requests - previous.requests) / elapsed
+		}
+		if count := current.latencyCount - previous.latencyCount; count > 0 {
+			info.Latency = (current.latencySum - previous.latencySum) / count
+		}
+		result[key] = info
+	}
+	return result
+}
+
+func isServingMetricsPort(name string) bool {
+	for _, n := range servingMetricsPortNames {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// scrapeServingRequestSnapshot fetches the metrics of pod through the api server proxy,
+// it returns nil if the pod has no metrics port or no known request metrics
+func scrapeServingRequestSnapshot(pod *v1.Pod) *servingRequestSnapshot {
+	port := 0
+	for _, c := range pod.Spec.Containers {
+		for _, p := range c.Ports {
+			if isServingMetricsPort(p.Name) {
+				port = int(p.ContainerPort)
+			}
+		}
+	}
+	if port == 0 || pod.Status.Phase != v1.PodRunning {
+		return nil
+	}
+	client := config.GetArenaConfiger().GetClientSet()
+	data, err := client.CoreV1().Pods(pod.Namespace).ProxyGet("http", pod.Name, strconv.Itoa(port), "metrics", nil).DoRaw(context.TODO())
+	if err != nil {
+		log.Debugf("failed to scrape metrics of pod %v/%v: %v", pod.Namespace, pod.Name, err)
+		return nil
+	}
+	parser := expfmt.TextParser{}
+	families, err := parser.TextToMetricFamilies(bytes.NewReader(data))
+	if err != nil {
+		log.Debugf("failed to parse metrics of pod %v/%v: %v", pod.Namespace, pod.Name, err)
+		return nil
+	}
+	snapshot := parseServingRequestSnapshot(families)
+	if snapshot != nil {
+		snapshot.time = time.Now()
+	}
+	return snapshot
+}
+
+func parseServingRequestSnapshot(families map[string]*dto.MetricFamily) *servingRequestSnapshot {
+	for _, counter := range servingRequestCounters {
+		if _, ok := families[counter.latencyCount]; !ok {
+			continue
+		}
+		snapshot := &servingRequestSnapshot{
+			latencySum:   sumMetricFamily(families[counter.latency]) * counter.latencyUnit,
+			latencyCount: sumMetricFamily(families[counter.latencyCount]),
+		}
+		for _, name := range counter.requests {
+			snapshot.requests += sumMetricFamily(families[name])
+		}
+		return snapshot
+	}
+	names := []string{}
+	for name := range families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		family := families[name]
+		if !requestLatencyMetricRegex.MatchString(name) {
+			continue
+		}
+		if family.GetType() != dto.MetricType_HISTOGRAM && family.GetType() != dto.MetricType_SUMMARY {
+			continue
+		}
+		snapshot := &servingRequestSnapshot{}
+		for _, m := range family.GetMetric() {
+			if h := m.GetHistogram(); h != nil {
+				snapshot.latencyCount += float64(h.GetSampleCount())
+				snapshot.latencySum += h.GetSampleSum()
+			}
+			if s := m.GetSummary(); s != nil {
+				snapshot.latencyCount += float64(s.GetSampleCount())
+				snapshot.latencySum += s.GetSampleSum()
+			}
+		}
+		snapshot.requests = snapshot.latencyCount
+		snapshot.latencySum = snapshot.latencySum * latencyUnitOfMetric(name)
+		return snapshot
+	}
+	return nil
+}
+
+// sumMetricFamily returns the sum of counters or gauges in the family
+func sumMetricFamily(family *dto.MetricFamily) float64 {
+	if family == nil {
+		return 0
+	}
+	total := float64(0)
+	for _, m := range family.GetMetric() {
+		if c := m.GetCounter(); c != nil {
+			total += c.GetValue()
+		}
+		if g := m.GetGauge(); g != nil {
+			total += g.GetValue()
+		}
+		if u := m.GetUntyped(); u != nil {
+			total += u.GetValue()
+		}
+	}
+	return total
+}
+
+// latencyUnitOfMetric returns the factor which converts the latency to milliseconds by the metric name
+func latencyUnitOfMetric(name string) float64 {
+	switch {
+	case strings.Contains(name, "microseconds") || strings.HasSuffix(name, "_us"):
+		return 0.001
+	case strings.Contains(name, "milliseconds") || strings.HasSuffix(name, "_ms"):
+		return 1
+	case strings.Contains(name, "seconds"):
+		return 1000
+	}
+	return 1
+}
+
+func displayServingJobsSummary(w *tabwriter.Writer, topInfos []types.ServingJobTopInfo, allNamespaces bool) {
+	header := []string{"NAME", "TYPE", "VERSION", "INSTANCES", "CPU", "MEMORY", "GPU(Requested)", "GPU(DutyCycle)", "GPU_MEMORY(Used/Total)", "QPS", "LATENCY(ms)"}
+	if allNamespaces {
+		header = append([]string{"NAMESPACE"}, header...)
+	}
+	PrintLine(w, header...)
+	totalRequestGPUs := float64(0)
+	for _, topInfo := range topInfos {
+		totalRequestGPUs += topInfo.RequestGPUs
+		cpu, memory, qps := float64(0), float64(0), float64(0)
+		latencyWeight, latencySum := float64(0), float64(0)
+		hasRequestMetrics := false
+		gpus := []types.GpuMetric{}
+		for _, instance := range topInfo.Instances {
+			cpu += instance.CPUUsage
+			memory += instance.MemoryUsage
+			for _, m := range instance.GPUMetrics {
+				gpus = append(gpus, m)
+			}
+			if instance.HasRequestMetrics {
+				hasRequestMetrics = true
+				qps += instance.QPS
+				latencySum += instance.Latency * instance.QPS
+				latencyWeight += instance.QPS
+			}
+		}
+		items := []string{
+			topInfo.Name,
+			topInfo.Type,
+			topInfo.Version,
+			fmt.Sprintf("%v/%v", topInfo.AvailableInstances, topInfo.DesiredInstances),
+			fmt.Sprintf("%.2f", cpu),
+			fmt.Sprintf("%.1fMiB", fromByteToMiB(memory)),
+			fmt.Sprintf("%v", topInfo.RequestGPUs),
+		}
+		items = append(items, formatGPUMetrics(gpus)...)
+		// the latency of job is weighted by the qps of instances
+		latency := float64(0)
+		if latencyWeight > 0 {
+			latency = latencySum / latencyWeight
+		}
+		items = append(items, formatRequestMetrics(hasRequestMetrics, qps, latency)...)
+		if allNamespaces {
+			items = append([]string{topInfo.Namespace}, items...)
+		}
+		PrintLine(w, items...)
+	}
+	PrintLine(w, "")
+	PrintLine(w, fmt.Sprintf("Total Requested GPUs of Serving Jobs: %v", totalRequestGPUs))
+}
+
+func displayServingJobsWithInstances(w *tabwriter.Writer, topInfos []types.ServingJobTopInfo, instanceName string) {
+	for _, topInfo := range topInfos {
+		PrintLine(w, "Name:", topInfo.Name)
+		PrintLine(w, "Namespace:", topInfo.Namespace)
+		PrintLine(w, "Type:", topInfo.Type)
+		PrintLine(w, "Version:", topInfo.Version)
+		PrintLine(w, "Desired:", strconv.Itoa(topInfo.DesiredInstances))
+		PrintLine(w, "Available:", strconv.Itoa(topInfo.AvailableInstances))
+		PrintLine(w, "Age:", topInfo.Age)
+		PrintLine(w, "")
+		PrintLine(w, "Instances:")
+		PrintLine(w, "  NAME", "STATUS", "NODE", "CPU", "MEMORY", "GPU(Requested)", "GPU(DeviceIndex)", "GPU(DutyCycle)", "GPU_MEMORY(Used/Total)", "QPS", "LATENCY(ms)")
+		PrintLine(w, "  ----", "------", "----", "---", "------", "--------------", "----------------", "--------------", "----------------------", "---", "-----------")
+		for _, instance := range topInfo.Instances {
+			if instanceName != "" && instanceName != instance.Name {
+				continue
+			}
+			requestMetrics := formatRequestMetrics(instance.HasRequestMetrics, instance.QPS, instance.Latency)
+			items := []string{
+				"  " + instance.Name,
+				instance.Status,
+				instance.NodeIP,
+				fmt.Sprintf("%.2f", instance.CPUUsage),
+				fmt.Sprintf("%.1fMiB", fromByteToMiB(instance.MemoryUsage)),
+				fmt.Sprintf("%v", instance.RequestGPUs),
+			}
+			if len(instance.GPUMetrics) == 0 {
+				items = append(items, "N/A", "N/A", "N/A")
+				PrintLine(w, append(items, requestMetrics...)...)
+				continue
+			}
+			gpuIds := []string{}
+			for gpuId := range instance.GPUMetrics {
+				gpuIds = append(gpuIds, gpuId)
+			}
+			sort.Strings(gpuIds)
+			for index, gpuId := range gpuIds {
+				if index != 0 {
+					items = []string{"", "", "", "", "", ""}
+					requestMetrics = []string{"", ""}
+				}
+				m := instance.GPUMetrics[gpuId]
+				gpuItems := []string{
+					gpuId,
+					fmt.Sprintf("%.1f%%", m.GpuDutyCycle),
+					fmt.Sprintf("%.1f/%.1f(MiB)", fromByteToMiB(m.GpuMemoryUsed), fromByteToMiB(m.GpuMemoryTotal)),
+				}
+				PrintLine(w, append(append(items, gpuItems...), requestMetrics...)...)
+			}
+		}
+		PrintLine(w, "")
+	}
+}
+
+// formatGPUMetrics returns the average duty cycle and the total used/total gpu memory
+func formatGPUMetrics(gpus []types.GpuMetric) []string {
+	if len(gpus) == 0 {
+		return []string{"N/A", "N/A"}
+	}
+	dutyCycle, used, total := float64(0), float64(0), float64(0)
+	for _, m := range gpus {
+		dutyCycle += m.GpuDutyCycle
+		used += m.GpuMemoryUsed
+		total += m.GpuMemoryTotal
+	}
+	return []string{
+		fmt.Sprintf("%.1f%%", dutyCycle/float64(len(gpus))),
+		fmt.Sprintf("%.1f/%.1f(MiB)", fromByteToMiB(used), fromByteToMiB(total)),
+	}
+}
+
+func formatRequestMetrics(hasRequestMetrics bool, qps, latency float64) []string {
+	if !hasRequestMetrics {
+		return []string{"N/A", "N/A"}
+	}
+	return []string{fmt.Sprintf("%.2f", qps), fmt.Sprintf("%.2f", latency)}
+}
+
+func fromByteToMiB(value float64) float64 {
+	return value / 1048576
+}
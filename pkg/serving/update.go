@@ -2,6 +2,7 @@ package serving
 
 import (
 	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -260,6 +261,110 @@ func UpdateCustomServing(args *types.UpdateCustomServingArgs) error {
 	return updateDeployment(args.Name, args.Version, deploy)
 }
 
+func UpdateTorchServe(args *types.UpdateTorchServeArgs) error {
+	deploy, err := findAndBuildDeployment(&args.CommonUpdateServingArgs)
+	if err != nil {
+		return err
+	}
+
+	if args.Command == "" && (args.ModelStore != "" || len(args.Models) != 0) {
+		containerArgs := deploy.Spec.Template.Spec.Containers[0].Args
+		if len(containerArgs) > 0 {
+			servingArgs := strings.TrimSpace(containerArgs[0])
+			if args.ModelStore != "" {
+				servingArgs = setServingCommandOption(servingArgs, "--model-store", args.ModelStore)
+			}
+			if len(args.Models) != 0 {
+				servingArgs = setServingCommandOption(servingArgs, "--models", args.Models...)
+			}
+			deploy.Spec.Template.Spec.Containers[0].Args = []string{servingArgs}
+		}
+	}
+
+	setDeploymentUpdateArgs(deploy, &args.CommonUpdateServingArgs)
+
+	return updateDeployment(args.Name, args.Version, deploy)
+}
+
+func UpdateONNXRuntimeServing(args *types.UpdateONNXRuntimeServingArgs) error {
+	deploy, err := findAndBuildDeployment(&args.CommonUpdateServingArgs)
+	if err != nil {
+		return err
+	}
+
+	if args.Command == "" && args.ModelPath != "" {
+		containerArgs := deploy.Spec.Template.Spec.Containers[0].Args
+		if len(containerArgs) > 0 {
+			servingArgs := strings.TrimSpace(containerArgs[0])
+			servingArgs = setServingCommandOption(servingArgs, "--model_path", args.ModelPath)
+			deploy.Spec.Template.Spec.Containers[0].Args = []string{servingArgs}
+		}
+	}
+
+	setDeploymentUpdateArgs(deploy, &args.CommonUpdateServingArgs)
+
+	return updateDeployment(args.Name, args.Version, deploy)
+}
+
+// setServingCommandOption replaces the values of the option in the serving command,
+// both "--option=value" and "--option value1 value2" are supported,
+// the option is appended if it does not exist
+func setServingCommandOption(command, option string, values ...string) string {
+	pattern := regexp.MustCompile(regexp.QuoteMeta(option) + `(=\S+|([ \t]+[^-\s]\S*)+)`)
+	replacement := option + " " + strings.Join(values, " ")
+	if pattern.MatchString(command) {
+		return pattern.ReplaceAllLiteralString(command, replacement)
+	}
+	return command + " " + replacement
+}
+
+// setDeploymentUpdateArgs updates the annotations,labels,node selectors and tolerations of the deployment
+func setDeploymentUpdateArgs(deploy *appsv1.Deployment, args *types.CommonUpdateServingArgs) {
+	if len(args.Annotations) > 0 {
+		for k, v := range args.Annotations {
+			deploy.Annotations[k] = v
+			deploy.Spec.Template.Annotations[k] = v
+		}
+	}
+
+	if len(args.Labels) > 0 {
+		for k, v := range args.Labels {
+			deploy.Labels[k] = v
+			deploy.Spec.Template.Labels[k] = v
+		}
+	}
+
+	if len(args.NodeSelectors) > 0 {
+		if deploy.Spec.Template.Spec.NodeSelector == nil {
+			deploy.Spec.Template.Spec.NodeSelector = map[string]string{}
+		}
+		for k, v := range args.NodeSelectors {
+			deploy.Spec.Template.Spec.NodeSelector[k] = v
+		}
+	}
+
+	if len(args.Tolerations) > 0 {
+		exist := map[string]bool{}
+		var tolerations []v1.Toleration
+		for _, toleration := range args.Tolerations {
+			tolerations = append(tolerations, v1.Toleration{
+				Key:      toleration.Key,
+				Value:    toleration.Value,
+				Effect:   v1.TaintEffect(toleration.Effect),
+				Operator: v1.TolerationOperator(toleration.Operator),
+			})
+			exist[toleration.Key+toleration.Value] = true
+		}
+
+		for _, preToleration := range deploy.Spec.Template.Spec.Tolerations {
+			if !exist[preToleration.Key+preToleration.Value] {
+				tolerations = append(tolerations, preToleration)
+			}
+		}
+		deploy.Spec.Template.Spec.Tolerations = tolerations
+	}
+}
+
 func UpdateKServe(args *types.UpdateKServeArgs) error {
 	inferenceService, err := findAndBuildInferenceService(args)
 	if err != nil {
@@ -335,6 +440,12 @@ func findAndBuildDeployment(args *types.CommonUpdateServingArgs) (*appsv1.Deploy
 	case types.CustomServingJob:
 		suffix = "custom-serving"
 		break
+	case types.TorchServeServingJob:
+		suffix = "torchserve"
+		break
+	case types.ONNXRuntimeServingJob:
+		suffix = "onnxruntime"
+		break
 	default:
 		return nil, fmt.Errorf("invalid serving job type [%s]", args.Type)
 	}
@@ -466,22 +577,43 @@ func findAndBuildInferenceService(args *types.UpdateKServeArgs) (*kservev1beta1.
 }
 
 func updateDeployment(name, version string, deploy *appsv1.Deployment) error {
-	err := kubectl.UpdateDeployment(deploy)
-	if err == nil {
-		log.Infof("The serving job %s with version %s has been updated successfully", name, version)
-	} else {
+	return updateDeploymentWithCause(name, version, deploy, changeCauseUpdate)
+}
+
+func updateDeploymentWithCause(name, version string, deploy *appsv1.Deployment, cause string) error {
+	previous, err := kubectl.GetDeployment(deploy.Name, deploy.Namespace)
+	if err != nil {
+		return err
+	}
+	err = kubectl.UpdateDeployment(deploy)
+	if err != nil {
 		log.Errorf("The serving job %s with version %s update failed", name, version)
+		return err
+	}
+	if err := recordDeploymentRevision(previous, deploy, cause); err != nil {
+		log.Warnf("failed to record the revision of serving job %s,reason: %v", name, err)
 	}
-	return err
+	log.Infof("The serving job %s with version %s has been updated successfully", name, version)
+	return nil
 }
 
 func updateInferenceService(name, version string, inferenceService *kservev1beta1.InferenceService) error {
-	err := kubectl.UpdateInferenceService(inferenceService)
+	return updateInferenceServiceWithCause(name, version, inferenceService, changeCauseUpdate)
+}
+
+func updateInferenceServiceWithCause(name, version string, inferenceService *kservev1beta1.InferenceService, cause string) error {
+	previous, err := kubectl.GetInferenceService(inferenceService.Name, inferenceService.Namespace)
+	if err != nil {
+		return err
+	}
+	err = kubectl.UpdateInferenceService(inferenceService)
 	if err != nil {
 		log.Errorf("The serving job %s with version %s update failed", name, version)
 		return err
 	}
-
+	if err := recordInferenceServiceRevision(previous, inferenceService, cause); err != nil {
+		log.Warnf("failed to record the revision of serving job %s,reason: %v", name, err)
+	}
 	log.Infof("The serving job %s with version %s has been updated successfully", name, version)
 	return nil
 }
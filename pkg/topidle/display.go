@@ -0,0 +1,78 @@
+package topidle
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/kubeflow/arena/pkg/apis/types"
+	"github.com/kubeflow/arena/pkg/util"
+	"gopkg.in/yaml.v2"
+)
+
+// DisplayIdleJobs prints the idle jobs, the jobs which waste more gpu hours are printed first
+func DisplayIdleJobs(jobs []*types.IdleJobInfo, args *types.IdleJobArgs, format types.FormatStyle) {
+	switch format {
+	case types.JsonFormat:
+		data, _ := json.MarshalIndent(jobs, "", "    ")
+		fmt.Printf("%v", string(data))
+		return
+	case types.YamlFormat:
+		data, _ := yaml.Marshal(jobs)
+		fmt.Printf("%v", string(data))
+		return
+	}
+	if len(jobs) == 0 {
+		fmt.Printf("No jobs keep gpu utilization under %v%% for %v\n", args.Threshold, args.Duration)
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	header := []string{"NAME", "NAMESPACE", "OWNER", "KIND", "TYPE", "GPUS", "MAX_UTIL", "IDLE_SINCE", "IDLE_DURATION", "WASTED_GPU_HOURS"}
+	if args.Action != types.NoneIdleJobAction {
+		header = append(header, "ACTION")
+	}
+	printLine(w, header...)
+	totalGPUHours := float64(0)
+	for _, job := range jobs {
+		totalGPUHours += job.WastedGPUHours
+		name := job.Name
+		if job.Version != "" {
+			name = fmt.Sprintf("%v(%v)", job.Name, job.Version)
+		}
+		items := []string{
+			name,
+			job.Namespace,
+			valueOrNone(job.Owner),
+			job.Kind,
+			job.Type,
+			fmt.Sprintf("%v", job.AllocatedGPUs),
+			fmt.Sprintf("%.1f%%", job.MaxDutyCycle),
+			util.GetFormatTime(job.IdleSince),
+			util.ShortHumanDuration(time.Duration(job.IdleDuration) * time.Second),
+			fmt.Sprintf("%.2f", job.WastedGPUHours),
+		}
+		if args.Action != types.NoneIdleJobAction {
+			items = append(items, job.Action)
+		}
+		printLine(w, items...)
+	}
+	printLine(w, "")
+	printLine(w, fmt.Sprintf("Total Wasted GPU Hours: %.2f", totalGPUHours))
+	_ = w.Flush()
+}
+
+func valueOrNone(value string) string {
+	if value == "" {
+		return "N/A"
+	}
+	return value
+}
+
+func printLine(w io.Writer, fields ...string) {
+	buffer := strings.Join(fields, "\t")
+	fmt.Fprintln(w, buffer)
+}
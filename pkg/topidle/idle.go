@@ -0,0 +1,401 @@
+package topidle
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/kubeflow/arena/pkg/apis/config"
+	"github.com/kubeflow/arena/pkg/apis/types"
+	"github.com/kubeflow/arena/pkg/prometheus"
+	"github.com/kubeflow/arena/pkg/serving"
+	"github.com/kubeflow/arena/pkg/training"
+	"github.com/kubeflow/arena/pkg/util"
+	log "github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// idleLookbackMin is the minimal time window of querying gpu utilization,
+	// the idle duration which is longer than the window can not be detected
+	idleLookbackMin = 24 * time.Hour
+	idleMaxSamples  = 200
+	idleMinStep     = time.Minute
+)
+
+// gpuJob is a running job which holds gpus
+type gpuJob struct {
+	info    *types.IdleJobInfo
+	pods    []*v1.Pod
+	serving serving.ServingJob
+}
+
+// ListIdleJobs finds the running training and serving jobs whose gpu utilization keeps
+// under the threshold for the duration, and takes the action on them
+func ListIdleJobs(args *types.IdleJobArgs) ([]*types.IdleJobInfo, error) {
+	jobs, err := listGPUJobs(args.Namespace, args.AllNamespaces)
+	if err != nil {
+		return nil, err
+	}
+	idleJobs := []*types.IdleJobInfo{}
+	if len(jobs) == 0 {
+		return idleJobs, nil
+	}
+	podNames := []string{}
+	namespaces := []string{}
+	for _, job := range jobs {
+		for _, pod := range job.pods {
+			podNames = append(podNames, pod.Name)
+			if !containsString(namespaces, pod.Namespace) {
+				namespaces = append(namespaces, pod.Namespace)
+			}
+		}
+	}
+	end := time.Now()
+	lookback := 2 * args.Duration
+	if lookback < idleLookbackMin {
+		lookback = idleLookbackMin
+	}
+	step := (lookback / idleMaxSamples).Round(time.Second)
+	if step < idleMinStep {
+		step = idleMinStep
+	}
+	client := config.GetArenaConfiger().GetClientSet()
+	samples, err := prometheus.GetPodsGpuHistory(client, namespaces, podNames, end.Add(-lookback), end, step)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the gpu utilization of jobs: %v", err)
+	}
+	idleJobsMap := map[*types.IdleJobInfo]*gpuJob{}
+	for _, job := range jobs {
+		idleSamples := -1
+		maxDutyCycle := float64(0)
+		for _, pod := range job.pods {
+			for _, s := range samples[prometheus.PodKey(pod.Namespace, pod.Name)] {
+				count, max := trailingIdleSamples(s.DutyCycle, args.Threshold)
+				if idleSamples == -1 || count < idleSamples {
+					idleSamples = count
+				}
+				if max > maxDutyCycle {
+					maxDutyCycle = max
+				}
+			}
+		}
+		// the job without gpu metrics is not regarded as idle
+		if idleSamples <= 0 {
+			continue
+		}
+		idleDuration := time.Duration(idleSamples) * step
+		if idleDuration < args.Duration {
+			continue
+		}
+		job.info.MaxDutyCycle = maxDutyCycle
+		job.info.IdleSince = end.Add(-idleDuration).Unix()
+		job.info.IdleDuration = int64(idleDuration.Seconds())
+		job.info.WastedGPUHours = job.info.AllocatedGPUs * idleDuration.Hours()
+		idleJobs = append(idleJobs, job.info)
+		idleJobsMap[job.info] = job
+	}
+	sort.Slice(idleJobs, func(i, j int) bool {
+		return idleJobs[i].WastedGPUHours > idleJobs[j].WastedGPUHours
+	})
+	for _, info := range idleJobs {
+		switch args.Action {
+		case types.AnnotateIdleJobAction:
+			info.Action = actionResult("annotated", annotateIdleJob(idleJobsMap[info]))
+		case types.SuspendIdleJobAction:
+			info.Action = actionResult("suspended", suspendIdleJob(idleJobsMap[info]))
+		}
+	}
+	return idleJobs, nil
+}
+
+// listGPUJobs returns the running training jobs and serving jobs which hold gpus
+func listGPUJobs(namespace string, allNamespaces bool) ([]*gpuJob, error) {
+	jobs := []*gpuJob{}
+	trainingJobs, err := training.ListTrainingJobs(namespace, allNamespaces, types.AllTrainingJob)
+	if err != nil {
+		return nil, err
+	}
+	for _, job := range trainingJobs {
+		if job.GetStatus() != string(types.TrainingJobRunning) || job.AllocatedGPU() <= 0 {
+			continue
+		}
+		pods := runningPods(job.AllPods())
+		jobs = append(jobs, &gpuJob{
+			info: &types.IdleJobInfo{
+				Name:          job.Name(),
+				Namespace:     job.Namespace(),
+				Owner:         util.GetPodsOwner(pods),
+				Kind:          "training",
+				Type:          string(job.Trainer()),
+				AllocatedGPUs: float64(job.AllocatedGPU()),
+			},
+			pods: pods,
+		})
+	}
+	servingJobs, err := serving.ListServingJobs(namespace, allNamespaces, types.AllServingJob)
+	if err != nil {
+		return nil, err
+	}
+	for _, job := range servingJobs {
+		if job.RequestGPUs() <= 0 {
+			continue
+		}
+		pods := runningPods(job.Pods())
+		if len(pods) == 0 {
+			continue
+		}
+		podGPUs := job.RequestGPUs()
+		if job.DesiredInstances() > 0 {
+			podGPUs = podGPUs / float64(job.DesiredInstances())
+		}
+		jobs = append(jobs, &gpuJob{
+			info: &types.IdleJobInfo{
+				Name:          job.Name(),
+				Namespace:     job.Namespace(),
+				Owner:         util.GetPodsOwner(pods),
+				Kind:          "serving",
+				Type:          string(job.Type()),
+				Version:       job.Version(),
+				AllocatedGPUs: podGPUs * float64(len(pods)),
+			},
+			pods:    pods,
+			serving: job,
+		})
+	}
+	return jobs, nil
+}
+
+func containsString(items []string, item string) bool {
+	for _, i := range items {
+		if i == item {
+			return true
+		}
+	}
+	return false
+}
+
+func runningPods(pods []*v1.Pod) []*v1.Pod {
+	result := []*v1.Pod{}
+	for _, pod := range pods {
+		if pod.Status.Phase == v1.PodRunning {
+			result = append(result, pod)
+		}
+	}
+	return result
+}
+
+// trailingIdleSamples returns the count of the latest samples which are not greater than threshold,
+// and the max value of them
+func trailingIdleSamples(values []float64, threshold float64) (int, float64) {
+	count := 0
+	max := float64(0)
+	for i := len(values) - 1; i >= 0; i-- {
+		if values[i] > threshold {
+			break
+		}
+		if values[i] > max {
+			max = values[i]
+		}
+		count++
+	}
+	return count, max
+}
+
+// annotateIdleJob marks the pods of job with the idle time and wasted gpu hours
+func annotateIdleJob(job *gpuJob) error {
+	client := config.GetArenaConfiger().GetClientSet()
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{
+				types.IdleSinceAnnotation:    time.Unix(job.info.IdleSince, 0).Format(time.RFC3339),
+				types.IdleGPUHoursAnnotation: strconv.FormatFloat(job.info.WastedGPUHours, 'f', 2, 64),
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	for _, pod := range job.pods {
+		_, err := client.CoreV1().Pods(pod.Namespace).Patch(context.TODO(), pod.Name, k8stypes.MergePatchType, patch, metav1.PatchOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to annotate pod %s: %v", pod.Name, err)
+		}
+	}
+	log.Debugf("the pods of %v job %v are annotated as idle", job.info.Kind, job.info.Name)
+	return nil
+}
+
+// suspendableTrainingJobResources are the training jobs which can be suspended by spec.runPolicy.suspend,
+// it is supported by training-operator v1.7 or later
+var suspendableTrainingJobResources = map[types.TrainingJobType]schema.GroupVersionResource{
+	types.TFTrainingJob:      {Group: "kubeflow.org", Version: "v1", Resource: "tfjobs"},
+	types.PytorchTrainingJob: {Group: "kubeflow.org", Version: "v1", Resource: "pytorchjobs"},
+}
+
+// suspendIdleJob suspends the training job by spec.runPolicy.suspend, or scales the deployment of
+// serving job to 0 and records the replicas in the annotation, the job can be resumed by ResumeIdleJob
+func suspendIdleJob(job *gpuJob) error {
+	if job.info.Kind == "training" {
+		return suspendTrainingJob(job)
+	}
+	if job.serving == nil || job.serving.Deployment() == nil {
+		return fmt.Errorf("suspending %v job is not supported", job.info.Type)
+	}
+	client := config.GetArenaConfiger().GetClientSet()
+	deploy := job.serving.Deployment()
+	replicas := int32(1)
+	if deploy.Spec.Replicas != nil {
+		replicas = *deploy.Spec.Replicas
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{
+				types.SuspendedReplicasAnnotation: strconv.Itoa(int(replicas)),
+				types.IdleSinceAnnotation:         time.Unix(job.info.IdleSince, 0).Format(time.RFC3339),
+			},
+		},
+		"spec": map[string]interface{}{
+			"replicas": 0,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = client.AppsV1().Deployments(deploy.Namespace).Patch(context.TODO(), deploy.Name, k8stypes.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to scale deployment %s: %v", deploy.Name, err)
+	}
+	log.Debugf("the serving job %v is suspended, its replicas %v are recorded", job.info.Name, replicas)
+	return nil
+}
+
+func suspendTrainingJob(job *gpuJob) error {
+	gvr, ok := suspendableTrainingJobResources[types.TrainingJobType(job.info.Type)]
+	if !ok {
+		return fmt.Errorf("suspending %v is not supported, only %v and %v support it", job.info.Type, types.TFTrainingJob, types.PytorchTrainingJob)
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{
+				types.IdleSinceAnnotation: time.Unix(job.info.IdleSince, 0).Format(time.RFC3339),
+			},
+		},
+		"spec": map[string]interface{}{
+			"runPolicy": map[string]interface{}{
+				"suspend": true,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	client := config.GetArenaConfiger().GetDynamicClient()
+	obj, err := client.Resource(gvr).Namespace(job.info.Namespace).Patch(context.TODO(), job.info.Name, k8stypes.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to suspend %v %v: %v", job.info.Type, job.info.Name, err)
+	}
+	// the unknown field is pruned by the crd of old operators, so the job keeps running
+	if suspended, _, _ := unstructured.NestedBool(obj.Object, "spec", "runPolicy", "suspend"); !suspended {
+		return fmt.Errorf("the operator of %v does not support spec.runPolicy.suspend, it requires training-operator v1.7 or later", job.info.Type)
+	}
+	log.Debugf("the training job %v is suspended", job.info.Name)
+	return nil
+}
+
+// ResumeIdleJob resumes the job which is suspended by the suspend action, the serving job is scaled to the
+// recorded replicas, the training job is resumed by setting spec.runPolicy.suspend to false
+func ResumeIdleJob(namespace, name string) error {
+	resumed, err := resumeServingJob(namespace, name)
+	if err != nil || resumed {
+		return err
+	}
+	job, err := training.SearchTrainingJob(name, namespace, types.AllTrainingJob)
+	if err != nil {
+		return fmt.Errorf("not found the suspended serving job or training job %v in namespace %v", name, namespace)
+	}
+	gvr, ok := suspendableTrainingJobResources[job.Trainer()]
+	if !ok {
+		return fmt.Errorf("resuming %v is not supported, only %v and %v support it", job.Trainer(), types.TFTrainingJob, types.PytorchTrainingJob)
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{
+				types.IdleSinceAnnotation: nil,
+			},
+		},
+		"spec": map[string]interface{}{
+			"runPolicy": map[string]interface{}{
+				"suspend": false,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	client := config.GetArenaConfiger().GetDynamicClient()
+	_, err = client.Resource(gvr).Namespace(namespace).Patch(context.TODO(), name, k8stypes.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to resume %v %v: %v", job.Trainer(), name, err)
+	}
+	return nil
+}
+
+// resumeServingJob scales the deployments of serving job which are suspended to the recorded replicas,
+// it returns false if the serving job has no suspended deployments
+func resumeServingJob(namespace, name string) (bool, error) {
+	client := config.GetArenaConfiger().GetClientSet()
+	deploys, err := client.AppsV1().Deployments(namespace).List(context.TODO(), metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("servingName=%v", name),
+	})
+	if err != nil {
+		return false, err
+	}
+	resumed := false
+	for _, deploy := range deploys.Items {
+		value, ok := deploy.Annotations[types.SuspendedReplicasAnnotation]
+		if !ok {
+			continue
+		}
+		replicas, err := strconv.Atoi(value)
+		if err != nil || replicas < 0 {
+			return resumed, fmt.Errorf("invalid annotation %v=%v of deployment %v", types.SuspendedReplicasAnnotation, value, deploy.Name)
+		}
+		patch, err := json.Marshal(map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"annotations": map[string]interface{}{
+					types.SuspendedReplicasAnnotation: nil,
+					types.IdleSinceAnnotation:         nil,
+				},
+			},
+			"spec": map[string]interface{}{
+				"replicas": replicas,
+			},
+		})
+		if err != nil {
+			return resumed, err
+		}
+		_, err = client.AppsV1().Deployments(namespace).Patch(context.TODO(), deploy.Name, k8stypes.MergePatchType, patch, metav1.PatchOptions{})
+		if err != nil {
+			return resumed, fmt.Errorf("failed to scale deployment %s: %v", deploy.Name, err)
+		}
+		log.Debugf("the deployment %v of serving job %v is scaled to %v", deploy.Name, name, replicas)
+		resumed = true
+	}
+	return resumed, nil
+}
+
+func actionResult(done string, err error) string {
+	if err != nil {
+		log.Warnf("failed to take action on job: %v", err)
+		return fmt.Sprintf("skipped(%v)", err)
+	}
+	return done
+}
@@ -22,6 +22,7 @@ type buildNodeArgs struct {
 	pods           []*v1.Pod
 	configmaps     []*v1.ConfigMap
 	nodeGPUMetrics map[string]types.NodeGpuMetric
+	nodeMIGDevices map[string][]types.MIGDeviceMetric
 }
 
 // NodeProcesser process the node
@@ -43,6 +44,8 @@ type NodeProcesser interface {
 type Node interface {
 	// Index is used to sort the nodes
 	Index() int
+	// SetIndex changes the position of the node when the nodes are sorted
+	SetIndex(index int)
 	// Name return the node name
 	Name() string
 	// Type return the node type
@@ -81,6 +84,10 @@ func (b *baseNode) Index() int {
 	return b.index
 }
 
+func (b *baseNode) SetIndex(index int) {
+	b.index = index
+}
+
 func (b *baseNode) Name() string {
 	return b.node.Name
 }
@@ -135,6 +142,7 @@ func GetSupportedNodePorcessers() []NodeProcesser {
 	return []NodeProcesser{
 		NewGPUShareNodeProcesser(),
 		NewGPUTopologyNodeProcesser(),
+		NewGPUMIGNodeProcesser(),
 		NewGPUExclusiveNodeProcesser(),
 		NewNormalNodeProcesser(),
 	}
@@ -230,8 +238,13 @@ func (n *nodeProcesser) SupportedNodeType() types.NodeType {
 }
 
 func BuildNodes(nodeNames []string, targetNodeType types.NodeType, showMetric bool) ([]Node, error) {
+	return BuildNodesBySelector(nodeNames, "", targetNodeType, showMetric)
+}
+
+// BuildNodesBySelector builds the nodes which match the label selector, like pool=a100
+func BuildNodesBySelector(nodeNames []string, selector string, targetNodeType types.NodeType, showMetric bool) ([]Node, error) {
 	client := config.GetArenaConfiger().GetClientSet()
-	allNodes, err := k8saccesser.GetK8sResourceAccesser().ListNodes("")
+	allNodes, err := k8saccesser.GetK8sResourceAccesser().ListNodes(selector)
 	if err != nil {
 		return nil, err
 	}
@@ -244,12 +257,18 @@ func BuildNodes(nodeNames []string, targetNodeType types.NodeType, showMetric bo
 		names[name] = true
 	}
 	nodeGPUMetrics := map[string]types.NodeGpuMetric{}
+	nodeMIGDevices := map[string][]types.MIGDeviceMetric{}
 	if showMetric {
 		nodeGPUMetrics, err = GetNodeGpuMetrics(client)
 		if err != nil {
 			log.Debugf("failed to get node metrics: %v", err)
 			nodeGPUMetrics = map[string]types.NodeGpuMetric{}
 		}
+		nodeMIGDevices, err = prometheus.GetNodeMIGDevices(client, []string{".*"})
+		if err != nil {
+			log.Debugf("failed to get node mig devices: %v", err)
+			nodeMIGDevices = map[string][]types.MIGDeviceMetric{}
+		}
 	}
 	pods, err := listRunningPods()
 	if err != nil {
@@ -260,6 +279,7 @@ func BuildNodes(nodeNames []string, targetNodeType types.NodeType, showMetric bo
 	args := buildNodeArgs{
 		configmaps:     configmaps,
 		nodeGPUMetrics: nodeGPUMetrics,
+		nodeMIGDevices: nodeMIGDevices,
 		pods:           pods,
 	}
 	for index, n := range allNodes {
@@ -29,6 +29,11 @@ func DisplayNodeDetails(nodeNames []string, nodeType types.NodeType, format type
 	if err != nil {
 		return err
 	}
+	displayNodeDetails(nodes, format)
+	return nil
+}
+
+func displayNodeDetails(nodes []Node, format types.FormatStyle) {
 	allNodeInfos := types.AllNodeInfo{}
 	for _, processer := range GetSupportedNodePorcessers() {
 		allNodeInfos = processer.Convert2NodeInfos(nodes, allNodeInfos)
@@ -37,11 +42,11 @@ func DisplayNodeDetails(nodeNames []string, nodeType types.NodeType, format type
 	case types.JsonFormat:
 		data, _ := json.MarshalIndent(allNodeInfos, "", "    ")
 		fmt.Printf("%v", string(data))
-		return nil
+		return
 	case types.YamlFormat:
 		data, _ := yaml.Marshal(allNodeInfos)
 		fmt.Printf("%v", string(data))
-		return nil
+		return
 	}
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
 	processers := GetSupportedNodePorcessers()
@@ -50,7 +55,6 @@ func DisplayNodeDetails(nodeNames []string, nodeType types.NodeType, format type
 		processer.DisplayNodesDetails(w, nodes)
 	}
 	_ = w.Flush()
-	return nil
 }
 
 func PrintLine(w io.Writer, fields ...string) {
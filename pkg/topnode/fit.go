@@ -0,0 +1,278 @@
+package topnode
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/kubeflow/arena/pkg/apis/types"
+	"github.com/kubeflow/arena/pkg/apis/utils"
+	"gopkg.in/yaml.v2"
+	v1 "k8s.io/api/core/v1"
+)
+
+// FitNodes simulates placing the workers of request on the nodes, the free resources of node are
+// the allocatable resources minus the resources requested by the pods on it
+func FitNodes(args *types.NodeFitArgs) (*types.NodeFitReport, error) {
+	if args.PoolLabel == "" {
+		args.PoolLabel = types.DefaultNodePoolLabel
+	}
+	nodes, err := BuildNodes(nil, types.AllKnownNode, false)
+	if err != nil {
+		return nil, err
+	}
+	report := &types.NodeFitReport{
+		Request: *args,
+		Nodes:   []types.NodeFitInfo{},
+		Pools:   []types.NodePoolFragmentation{},
+	}
+	pools := map[string]*types.NodePoolFragmentation{}
+	for _, node := range nodes {
+		info := buildNodeFitInfo(node, args)
+		report.Nodes = append(report.Nodes, info)
+		report.PlacedWorkers += info.Workers
+		pool, ok := pools[info.Pool]
+		if !ok {
+			pool = &types.NodePoolFragmentation{Pool: info.Pool}
+			pools[info.Pool] = pool
+		}
+		pool.Nodes++
+		pool.FreeGPUs += info.FreeGPUs
+		if info.FreeGPUs > pool.LargestFreeGPUs {
+			pool.LargestFreeGPUs = info.FreeGPUs
+		}
+		if args.GPUs > 0 {
+			pool.StrandedGPUs += info.FreeGPUs - info.Workers*args.GPUs
+		}
+	}
+	if report.PlacedWorkers > args.Workers {
+		report.PlacedWorkers = args.Workers
+	}
+	report.Feasible = report.PlacedWorkers == args.Workers
+	for _, pool := range pools {
+		if pool.FreeGPUs != 0 {
+			pool.Score = 1 - float64(pool.LargestFreeGPUs)/float64(pool.FreeGPUs)
+		}
+		report.Pools = append(report.Pools, *pool)
+	}
+	sort.Slice(report.Nodes, func(i, j int) bool {
+		if report.Nodes[i].Workers != report.Nodes[j].Workers {
+			return report.Nodes[i].Workers > report.Nodes[j].Workers
+		}
+		return report.Nodes[i].Name < report.Nodes[j].Name
+	})
+	sort.Slice(report.Pools, func(i, j int) bool {
+		return report.Pools[i].Pool < report.Pools[j].Pool
+	})
+	return report, nil
+}
+
+func buildNodeFitInfo(node Node, args *types.NodeFitArgs) types.NodeFitInfo {
+	v1node := node.GetV1Node()
+	info := types.NodeFitInfo{
+		Name: node.Name(),
+		Pool: v1node.Labels[args.PoolLabel],
+		Type: node.Type(),
+	}
+	if info.Pool == "" {
+		info.Pool = "<none>"
+	}
+	info.FreeGPUs, info.FreeGPUMemory = freeGPUsOfNode(node)
+	info.FreeCPU, info.FreeMemory = freeResourcesOfNode(v1node, node.GetV1Pods())
+	if !strings.HasPrefix(node.Status(), string(v1.NodeReady)) || v1node.Spec.Unschedulable {
+		info.Reason = fmt.Sprintf("node is %v", node.Status())
+		return info
+	}
+	// the workers which the node can host are not limited by the workers of request,
+	// so that the stranded gpus can be counted
+	workers := math.MaxInt32
+	reasons := []string{}
+	fit := func(name string, free, request float64, format func(float64) string) {
+		if request <= 0 {
+			return
+		}
+		count := int(math.Floor(free / request))
+		if count < workers {
+			workers = count
+		}
+		if count == 0 {
+			reasons = append(reasons, fmt.Sprintf("insufficient %v(%v free,%v requested)", name, format(free), format(request)))
+		}
+	}
+	fit("gpus", float64(info.FreeGPUs), float64(args.GPUs), formatFitQuantity)
+	fit("cpu", info.FreeCPU, args.CPU, formatFitQuantity)
+	fit("memory", info.FreeMemory, args.Memory, formatFitMemory)
+	if args.GPUMemory > 0 {
+		// a worker uses the gpu memory of one device, so the free gpu memory of devices can not be added up
+		request := utils.DataUnitTransfer("GiB", "bytes", args.GPUMemory)
+		count := 0
+		largest := float64(0)
+		for _, free := range info.FreeGPUMemory {
+			count += int(math.Floor(free / request))
+			largest = math.Max(largest, free)
+		}
+		if count < workers {
+			workers = count
+		}
+		if count == 0 {
+			reasons = append(reasons, fmt.Sprintf("insufficient gpu memory of one device(%v free,%v requested)", formatFitMemory(largest), formatFitMemory(request)))
+		}
+	}
+	if workers == math.MaxInt32 {
+		workers = args.Workers
+	}
+	info.Workers = workers
+	info.Reason = strings.Join(reasons, ",")
+	return info
+}
+
+// freeGPUsOfNode returns the free whole gpus of node, and the free gpu memory of each device for gpushare nodes
+func freeGPUsOfNode(node Node) (int, []float64) {
+	switch nodeInfo := node.Convert2NodeInfo().(type) {
+	case types.GPUShareNodeInfo:
+		freeGPUs := 0
+		freeGPUMemory := []float64{}
+		for _, dev := range nodeInfo.Devices {
+			if dev.AllocatedGPUMemory == 0 && dev.AllocatedGPUCore == 0 {
+				freeGPUs++
+			}
+			freeGPUMemory = append(freeGPUMemory, math.Max(dev.TotalGPUMemory-dev.AllocatedGPUMemory, 0))
+		}
+		return freeGPUs, freeGPUMemory
+	case types.GPUMIGNodeInfo:
+		// the gpus partitioned by mig can not be allocated as whole gpus
+		v1node := node.GetV1Node()
+		val, ok := v1node.Status.Allocatable[v1.ResourceName(types.NvidiaGPUResourceName)]
+		if !ok {
+			return 0, nil
+		}
+		allocated := 0
+		for _, pod := range node.GetV1Pods() {
+			if !utils.IsCompletedPod(pod) {
+				allocated += utils.GPUCountInPod(pod)
+			}
+		}
+		return int(math.Max(float64(val.Value())-float64(allocated), 0)), nil
+	case types.GPUExclusiveNodeInfo:
+		return freeGPUs(nodeInfo.CommonGPUNodeInfo), nil
+	case types.GPUTopologyNodeInfo:
+		return freeGPUs(nodeInfo.CommonGPUNodeInfo), nil
+	}
+	return 0, nil
+}
+
+func freeGPUs(info types.CommonGPUNodeInfo) int {
+	return int(math.Max(math.Floor(info.TotalGPUs-info.AllocatedGPUs-info.UnhealthyGPUs), 0))
+}
+
+// freeResourcesOfNode returns the free cpu cores and memory(bytes) of node
+func freeResourcesOfNode(node *v1.Node, pods []*v1.Pod) (float64, float64) {
+	cpu := node.Status.Allocatable[v1.ResourceCPU]
+	memory := node.Status.Allocatable[v1.ResourceMemory]
+	freeCPU := float64(cpu.MilliValue()) / 1000
+	freeMemory := float64(memory.Value())
+	for _, pod := range pods {
+		if utils.IsCompletedPod(pod) {
+			continue
+		}
+		for _, c := range pod.Spec.Containers {
+			if val, ok := c.Resources.Requests[v1.ResourceCPU]; ok {
+				freeCPU -= float64(val.MilliValue()) / 1000
+			}
+			if val, ok := c.Resources.Requests[v1.ResourceMemory]; ok {
+				freeMemory -= float64(val.Value())
+			}
+		}
+	}
+	return math.Max(freeCPU, 0), math.Max(freeMemory, 0)
+}
+
+func formatFitQuantity(value float64) string {
+	return strconv.FormatFloat(math.Round(value*100)/100, 'f', -1, 64)
+}
+
+func formatFitMemory(value float64) string {
+	return fmt.Sprintf("%.1fGiB", utils.DataUnitTransfer("bytes", "GiB", value))
+}
+
+// DisplayNodeFitReport prints the feasible nodes and the fragmentation of node pools
+func DisplayNodeFitReport(report *types.NodeFitReport, format types.FormatStyle) {
+	switch format {
+	case types.JsonFormat:
+		data, _ := json.MarshalIndent(report, "", "    ")
+		fmt.Printf("%v", string(data))
+		return
+	case types.YamlFormat:
+		data, _ := yaml.Marshal(report)
+		fmt.Printf("%v", string(data))
+		return
+	}
+	request := report.Request
+	items := []string{}
+	if request.GPUs > 0 {
+		items = append(items, fmt.Sprintf("gpus=%v", request.GPUs))
+	}
+	if request.GPUMemory > 0 {
+		items = append(items, fmt.Sprintf("gpu-memory=%vGiB", request.GPUMemory))
+	}
+	if request.CPU > 0 {
+		items = append(items, fmt.Sprintf("cpu=%v", formatFitQuantity(request.CPU)))
+	}
+	if request.Memory > 0 {
+		items = append(items, fmt.Sprintf("memory=%v", formatFitMemory(request.Memory)))
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	PrintLine(w, fmt.Sprintf("Request: %v per worker,%v workers", strings.Join(items, ","), request.Workers))
+	PrintLine(w, "")
+	PrintLine(w, "NAME", "POOL", "GPU_MODE", "GPU(Free)", "CPU(Free)", "MEMORY(Free)", "WORKERS", "REASON")
+	for _, node := range report.Nodes {
+		reason := node.Reason
+		if reason == "" {
+			reason = "-"
+		}
+		PrintLine(w,
+			node.Name,
+			node.Pool,
+			NodeTypeAlias(node.Type),
+			fmt.Sprintf("%v", node.FreeGPUs),
+			formatFitQuantity(node.FreeCPU),
+			formatFitMemory(node.FreeMemory),
+			fmt.Sprintf("%v", node.Workers),
+			reason,
+		)
+	}
+	PrintLine(w, "")
+	PrintLine(w, "POOL", "NODES", "GPU(Free)", "GPU(Largest Free Block)", "GPU(Stranded)", "FRAGMENTATION")
+	for _, pool := range report.Pools {
+		PrintLine(w,
+			pool.Pool,
+			fmt.Sprintf("%v", pool.Nodes),
+			fmt.Sprintf("%v", pool.FreeGPUs),
+			fmt.Sprintf("%v", pool.LargestFreeGPUs),
+			fmt.Sprintf("%v", pool.StrandedGPUs),
+			fmt.Sprintf("%.2f", pool.Score),
+		)
+	}
+	PrintLine(w, "---------------------------------------------------------------------------------------------------")
+	if report.Feasible {
+		PrintLine(w, fmt.Sprintf("The job fits: all %v workers can be placed.", request.Workers))
+	} else {
+		PrintLine(w, fmt.Sprintf("The job does not fit: only %v/%v workers can be placed.", report.PlacedWorkers, request.Workers))
+	}
+	_ = w.Flush()
+}
+
+// NodeTypeAlias returns the gpu mode of node type displayed by 'arena top node', like exclusive
+func NodeTypeAlias(nodeType types.NodeType) string {
+	for _, typeInfo := range types.NodeTypeSlice {
+		if typeInfo.Name == nodeType {
+			return typeInfo.Alias
+		}
+	}
+	return string(nodeType)
+}
@@ -0,0 +1,171 @@
+package topnode
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kubeflow/arena/pkg/apis/types"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const gib = float64(1024 * 1024 * 1024)
+
+// fakeFitNode implements the methods of Node used by buildNodeFitInfo
+type fakeFitNode struct {
+	Node
+	node     *v1.Node
+	pods     []*v1.Pod
+	nodeType types.NodeType
+	status   string
+	info     interface{}
+}
+
+func (n *fakeFitNode) Name() string                  { return n.node.Name }
+func (n *fakeFitNode) Type() types.NodeType          { return n.nodeType }
+func (n *fakeFitNode) Status() string                { return n.status }
+func (n *fakeFitNode) GetV1Pods() []*v1.Pod          { return n.pods }
+func (n *fakeFitNode) GetV1Node() *v1.Node           { return n.node }
+func (n *fakeFitNode) Convert2NodeInfo() interface{} { return n.info }
+
+func newFitNode(cpu, memory string) *v1.Node {
+	return &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "node-1",
+			Labels: map[string]string{types.DefaultNodePoolLabel: "a100"},
+		},
+		Status: v1.NodeStatus{
+			Allocatable: v1.ResourceList{
+				v1.ResourceCPU:    resource.MustParse(cpu),
+				v1.ResourceMemory: resource.MustParse(memory),
+			},
+		},
+	}
+}
+
+func newFitPod(cpu, memory string, phase v1.PodPhase) *v1.Pod {
+	return &v1.Pod{
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{
+				Resources: v1.ResourceRequirements{
+					Requests: v1.ResourceList{
+						v1.ResourceCPU:    resource.MustParse(cpu),
+						v1.ResourceMemory: resource.MustParse(memory),
+					},
+				},
+			}},
+		},
+		Status: v1.PodStatus{Phase: phase},
+	}
+}
+
+func newExclusiveFitNode(totalGPUs, allocatedGPUs float64, pods ...*v1.Pod) *fakeFitNode {
+	return &fakeFitNode{
+		node:     newFitNode("32", "128Gi"),
+		pods:     pods,
+		nodeType: types.GPUExclusiveNode,
+		status:   string(v1.NodeReady),
+		info: types.GPUExclusiveNodeInfo{
+			CommonGPUNodeInfo: types.CommonGPUNodeInfo{TotalGPUs: totalGPUs, AllocatedGPUs: allocatedGPUs},
+		},
+	}
+}
+
+func TestBuildNodeFitInfo(t *testing.T) {
+	args := &types.NodeFitArgs{PoolLabel: types.DefaultNodePoolLabel, Workers: 4}
+	tc := []struct {
+		Name      string
+		Node      *fakeFitNode
+		Args      types.NodeFitArgs
+		FreeGPUs  int
+		FreeCPU   float64
+		Workers   int
+		HasReason string
+	}{
+		{
+			Name:     "limited by gpus",
+			Node:     newExclusiveFitNode(8, 2),
+			Args:     types.NodeFitArgs{GPUs: 2, CPU: 4},
+			FreeGPUs: 6,
+			FreeCPU:  32,
+			Workers:  3,
+		},
+		{
+			Name: "limited by cpu of running pods, the completed pods are skipped",
+			Node: newExclusiveFitNode(8, 0,
+				newFitPod("20", "1Gi", v1.PodRunning),
+				newFitPod("30", "1Gi", v1.PodSucceeded),
+			),
+			Args:     types.NodeFitArgs{GPUs: 1, CPU: 4},
+			FreeGPUs: 8,
+			FreeCPU:  12,
+			Workers:  3,
+		},
+		{
+			Name:      "insufficient memory",
+			Node:      newExclusiveFitNode(8, 0, newFitPod("1", "120Gi", v1.PodRunning)),
+			Args:      types.NodeFitArgs{GPUs: 1, Memory: 16 * gib},
+			FreeGPUs:  8,
+			FreeCPU:   31,
+			Workers:   0,
+			HasReason: "insufficient memory",
+		},
+		{
+			Name:     "no resources requested",
+			Node:     newExclusiveFitNode(8, 0),
+			Args:     types.NodeFitArgs{},
+			FreeGPUs: 8,
+			FreeCPU:  32,
+			Workers:  4,
+		},
+		{
+			Name: "gpu memory of one device can not be added up",
+			Node: &fakeFitNode{
+				node:     newFitNode("32", "128Gi"),
+				nodeType: types.GPUShareNode,
+				status:   string(v1.NodeReady),
+				info: types.GPUShareNodeInfo{
+					Devices: []types.GPUShareNodeDevice{
+						{Id: "0", TotalGPUMemory: 16 * gib, AllocatedGPUMemory: 10 * gib},
+						{Id: "1", TotalGPUMemory: 16 * gib, AllocatedGPUMemory: 10 * gib},
+					},
+				},
+			},
+			Args:      types.NodeFitArgs{GPUMemory: 8},
+			FreeGPUs:  0,
+			FreeCPU:   32,
+			Workers:   0,
+			HasReason: "insufficient gpu memory of one device",
+		},
+		{
+			Name: "not ready node",
+			Node: &fakeFitNode{
+				node:     newFitNode("32", "128Gi"),
+				nodeType: types.GPUExclusiveNode,
+				status:   "NotReady",
+				info:     types.GPUExclusiveNodeInfo{CommonGPUNodeInfo: types.CommonGPUNodeInfo{TotalGPUs: 8}},
+			},
+			Args:      types.NodeFitArgs{GPUs: 1},
+			FreeGPUs:  8,
+			FreeCPU:   32,
+			Workers:   0,
+			HasReason: "node is NotReady",
+		},
+	}
+	for _, c := range tc {
+		c.Args.PoolLabel = args.PoolLabel
+		c.Args.Workers = args.Workers
+		info := buildNodeFitInfo(c.Node, &c.Args)
+		if info.Pool != "a100" {
+			t.Errorf("%s: Expected pool a100; Got %v", c.Name, info.Pool)
+		}
+		if info.FreeGPUs != c.FreeGPUs || info.FreeCPU != c.FreeCPU || info.Workers != c.Workers {
+			t.Errorf("%s: Expected %v free gpus, %v free cpu and %v workers; Got %v free gpus, %v free cpu and %v workers",
+				c.Name, c.FreeGPUs, c.FreeCPU, c.Workers, info.FreeGPUs, info.FreeCPU, info.Workers)
+		}
+		if (c.HasReason == "" && info.Reason != "") || !strings.Contains(info.Reason, c.HasReason) {
+			t.Errorf("%s: Expected reason %q; Got %q", c.Name, c.HasReason, info.Reason)
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 	"text/tabwriter"
 
+	"github.com/kubeflow/arena/pkg/apis/config"
 	"github.com/kubeflow/arena/pkg/apis/types"
 	"github.com/kubeflow/arena/pkg/apis/utils"
 	v1 "k8s.io/api/core/v1"
@@ -16,6 +17,11 @@ var GPUExclusiveNodeDescription = `
   2.Pods can request resource 'nvidia.com/gpu' to use gpu exclusive feature on this node
 `
 
+var acceleratorExclusiveNodeDescription = `
+  1.This node has accelerators %v, they are allocated exclusively.
+  2.Pods can request resource '%v' to use the accelerators on this node
+`
+
 var gpuExclusiveTemplate = `
 Name:    %v
 Status:  %v
@@ -38,18 +44,20 @@ GPU Summary:
 `
 
 type gpuexclusive struct {
-	node       *v1.Node
-	pods       []*v1.Pod
-	gpuMetrics types.NodeGpuMetric
+	node        *v1.Node
+	pods        []*v1.Pod
+	gpuMetrics  types.NodeGpuMetric
+	accelerator types.Accelerator
 	baseNode
 }
 
 func NewGPUExclusiveNode(client *kubernetes.Clientset, node *v1.Node, index int, args buildNodeArgs) (Node, error) {
 	pods := getNodePods(node, args.pods)
 	return &gpuexclusive{
-		node:       node,
-		pods:       pods,
-		gpuMetrics: getGPUMetricsByNodeName(node.Name, args.nodeGPUMetrics),
+		node:        node,
+		pods:        pods,
+		gpuMetrics:  getGPUMetricsByNodeName(node.Name, args.nodeGPUMetrics),
+		accelerator: nodeAccelerator(node),
 		baseNode: baseNode{
 			index:    index,
 			node:     node,
@@ -67,7 +75,7 @@ func (g *gpuexclusive) getTotalGPUs() float64 {
 	if len(g.gpuMetrics) != 0 {
 		return float64(len(g.gpuMetrics))
 	}
-	val, ok := g.node.Status.Capacity[v1.ResourceName(types.NvidiaGPUResourceName)]
+	val, ok := g.node.Status.Capacity[v1.ResourceName(g.accelerator.ResourceName)]
 	if !ok {
 		return 0
 	}
@@ -147,7 +155,7 @@ func (g *gpuexclusive) getDutyCycle() float64 {
 
 func (g *gpuexclusive) getUnhealthyGPUs() float64 {
 	totalGPUs := g.getTotalGPUs()
-	allocatableGPUs, ok := g.node.Status.Allocatable[v1.ResourceName(types.NvidiaGPUResourceName)]
+	allocatableGPUs, ok := g.node.Status.Allocatable[v1.ResourceName(g.accelerator.ResourceName)]
 	if !ok {
 		return 0
 	}
@@ -176,8 +184,9 @@ func (g *gpuexclusive) convert2NodeInfo() types.GPUExclusiveNodeInfo {
 			IP:          g.IP(),
 			Status:      g.Status(),
 			Type:        types.GPUExclusiveNode,
-			Description: GPUExclusiveNodeDescription,
+			Description: g.description(),
 		},
+		Accelerator: g.accelerator.DisplayName,
 		CommonGPUNodeInfo: types.CommonGPUNodeInfo{
 			TotalGPUs:     g.getTotalGPUs(),
 			UnhealthyGPUs: g.getUnhealthyGPUs(),
@@ -206,6 +215,13 @@ func (g *gpuexclusive) convert2NodeInfo() types.GPUExclusiveNodeInfo {
 	return gpuExclusiveInfo
 }
 
+func (g *gpuexclusive) description() string {
+	if g.accelerator.ResourceName == types.NvidiaGPUResourceName {
+		return GPUExclusiveNodeDescription
+	}
+	return fmt.Sprintf(acceleratorExclusiveNodeDescription, g.accelerator.DisplayName, g.accelerator.ResourceName)
+}
+
 func (g *gpuexclusive) AllDevicesAreHealthy() bool {
 	return g.getUnhealthyGPUs() == 0
 }
@@ -356,11 +372,23 @@ func (g *gpuexclusive) displayDeviceInfoUnderMetrics(lines []string, nodeInfo ty
 }
 
 func IsGPUExclusiveNode(node *v1.Node) bool {
-	val, ok := node.Status.Allocatable[v1.ResourceName(types.NvidiaGPUResourceName)]
-	if !ok {
-		return false
+	for _, accelerator := range config.GetAccelerators() {
+		val, ok := node.Status.Allocatable[v1.ResourceName(accelerator.ResourceName)]
+		if ok && int(val.Value()) > 0 && int(val.Value()) <= 100 {
+			return true
+		}
+	}
+	return false
+}
+
+// nodeAccelerator returns the first registered accelerator which the node owns
+func nodeAccelerator(node *v1.Node) types.Accelerator {
+	for _, accelerator := range config.GetAccelerators() {
+		if val, ok := node.Status.Allocatable[v1.ResourceName(accelerator.ResourceName)]; ok && val.Value() > 0 {
+			return accelerator
+		}
 	}
-	return int(val.Value()) > 0 && int(val.Value()) <= 100
+	return types.NvidiaGPUAccelerator
 }
 
 /*
@@ -378,9 +406,11 @@ UsedGPUs:      1/1(100.0%)
 UnhealthyGPUs: 0/1
 
 Instances:
-  NAMESPACE  NAME                          GPU(Requested)
-  ---------  ----                          --------------
-  default    tf-standalone-test-1-chief-0  1
+
+	NAMESPACE  NAME                          GPU(Requested)
+	---------  ----                          --------------
+	default    tf-standalone-test-1-chief-0  1
+
 -----------------------------------------------------------------------------------------
 Allocated/Total GPUs In Cluster: 1/1(100.0%)
 
@@ -443,10 +473,20 @@ func displayGPUExclusiveNodesCustomSummary(w *tabwriter.Writer, nodes []Node) {
 	}
 	header := []string{"NAME", "IPADDRESS", "ROLE", "STATUS", "GPU(Total)", "GPU(Allocated)"}
 	isUnhealthy := false
+	resourceNames := []string{}
 	for _, node := range nodes {
 		if !node.AllDevicesAreHealthy() {
 			isUnhealthy = true
 		}
+		resourceName := node.(*gpuexclusive).accelerator.ResourceName
+		if !containsString(resourceNames, resourceName) {
+			resourceNames = append(resourceNames, resourceName)
+		}
+	}
+	// the accelerators are displayed if the nodes own different accelerators
+	showAccelerator := len(resourceNames) > 1
+	if showAccelerator {
+		header = append(header, "ACCELERATOR")
 	}
 	if isUnhealthy {
 		header = append(header, "UNHEALTHY")
@@ -471,13 +511,16 @@ func displayGPUExclusiveNodesCustomSummary(w *tabwriter.Writer, nodes []Node) {
 		items = append(items, node.Status())
 		items = append(items, fmt.Sprintf("%v", nodeInfo.TotalGPUs))
 		items = append(items, fmt.Sprintf("%v", nodeInfo.AllocatedGPUs))
+		if showAccelerator {
+			items = append(items, nodeInfo.Accelerator)
+		}
 		if isUnhealthy {
 			items = append(items, fmt.Sprintf("%v", nodeInfo.UnhealthyGPUs))
 		}
 		PrintLine(w, items...)
 	}
 	PrintLine(w, "---------------------------------------------------------------------------------------------------")
-	PrintLine(w, "Allocated/Total GPUs of nodes which own resource nvidia.com/gpu In Cluster:")
+	PrintLine(w, fmt.Sprintf("Allocated/Total GPUs of nodes which own resource %v In Cluster:", strings.Join(resourceNames, ",")))
 	allocatedPercent := float64(0)
 	if totalGPUs != 0 {
 		allocatedPercent = float64(allocatedGPUs) / float64(totalGPUs) * 100
@@ -488,7 +531,7 @@ func displayGPUExclusiveNodesCustomSummary(w *tabwriter.Writer, nodes []Node) {
 	}
 	PrintLine(w, fmt.Sprintf("%v/%v (%.1f%%)", allocatedGPUs, totalGPUs, allocatedPercent))
 	if unhealthyGPUs != 0 {
-		PrintLine(w, "Unhealthy/Total GPUs of nodes which own resource nvidia.com/gpu In Cluster:")
+		PrintLine(w, fmt.Sprintf("Unhealthy/Total GPUs of nodes which own resource %v In Cluster:", strings.Join(resourceNames, ",")))
 		PrintLine(w, fmt.Sprintf("%v/%v (%.1f%%)", unhealthyGPUs, totalGPUs, unhealthyPercent))
 	}
 }
@@ -504,3 +547,12 @@ func NewGPUExclusiveNodeProcesser() NodeProcesser {
 		displayNodesCustomSummary: displayGPUExclusiveNodesCustomSummary,
 	}
 }
+
+func containsString(items []string, item string) bool {
+	for _, i := range items {
+		if i == item {
+			return true
+		}
+	}
+	return false
+}
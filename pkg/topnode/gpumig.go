@@ -0,0 +1,471 @@
+package topnode
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/kubeflow/arena/pkg/apis/types"
+	"github.com/kubeflow/arena/pkg/apis/utils"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+var GPUMIGNodeDescription = `
+  1.This node is enabled nvidia multi-instance gpu(MIG) with strategy mixed.
+  2.Pods can request resource 'nvidia.com/mig-<profile>' (like nvidia.com/mig-1g.10gb) to use a gpu instance on this node
+`
+
+var gpuMIGTemplate = `
+Name:    %v
+Status:  %v
+Role:    %v
+Type:    %v
+Address: %v
+Description:
+%v
+%v
+`
+
+// migComputeSlices is the count of compute slices of a physical gpu, the A30 has 4 slices
+// and the A100 and H100 have 7 slices
+const (
+	migComputeSlices      = 7
+	migComputeSlicesOfA30 = 4
+)
+
+type gpumig struct {
+	node       *v1.Node
+	pods       []*v1.Pod
+	migDevices []types.MIGDeviceMetric
+	baseNode
+}
+
+func NewGPUMIGNode(client *kubernetes.Clientset, node *v1.Node, index int, args buildNodeArgs) (Node, error) {
+	pods := getNodePods(node, args.pods)
+	return &gpumig{
+		node:       node,
+		pods:       pods,
+		migDevices: args.nodeMIGDevices[node.Name],
+		baseNode: baseNode{
+			index:    index,
+			node:     node,
+			pods:     pods,
+			nodeType: types.GPUMIGNode,
+		},
+	}, nil
+}
+
+// computeSlices returns the count of compute slices of the physical gpu on the node
+func (g *gpumig) computeSlices() float64 {
+	if strings.Contains(g.node.Labels[types.MIGGPUProductLabel], "A30") {
+		return migComputeSlicesOfA30
+	}
+	return migComputeSlices
+}
+
+// gpuFractionOfProfile returns the fraction of a physical gpu which a gpu instance of the profile takes,
+// the profile 3g.40gb takes 3 compute slices
+func (g *gpumig) gpuFractionOfProfile(profile string) float64 {
+	slices, err := strconv.Atoi(strings.SplitN(profile, "g.", 2)[0])
+	if err != nil || slices <= 0 {
+		return 0
+	}
+	return float64(slices) / g.computeSlices()
+}
+
+func (g *gpumig) getTotalGPUs() float64 {
+	if val, err := strconv.Atoi(g.node.Labels[types.MIGGPUCountLabel]); err == nil && val > 0 {
+		return float64(val)
+	}
+	devices := map[string]bool{}
+	for _, dev := range g.migDevices {
+		devices[dev.GPUId] = true
+	}
+	if len(devices) != 0 {
+		return float64(len(devices))
+	}
+	// guess the physical gpus by the gpu instances if gpu feature discovery is not deployed
+	total := float64(0)
+	for name, val := range g.node.Status.Capacity {
+		if profile, ok := migProfileOfResource(name); ok {
+			total += g.gpuFractionOfProfile(profile) * float64(val.Value())
+		}
+	}
+	if val, ok := g.node.Status.Capacity[v1.ResourceName(types.NvidiaGPUResourceName)]; ok {
+		total += float64(val.Value())
+	}
+	return math.Ceil(total)
+}
+
+func (g *gpumig) getAllocatedGPUs() float64 {
+	allocatedGPUs := float64(0)
+	for _, pod := range g.pods {
+		if utils.IsCompletedPod(pod) {
+			continue
+		}
+		for profile, count := range migDevicesInPod(pod) {
+			allocatedGPUs += g.gpuFractionOfProfile(profile) * float64(count)
+		}
+		allocatedGPUs += float64(utils.GPUCountInPod(pod))
+	}
+	return math.Round(allocatedGPUs*100) / 100
+}
+
+func (g *gpumig) getUnhealthyGPUs() float64 {
+	unhealthyGPUs := float64(0)
+	for name, capacity := range g.node.Status.Capacity {
+		profile, ok := migProfileOfResource(name)
+		if !ok {
+			continue
+		}
+		allocatable := g.node.Status.Allocatable[name]
+		unhealthyGPUs += g.gpuFractionOfProfile(profile) * float64(capacity.Value()-allocatable.Value())
+	}
+	return math.Round(unhealthyGPUs*100) / 100
+}
+
+func (g *gpumig) getProfiles() []types.GPUMIGProfileInfo {
+	allocated := map[string]int{}
+	for _, pod := range g.pods {
+		if utils.IsCompletedPod(pod) {
+			continue
+		}
+		for profile, count := range migDevicesInPod(pod) {
+			allocated[profile] += count
+		}
+	}
+	profiles := []types.GPUMIGProfileInfo{}
+	for name, val := range g.node.Status.Allocatable {
+		profile, ok := migProfileOfResource(name)
+		if !ok {
+			continue
+		}
+		profiles = append(profiles, types.GPUMIGProfileInfo{
+			Profile:   profile,
+			Total:     int(val.Value()),
+			Allocated: allocated[profile],
+		})
+	}
+	sort.Slice(profiles, func(i, j int) bool {
+		return profiles[i].Profile < profiles[j].Profile
+	})
+	return profiles
+}
+
+// getDevices groups the gpu instances reported by the gpu exporter by the physical gpus
+func (g *gpumig) getDevices() []types.GPUMIGDevice {
+	devices := map[string]*types.GPUMIGDevice{}
+	for _, dev := range g.migDevices {
+		if devices[dev.GPUId] == nil {
+			devices[dev.GPUId] = &types.GPUMIGDevice{Id: dev.GPUId, Slices: []types.GPUMIGSlice{}}
+		}
+		slice := types.GPUMIGSlice{
+			Profile:    dev.Profile,
+			InstanceId: dev.InstanceId,
+		}
+		if dev.PodName != "" {
+			slice.Pod = fmt.Sprintf("%v/%v", dev.PodNamespace, dev.PodName)
+		}
+		devices[dev.GPUId].Slices = append(devices[dev.GPUId].Slices, slice)
+	}
+	result := []types.GPUMIGDevice{}
+	for _, dev := range devices {
+		sort.Slice(dev.Slices, func(i, j int) bool {
+			return dev.Slices[i].InstanceId < dev.Slices[j].InstanceId
+		})
+		result = append(result, *dev)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Id < result[j].Id
+	})
+	return result
+}
+
+func (g *gpumig) convert2NodeInfo() types.GPUMIGNodeInfo {
+	podInfos := []types.GPUMIGPodInfo{}
+	gpuMIGInfo := types.GPUMIGNodeInfo{
+		CommonNodeInfo: types.CommonNodeInfo{
+			Name:        g.Name(),
+			IP:          g.IP(),
+			Status:      g.Status(),
+			Type:        types.GPUMIGNode,
+			Description: GPUMIGNodeDescription,
+		},
+		CommonGPUNodeInfo: types.CommonGPUNodeInfo{
+			TotalGPUs:     g.getTotalGPUs(),
+			UnhealthyGPUs: g.getUnhealthyGPUs(),
+			AllocatedGPUs: g.getAllocatedGPUs(),
+			GPUMetrics:    []*types.AdvancedGpuMetric{},
+		},
+		Profiles: g.getProfiles(),
+		Devices:  g.getDevices(),
+	}
+	for _, pod := range g.pods {
+		if utils.IsCompletedPod(pod) {
+			continue
+		}
+		devices := migDevicesInPod(pod)
+		if len(devices) == 0 {
+			continue
+		}
+		status, _, _, _ := utils.DefinePodPhaseStatus(*pod)
+		podInfos = append(podInfos, types.GPUMIGPodInfo{
+			Name:              pod.Name,
+			Namespace:         pod.Namespace,
+			Status:            status,
+			RequestMIGDevices: devices,
+		})
+	}
+	gpuMIGInfo.PodInfos = podInfos
+	return gpuMIGInfo
+}
+
+func (g *gpumig) AllDevicesAreHealthy() bool {
+	return g.getUnhealthyGPUs() == 0
+}
+
+func (g *gpumig) Convert2NodeInfo() interface{} {
+	return g.convert2NodeInfo()
+}
+
+func (g *gpumig) WideFormat() string {
+	role := strings.Join(g.Role(), ",")
+	if role == "" {
+		role = "<none>"
+	}
+	nodeInfo := g.convert2NodeInfo()
+	lines := []string{}
+	lines = g.displayPodInfos(lines, nodeInfo)
+	lines = g.displayProfileInfos(lines, nodeInfo)
+	lines = g.displayDeviceInfos(lines, nodeInfo)
+	return fmt.Sprintf(gpuMIGTemplate,
+		nodeInfo.Name,
+		nodeInfo.Status,
+		role,
+		nodeInfo.Type,
+		nodeInfo.IP,
+		strings.Trim(nodeInfo.Description, "\n"),
+		strings.Join(lines, "\n"),
+	)
+}
+
+func (g *gpumig) displayPodInfos(lines []string, nodeInfo types.GPUMIGNodeInfo) []string {
+	podLines := []string{"Instances:", "  NAMESPACE\tNAME\tSTATUS\tMIG(Requested)"}
+	podLines = append(podLines, "  ---------\t----\t------\t--------------")
+	for _, podInfo := range nodeInfo.PodInfos {
+		podLines = append(podLines, fmt.Sprintf("  %v\t%v\t%v\t%v", podInfo.Namespace, podInfo.Name, podInfo.Status, formatMIGDevices(podInfo.RequestMIGDevices)))
+	}
+	if len(podLines) == 3 {
+		podLines = []string{}
+	}
+	lines = append(lines, podLines...)
+	return lines
+}
+
+func (g *gpumig) displayProfileInfos(lines []string, nodeInfo types.GPUMIGNodeInfo) []string {
+	profileLines := []string{"MIG Profiles:", "  PROFILE\tTOTAL\tALLOCATED\tFREE"}
+	profileLines = append(profileLines, "  -------\t-----\t---------\t----")
+	for _, profile := range nodeInfo.Profiles {
+		free := profile.Total - profile.Allocated
+		if free < 0 {
+			free = 0
+		}
+		profileLines = append(profileLines, fmt.Sprintf("  %v\t%v\t%v\t%v", profile.Profile, profile.Total, profile.Allocated, free))
+	}
+	lines = append(lines, profileLines...)
+	return lines
+}
+
+// displayDeviceInfos displays the gpu instances of each physical gpu, which is only known by the gpu metrics
+func (g *gpumig) displayDeviceInfos(lines []string, nodeInfo types.GPUMIGNodeInfo) []string {
+	if len(nodeInfo.Devices) != 0 {
+		deviceLines := []string{"GPUs:", "  INDEX\tPROFILE\tINSTANCE\tPOD"}
+		deviceLines = append(deviceLines, "  -----\t-------\t--------\t---")
+		for _, dev := range nodeInfo.Devices {
+			for _, slice := range dev.Slices {
+				pod := slice.Pod
+				if pod == "" {
+					pod = "<free>"
+				}
+				deviceLines = append(deviceLines, fmt.Sprintf("  %v\t%v\t%v\t%v", dev.Id, slice.Profile, slice.InstanceId, pod))
+			}
+		}
+		lines = append(lines, deviceLines...)
+	}
+	lines = append(lines, "GPU Summary:")
+	lines = append(lines, fmt.Sprintf("  Total GPUs:     %v", nodeInfo.TotalGPUs))
+	lines = append(lines, fmt.Sprintf("  Allocated GPUs: %v", nodeInfo.AllocatedGPUs))
+	lines = append(lines, fmt.Sprintf("  Unhealthy GPUs: %v", nodeInfo.UnhealthyGPUs))
+	return lines
+}
+
+// migProfileOfResource returns the mig profile of resource, like 1g.10gb of nvidia.com/mig-1g.10gb
+func migProfileOfResource(name v1.ResourceName) (string, bool) {
+	if !strings.HasPrefix(string(name), types.MIGResourceNamePrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(string(name), types.MIGResourceNamePrefix), true
+}
+
+// migDevicesInPod returns the count of gpu instances requested by the containers of pod for each profile
+func migDevicesInPod(pod *v1.Pod) map[string]int {
+	devices := map[string]int{}
+	for _, container := range pod.Spec.Containers {
+		resources := container.Resources.Limits
+		if len(resources) == 0 {
+			resources = container.Resources.Requests
+		}
+		for name, val := range resources {
+			if profile, ok := migProfileOfResource(name); ok {
+				devices[profile] += int(val.Value())
+			}
+		}
+	}
+	return devices
+}
+
+func formatMIGDevices(devices map[string]int) string {
+	items := []string{}
+	for profile, count := range devices {
+		items = append(items, fmt.Sprintf("%vx%v", profile, count))
+	}
+	sort.Strings(items)
+	return strings.Join(items, ",")
+}
+
+func formatMIGProfiles(profiles []types.GPUMIGProfileInfo) string {
+	items := []string{}
+	for _, profile := range profiles {
+		items = append(items, fmt.Sprintf("%v(%v/%v)", profile.Profile, profile.Allocated, profile.Total))
+	}
+	if len(items) == 0 {
+		return "<none>"
+	}
+	return strings.Join(items, ",")
+}
+
+func IsGPUMIGNode(node *v1.Node) bool {
+	for name, val := range node.Status.Capacity {
+		if _, ok := migProfileOfResource(name); ok && val.Value() > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func displayGPUMIGNodeDetails(w *tabwriter.Writer, nodes []Node) {
+	if len(nodes) == 0 {
+		return
+	}
+	for _, node := range nodes {
+		PrintLine(w, node.WideFormat())
+	}
+}
+
+func displayGPUMIGNodeSummary(w *tabwriter.Writer, nodes []Node, isUnhealthy, showNodeType bool) (float64, float64, float64) {
+	totalGPUs := float64(0)
+	allocatedGPUs := float64(0)
+	unhealthyGPUs := float64(0)
+	for _, node := range nodes {
+		nodeInfo := node.Convert2NodeInfo().(types.GPUMIGNodeInfo)
+		totalGPUs += nodeInfo.TotalGPUs
+		allocatedGPUs += nodeInfo.AllocatedGPUs
+		unhealthyGPUs += nodeInfo.UnhealthyGPUs
+		items := []string{}
+		items = append(items, node.Name())
+		items = append(items, node.IP())
+		role := nodeInfo.Role
+		if role == "" {
+			role = "<none>"
+		}
+		items = append(items, role)
+		items = append(items, node.Status())
+		items = append(items, fmt.Sprintf("%v", nodeInfo.TotalGPUs))
+		items = append(items, fmt.Sprintf("%v", nodeInfo.AllocatedGPUs))
+		if showNodeType {
+			for _, typeInfo := range types.NodeTypeSlice {
+				if typeInfo.Name == types.GPUMIGNode {
+					items = append(items, typeInfo.Alias)
+				}
+			}
+		}
+		if isUnhealthy {
+			items = append(items, fmt.Sprintf("%v", nodeInfo.UnhealthyGPUs))
+		}
+		PrintLine(w, items...)
+	}
+	return totalGPUs, allocatedGPUs, unhealthyGPUs
+}
+
+func displayGPUMIGNodesCustomSummary(w *tabwriter.Writer, nodes []Node) {
+	if len(nodes) == 0 {
+		return
+	}
+	header := []string{"NAME", "IPADDRESS", "ROLE", "STATUS", "GPU(Total)", "GPU(Allocated)", "MIG(Allocated/Total)"}
+	isUnhealthy := false
+	for _, node := range nodes {
+		if !node.AllDevicesAreHealthy() {
+			isUnhealthy = true
+		}
+	}
+	if isUnhealthy {
+		header = append(header, "UNHEALTHY")
+	}
+	PrintLine(w, header...)
+	totalGPUs := float64(0)
+	allocatedGPUs := float64(0)
+	unhealthyGPUs := float64(0)
+	for _, node := range nodes {
+		nodeInfo := node.Convert2NodeInfo().(types.GPUMIGNodeInfo)
+		totalGPUs += nodeInfo.TotalGPUs
+		allocatedGPUs += nodeInfo.AllocatedGPUs
+		unhealthyGPUs += nodeInfo.UnhealthyGPUs
+		items := []string{}
+		items = append(items, node.Name())
+		items = append(items, node.IP())
+		role := nodeInfo.Role
+		if role == "" {
+			role = "<none>"
+		}
+		items = append(items, role)
+		items = append(items, node.Status())
+		items = append(items, fmt.Sprintf("%v", nodeInfo.TotalGPUs))
+		items = append(items, fmt.Sprintf("%v", nodeInfo.AllocatedGPUs))
+		items = append(items, formatMIGProfiles(nodeInfo.Profiles))
+		if isUnhealthy {
+			items = append(items, fmt.Sprintf("%v", nodeInfo.UnhealthyGPUs))
+		}
+		PrintLine(w, items...)
+	}
+	PrintLine(w, "---------------------------------------------------------------------------------------------------")
+	PrintLine(w, "Allocated/Total GPUs of nodes which own resource nvidia.com/mig-<profile> In Cluster:")
+	allocatedPercent := float64(0)
+	if totalGPUs != 0 {
+		allocatedPercent = allocatedGPUs / totalGPUs * 100
+	}
+	unhealthyPercent := float64(0)
+	if totalGPUs != 0 {
+		unhealthyPercent = unhealthyGPUs / totalGPUs * 100
+	}
+	PrintLine(w, fmt.Sprintf("%v/%v (%.1f%%)", math.Round(allocatedGPUs*100)/100, totalGPUs, allocatedPercent))
+	if unhealthyGPUs != 0 {
+		PrintLine(w, "Unhealthy/Total GPUs of nodes which own resource nvidia.com/mig-<profile> In Cluster:")
+		PrintLine(w, fmt.Sprintf("%v/%v (%.1f%%)", math.Round(unhealthyGPUs*100)/100, totalGPUs, unhealthyPercent))
+	}
+}
+
+func NewGPUMIGNodeProcesser() NodeProcesser {
+	return &nodeProcesser{
+		nodeType:                  types.GPUMIGNode,
+		key:                       "gpuMIGNodes",
+		builder:                   NewGPUMIGNode,
+		canBuildNode:              IsGPUMIGNode,
+		displayNodesDetails:       displayGPUMIGNodeDetails,
+		displayNodesSummary:       displayGPUMIGNodeSummary,
+		displayNodesCustomSummary: displayGPUMIGNodesCustomSummary,
+	}
+}
@@ -0,0 +1,182 @@
+package topnode
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/kubeflow/arena/pkg/apis/config"
+	"github.com/kubeflow/arena/pkg/apis/types"
+	"github.com/kubeflow/arena/pkg/prometheus"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+	v1 "k8s.io/api/core/v1"
+)
+
+// BuildNodeHealthReport checks the node conditions, the unhealthy gpus reported by the device plugin and
+// the gpu xid errors, ecc errors and thermal throttling in the time window reported by the gpu exporter
+func BuildNodeHealthReport(nodes []Node, window time.Duration) *types.NodeHealthReport {
+	report := &types.NodeHealthReport{
+		Window: window.String(),
+		Nodes:  []types.NodeHealthInfo{},
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Index() < nodes[j].Index()
+	})
+	nodeNames := []string{}
+	for _, node := range nodes {
+		nodeNames = append(nodeNames, node.Name())
+	}
+	client := config.GetArenaConfiger().GetClientSet()
+	metrics, err := prometheus.GetNodeGPUHealthMetrics(client, nodeNames, window)
+	if err != nil {
+		log.Debugf("failed to get the gpu health metrics of nodes,reason: %v", err)
+		metrics = map[string]types.NodeGPUHealthMetrics{}
+	} else {
+		report.GPUMetricsEnabled = true
+	}
+	for _, node := range nodes {
+		report.Nodes = append(report.Nodes, buildNodeHealthInfo(node, metrics[node.Name()]))
+	}
+	return report
+}
+
+func buildNodeHealthInfo(node Node, metrics types.NodeGPUHealthMetrics) types.NodeHealthInfo {
+	info := types.NodeHealthInfo{
+		Name:       node.Name(),
+		Type:       node.Type(),
+		Status:     node.Status(),
+		Conditions: abnormalNodeConditions(node.GetV1Node()),
+		Devices:    []types.GPUDeviceHealth{},
+		Reasons:    []string{},
+	}
+	if gpuInfo, ok := gpuInfoOfNode(node); ok {
+		info.UnhealthyGPUs = gpuInfo.UnhealthyGPUs
+	}
+	for _, device := range metrics {
+		info.Devices = append(info.Devices, *device)
+	}
+	sort.Slice(info.Devices, func(i, j int) bool {
+		return info.Devices[i].Id < info.Devices[j].Id
+	})
+	if len(info.Conditions) != 0 {
+		info.Reasons = append(info.Reasons, fmt.Sprintf("abnormal conditions %v", strings.Join(info.Conditions, ",")))
+	}
+	if info.UnhealthyGPUs != 0 {
+		info.Reasons = append(info.Reasons, fmt.Sprintf("%v unhealthy gpus reported by device plugin", info.UnhealthyGPUs))
+	}
+	for _, device := range info.Devices {
+		if device.XID != 0 {
+			info.Reasons = append(info.Reasons, fmt.Sprintf("gpu %v reported xid %v", device.Id, device.XID))
+		}
+		if device.ECCErrors != 0 {
+			info.Reasons = append(info.Reasons, fmt.Sprintf("gpu %v reported %v uncorrectable ecc errors", device.Id, device.ECCErrors))
+		}
+		if device.ThermalThrottled {
+			info.Reasons = append(info.Reasons, fmt.Sprintf("gpu %v was thermal throttled", device.Id))
+		}
+	}
+	info.Suspicious = len(info.Reasons) != 0
+	return info
+}
+
+// abnormalNodeConditions returns the condition Ready if it is not true, and the other conditions which are true,
+// like MemoryPressure or the conditions reported by node-problem-detector
+func abnormalNodeConditions(node *v1.Node) []string {
+	conditions := []string{}
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == v1.NodeReady {
+			if condition.Status != v1.ConditionTrue {
+				conditions = append(conditions, fmt.Sprintf("%v=%v", condition.Type, condition.Status))
+			}
+			continue
+		}
+		if condition.Status == v1.ConditionTrue {
+			conditions = append(conditions, string(condition.Type))
+		}
+	}
+	return conditions
+}
+
+/*
+format like:
+
+Health metrics in the last 1h0m0s:
+NAME                     STATUS    GPU_MODE   UNHEALTHY  XID       ECC  THERMAL_THROTTLE  CONDITIONS   SUSPICIOUS
+cn-beijing.192.168.8.11  Ready     exclusive  0          gpu3(79)  -    -                 -            true
+cn-beijing.192.168.8.12  Ready     exclusive  0          -         -    gpu0,gpu1         -            true
+cn-beijing.192.168.8.13  NotReady  exclusive  0          -         -    -                 Ready=False  true
+---------------------------------------------------------------------------------------------------
+Suspicious/Total Nodes: 3/3
+*/
+func displayNodeHealthReport(report *types.NodeHealthReport, format types.FormatStyle) {
+	switch format {
+	case types.JsonFormat:
+		data, _ := json.MarshalIndent(report, "", "    ")
+		fmt.Printf("%v", string(data))
+		return
+	case types.YamlFormat:
+		data, _ := yaml.Marshal(report)
+		fmt.Printf("%v", string(data))
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	PrintLine(w, fmt.Sprintf("Health metrics in the last %v:", report.Window))
+	PrintLine(w, "NAME", "STATUS", "GPU_MODE", "UNHEALTHY", "XID", "ECC", "THERMAL_THROTTLE", "CONDITIONS", "SUSPICIOUS")
+	suspicious := 0
+	for _, node := range report.Nodes {
+		if node.Suspicious {
+			suspicious++
+		}
+		xids := []string{}
+		eccs := []string{}
+		throttled := []string{}
+		for _, device := range node.Devices {
+			if device.XID != 0 {
+				xids = append(xids, fmt.Sprintf("gpu%v(%v)", device.Id, device.XID))
+			}
+			if device.ECCErrors != 0 {
+				eccs = append(eccs, fmt.Sprintf("gpu%v(%v)", device.Id, device.ECCErrors))
+			}
+			if device.ThermalThrottled {
+				throttled = append(throttled, fmt.Sprintf("gpu%v", device.Id))
+			}
+		}
+		PrintLine(w,
+			node.Name,
+			node.Status,
+			NodeTypeAlias(node.Type),
+			fmt.Sprintf("%v", node.UnhealthyGPUs),
+			formatHealthItems(xids, report.GPUMetricsEnabled),
+			formatHealthItems(eccs, report.GPUMetricsEnabled),
+			formatHealthItems(throttled, report.GPUMetricsEnabled),
+			formatHealthItems(node.Conditions, true),
+			fmt.Sprintf("%v", node.Suspicious),
+		)
+	}
+	PrintLine(w, "---------------------------------------------------------------------------------------------------")
+	PrintLine(w, fmt.Sprintf("Suspicious/Total Nodes: %v/%v", suspicious, len(report.Nodes)))
+	for _, node := range report.Nodes {
+		if node.Suspicious {
+			PrintLine(w, fmt.Sprintf("  %v: %v", node.Name, strings.Join(node.Reasons, "; ")))
+		}
+	}
+	if !report.GPUMetricsEnabled {
+		PrintLine(w, "The gpu xid errors, ecc errors and thermal throttling are not available, they require prometheus and a gpu exporter like dcgm-exporter.")
+	}
+	_ = w.Flush()
+}
+
+func formatHealthItems(items []string, enabled bool) string {
+	if !enabled {
+		return "N/A"
+	}
+	if len(items) == 0 {
+		return "-"
+	}
+	return strings.Join(items, ",")
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"sort"
 	"text/tabwriter"
 
 	"github.com/kubeflow/arena/pkg/apis/types"
@@ -23,13 +24,20 @@ cn-shanghai.192.168.7.186  192.168.7.186  <none>  Ready   4           0
 cn-shanghai.192.168.7.183  192.168.7.183  <none>  Ready   4           2.1             share
 */
 func DisplayNodeSummary(nodeNames []string, targetNodeType types.NodeType, format types.FormatStyle, showMetric bool) error {
-	totalGPUs := float64(0)
-	allocatedGPUs := float64(0)
-	unhealthyGPUs := float64(0)
 	nodes, err := BuildNodes(nodeNames, targetNodeType, showMetric)
 	if err != nil {
 		return err
 	}
+	displayNodeSummary(nodes, len(nodeNames) == 0, false, format)
+	return nil
+}
+
+// displayNodeSummary displays the nodes, the nodes are printed in the order of index
+// instead of being grouped by node type if sorted is true
+func displayNodeSummary(nodes []Node, showClusterSummary bool, sorted bool, format types.FormatStyle) {
+	totalGPUs := float64(0)
+	allocatedGPUs := float64(0)
+	unhealthyGPUs := float64(0)
 	allNodeInfos := types.AllNodeInfo{}
 	for _, processer := range GetSupportedNodePorcessers() {
 		allNodeInfos = processer.Convert2NodeInfos(nodes, allNodeInfos)
@@ -38,11 +46,11 @@ func DisplayNodeSummary(nodeNames []string, targetNodeType types.NodeType, forma
 	case types.JsonFormat:
 		data, _ := json.MarshalIndent(allNodeInfos, "", "    ")
 		fmt.Printf("%v", string(data))
-		return nil
+		return
 	case types.YamlFormat:
 		data, _ := yaml.Marshal(allNodeInfos)
 		fmt.Printf("%v", string(data))
-		return nil
+		return
 	}
 	var showNodeType bool
 	var isUnhealthy bool
@@ -61,7 +69,7 @@ func DisplayNodeSummary(nodeNames []string, targetNodeType types.NodeType, forma
 			processer.DisplayNodesCustomSummary(w, nodes)
 		}
 		_ = w.Flush()
-		return nil
+		return
 	}
 
 	delete(nodeTypes, types.NormalNode)
@@ -79,16 +87,30 @@ func DisplayNodeSummary(nodeNames []string, targetNodeType types.NodeType, forma
 	}
 	PrintLine(w, header...)
 	processers := GetSupportedNodePorcessers()
-	for i := len(processers) - 1; i >= 0; i-- {
-		processer := processers[i]
-		t, a, u := processer.DisplayNodesSummary(w, nodes, showNodeType, isUnhealthy)
-		totalGPUs += t
-		allocatedGPUs += a
-		unhealthyGPUs += u
+	if sorted {
+		sort.Slice(nodes, func(i, j int) bool {
+			return nodes[i].Index() < nodes[j].Index()
+		})
+		for _, node := range nodes {
+			for _, processer := range processers {
+				t, a, u := processer.DisplayNodesSummary(w, []Node{node}, showNodeType, isUnhealthy)
+				totalGPUs += t
+				allocatedGPUs += a
+				unhealthyGPUs += u
+			}
+		}
+	} else {
+		for i := len(processers) - 1; i >= 0; i-- {
+			processer := processers[i]
+			t, a, u := processer.DisplayNodesSummary(w, nodes, showNodeType, isUnhealthy)
+			totalGPUs += t
+			allocatedGPUs += a
+			unhealthyGPUs += u
+		}
 	}
-	if len(nodeNames) != 0 {
+	if !showClusterSummary {
 		_ = w.Flush()
-		return nil
+		return
 	}
 	PrintLine(w, "---------------------------------------------------------------------------------------------------")
 	PrintLine(w, "Allocated/Total GPUs In Cluster:")
@@ -106,5 +128,4 @@ func DisplayNodeSummary(nodeNames []string, targetNodeType types.NodeType, forma
 		PrintLine(w, fmt.Sprintf("%v/%v (%.1f%%)", unhealthyGPUs, totalGPUs, unhealthyPercent))
 	}
 	_ = w.Flush()
-	return nil
 }
@@ -0,0 +1,233 @@
+package topnode
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/kubeflow/arena/pkg/apis/types"
+	"github.com/kubeflow/arena/pkg/apis/utils"
+	log "github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+)
+
+// clearScreen moves the cursor to the top left and clears the terminal
+const clearScreen = "\033[H\033[2J"
+
+// DisplayNodes displays the nodes selected by args once
+func DisplayNodes(args *types.TopNodeArgs, format types.FormatStyle) error {
+	nodes, health, err := buildTopNodes(args)
+	if err != nil {
+		return err
+	}
+	displayTopNodes(nodes, health, args, format)
+	return nil
+}
+
+// WatchNodes redraws the nodes selected by args in place every interval, it never returns
+func WatchNodes(args *types.TopNodeArgs, format types.FormatStyle) error {
+	for {
+		// build the nodes before clearing the screen, querying prometheus may take a while
+		nodes, health, err := buildTopNodes(args)
+		fmt.Print(clearScreen)
+		if err != nil {
+			log.Errorf("failed to display nodes,reason: %v", err)
+		} else {
+			displayTopNodes(nodes, health, args, format)
+		}
+		fmt.Printf("\nEvery %v, last updated at %v\n", args.Interval, time.Now().Format("2006-01-02 15:04:05"))
+		time.Sleep(args.Interval)
+	}
+}
+
+// buildTopNodes builds the nodes, the health report is only built if it is required
+func buildTopNodes(args *types.TopNodeArgs) ([]Node, *types.NodeHealthReport, error) {
+	nodes, err := BuildNodesBySelector(args.NodeNames, args.Selector, args.NodeType, args.ShowMetric)
+	if err != nil {
+		return nil, nil, err
+	}
+	sortNodes(nodes, args.SortBy)
+	if !args.ShowHealth {
+		return nodes, nil, nil
+	}
+	return nodes, BuildNodeHealthReport(nodes, args.HealthWindow), nil
+}
+
+func displayTopNodes(nodes []Node, health *types.NodeHealthReport, args *types.TopNodeArgs, format types.FormatStyle) {
+	if health != nil {
+		displayNodeHealthReport(health, format)
+	} else if args.ShowDetails {
+		displayNodeDetails(nodes, format)
+	} else {
+		showClusterSummary := len(args.NodeNames) == 0 && args.Selector == ""
+		displayNodeSummary(nodes, showClusterSummary, args.SortBy != "", format)
+	}
+	if args.ShowPods && format == types.WideFormat {
+		displayNodePods(nodes)
+	}
+}
+
+// sortNodes sorts the nodes and resets their indexes, so the nodes grouped by node type keep the order
+func sortNodes(nodes []Node, sortBy types.NodeSortBy) {
+	if sortBy == "" {
+		return
+	}
+	sort.SliceStable(nodes, func(i, j int) bool {
+		switch sortBy {
+		case types.NodeSortByGPUFree:
+			a, b := NodeFreeGPUs(nodes[i]), NodeFreeGPUs(nodes[j])
+			if a != b {
+				return a > b
+			}
+		case types.NodeSortByGPUUtil:
+			a, b := gpuUtilForSort(nodes[i]), gpuUtilForSort(nodes[j])
+			if a != b {
+				return a > b
+			}
+		}
+		return nodes[i].Name() < nodes[j].Name()
+	})
+	for index, node := range nodes {
+		node.SetIndex(index)
+	}
+}
+
+func gpuInfoOfNode(node Node) (types.CommonGPUNodeInfo, bool) {
+	switch nodeInfo := node.Convert2NodeInfo().(type) {
+	case types.GPUShareNodeInfo:
+		return nodeInfo.CommonGPUNodeInfo, true
+	case types.GPUTopologyNodeInfo:
+		return nodeInfo.CommonGPUNodeInfo, true
+	case types.GPUMIGNodeInfo:
+		return nodeInfo.CommonGPUNodeInfo, true
+	case types.GPUExclusiveNodeInfo:
+		return nodeInfo.CommonGPUNodeInfo, true
+	}
+	return types.CommonGPUNodeInfo{}, false
+}
+
+// NodeTotalGPUs returns the total gpus of node, it is 0 for the nodes without gpus
+func NodeTotalGPUs(node Node) float64 {
+	info, _ := gpuInfoOfNode(node)
+	return info.TotalGPUs
+}
+
+// NodeFreeGPUs returns the gpus which are neither allocated nor unhealthy, the allocated gpus on
+// gpushare nodes are counted by the fraction of gpu memory
+func NodeFreeGPUs(node Node) float64 {
+	info, ok := gpuInfoOfNode(node)
+	if !ok {
+		return 0
+	}
+	return info.TotalGPUs - info.AllocatedGPUs - info.UnhealthyGPUs
+}
+
+// gpuUtilForSort returns the average duty cycle of gpus, the nodes without gpu metrics are placed at last
+func gpuUtilForSort(node Node) float64 {
+	info, ok := gpuInfoOfNode(node)
+	if !ok || len(info.GPUMetrics) == 0 {
+		return -1
+	}
+	total := float64(0)
+	for _, metric := range info.GPUMetrics {
+		total += metric.GpuDutyCycle
+	}
+	return total / float64(len(info.GPUMetrics))
+}
+
+/*
+format like:
+
+NODE                       NAMESPACE  JOB         TYPE   INSTANCE                    GPU(Requested)  GPU(Index)
+cn-shanghai.192.168.7.186  default    tf-dist     tfjob  tf-dist-worker-0            2               0,1
+cn-shanghai.192.168.7.183  default    share-test  tfjob  share-test-worker-0         4GiB            1
+*/
+func displayNodePods(nodes []Node) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	PrintLine(w, "")
+	PrintLine(w, "NODE", "NAMESPACE", "JOB", "TYPE", "INSTANCE", "GPU(Requested)", "GPU(Index)")
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Index() < nodes[j].Index()
+	})
+	for _, node := range nodes {
+		pods := node.GetV1Pods()
+		sort.Slice(pods, func(i, j int) bool {
+			return pods[i].Namespace+"/"+pods[i].Name < pods[j].Namespace+"/"+pods[j].Name
+		})
+		for _, pod := range pods {
+			if utils.IsCompletedPod(pod) {
+				continue
+			}
+			jobName, jobType, ok := arenaJobOfPod(pod)
+			if !ok {
+				continue
+			}
+			requested, index := gpusOfPod(node.Type(), pod)
+			PrintLine(w, node.Name(), pod.Namespace, jobName, jobType, pod.Name, requested, index)
+		}
+	}
+	_ = w.Flush()
+}
+
+// arenaJobOfPod returns the name and type of the training job or serving job which the pod belongs to,
+// the label release is set by all helm charts, so only the pods labeled by arena charts are matched
+func arenaJobOfPod(pod *v1.Pod) (string, string, bool) {
+	if name := pod.Labels["servingName"]; name != "" {
+		return name, pod.Labels["servingType"], true
+	}
+	createdBy := pod.Labels["createdBy"]
+	name := pod.Labels["release"]
+	if createdBy == "" || name == "" {
+		return "", "", false
+	}
+	if jobType := pod.Labels["app"]; jobType != "" {
+		return name, jobType, true
+	}
+	return name, createdBy, true
+}
+
+// gpusOfPod returns the requested gpus and the allocated gpu indexes of pod, the indexes
+// are only known on gpushare and gpu topology nodes which record the allocation in pod annotations
+func gpusOfPod(nodeType types.NodeType, pod *v1.Pod) (string, string) {
+	switch nodeType {
+	case types.GPUShareNode:
+		requested := fmt.Sprintf("%vGiB", utils.GPUMemoryCountInPod(pod))
+		if core := utils.GPUCoreCountInPod(pod); core != 0 {
+			requested = fmt.Sprintf("%v(core %v%%)", requested, core)
+		}
+		indexes := []string{}
+		for id := range utils.GetPodAllocation(pod) {
+			indexes = append(indexes, id)
+		}
+		for id := range utils.GetPodGPUCoreAllocation(pod) {
+			if !containsString(indexes, id) {
+				indexes = append(indexes, id)
+			}
+		}
+		return requested, formatGPUIndexes(indexes)
+	case types.GPUTopologyNode:
+		return fmt.Sprintf("%v", utils.GPUCountInPod(pod)), formatGPUIndexes(utils.GetPodGPUTopologyAllocation(pod))
+	case types.GPUMIGNode:
+		if devices := migDevicesInPod(pod); len(devices) != 0 {
+			return formatMIGDevices(devices), "N/A"
+		}
+	}
+	return fmt.Sprintf("%v", utils.GPUCountInPod(pod)), "N/A"
+}
+
+func formatGPUIndexes(indexes []string) string {
+	items := []string{}
+	for _, index := range indexes {
+		if index = strings.TrimSpace(index); index != "" {
+			items = append(items, index)
+		}
+	}
+	if len(items) == 0 {
+		return "N/A"
+	}
+	sort.Strings(items)
+	return strings.Join(items, ",")
+}
@@ -155,27 +155,56 @@ func getTrainingJobsByName(name, namespace string) (jobs []TrainingJob, err erro
 }
 
 func PrintTrainingJob(job TrainingJob, format string, showEvents bool, showGPUs bool) {
+	printTrainingJob(job, format, showEvents, showGPUs, false)
+}
+
+// PrintTrainingJobWithGPUHistory prints the training job with the gpu utilization and memory
+// of instances over the whole run
+func PrintTrainingJobWithGPUHistory(job TrainingJob, format string, showEvents bool, showGPUs bool) {
+	printTrainingJob(job, format, showEvents, showGPUs, true)
+}
+
+func printTrainingJob(job TrainingJob, format string, showEvents bool, showGPUs bool, showGPUHistory bool) {
 	services, nodes := PrepareServicesAndNodesForTensorboard([]TrainingJob{job}, false)
+	buildJobInfo := func() *types.TrainingJobInfo {
+		jobInfo := BuildJobInfo(job, showGPUs, services, nodes)
+		if showGPUHistory {
+			history, err := GetTrainingJobGPUHistory(job, 0)
+			if err != nil {
+				log.Warnf("failed to get the gpu history of job %v,reason: %v", job.Name(), err)
+			}
+			jobInfo.GPUHistory = history
+		}
+		// the gpu faults may be the reason of failure
+		if jobInfo.Status == types.TrainingJobFailed {
+			xidErrors, err := GetTrainingJobXIDErrors(job)
+			if err != nil {
+				log.Debugf("failed to get the gpu xid errors of job %v,reason: %v", job.Name(), err)
+			}
+			jobInfo.XIDErrors = xidErrors
+		}
+		return jobInfo
+	}
 	switch format {
 	case "name":
 		fmt.Println(job.Name())
 		// for future CRD support
 	case "json":
-		outBytes, err := json.MarshalIndent(BuildJobInfo(job, showGPUs, services, nodes), "", "    ")
+		outBytes, err := json.MarshalIndent(buildJobInfo(), "", "    ")
 		if err != nil {
 			fmt.Printf("Failed due to %v", err)
 		} else {
 			fmt.Printf(string(outBytes))
 		}
 	case "yaml":
-		outBytes, err := yaml.Marshal(BuildJobInfo(job, showGPUs, services, nodes))
+		outBytes, err := yaml.Marshal(buildJobInfo())
 		if err != nil {
 			fmt.Printf("Failed due to %v", err)
 		} else {
 			fmt.Printf(string(outBytes))
 		}
 	case "wide", "":
-		printSingleJobHelper(BuildJobInfo(job, showGPUs, services, nodes), job.Resources(), showEvents, showGPUs)
+		printSingleJobHelper(buildJobInfo(), job.Resources(), showEvents, showGPUs)
 		job.Resources()
 	default:
 		log.Fatalf("Unknown output format: %s", format)
@@ -217,6 +246,20 @@ func printSingleJobHelper(job *types.TrainingJobInfo, resouce []Resource, showEv
 	if job.Status != types.TrainingJobSucceeded {
 		lines = displayGPUUsage(lines, job.Status, totalAllocatedGPUs, totalRequestGPUs, job.Instances, showGPU)
 	}
+	if len(job.XIDErrors) != 0 {
+		lines = append(lines, "", "Warnings:")
+		lines = append(lines, "  The nodes of the job reported gpu xid errors during its lifetime, the job may fail due to gpu faults:")
+		lines = append(lines, "  NODE\tGPU\tXID\tTIME")
+		lines = append(lines, "  ----\t---\t---\t----")
+		for _, xidError := range job.XIDErrors {
+			lines = append(lines, fmt.Sprintf("  %v\t%v\t%v\t%v",
+				xidError.NodeName,
+				xidError.GPUId,
+				xidError.XID,
+				util.GetFormatTime(xidError.Time),
+			))
+		}
+	}
 	if job.Tensorboard != "" {
 		lines = append(lines, "", "Tensorboard:")
 		lines = append(lines, "  Your tensorboard will be available on: ")
@@ -226,6 +269,9 @@ func printSingleJobHelper(job *types.TrainingJobInfo, resouce []Resource, showEv
 	if job.ChiefName != "" {
 		chiefPodNamespace = job.Namespace
 	}
+	if job.GPUHistory != nil {
+		lines = append(lines, gpuHistoryLines(job.GPUHistory)...)
+	}
 	if showEvents {
 		lines = printEvents(lines, chiefPodNamespace, resouce)
 	}
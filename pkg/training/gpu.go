@@ -17,6 +17,7 @@ package training
 import (
 	"strconv"
 
+	"github.com/kubeflow/arena/pkg/apis/config"
 	"github.com/kubeflow/arena/pkg/apis/types"
 	"github.com/kubeflow/arena/pkg/apis/utils"
 	log "github.com/sirupsen/logrus"
@@ -33,26 +34,40 @@ func gpuPods(pods []v1.Pod) (podsWithGPU []v1.Pod) {
 	return podsWithGPU
 }
 
-// The way to get total GPU Count of Node: nvidia.com/gpu
+// The way to get total GPU Count of Node: nvidia.com/gpu and the resources of registered accelerators
 func totalGpuInNode(node v1.Node) int64 {
-	val, ok := node.Status.Capacity[NVIDIAGPUResourceName]
+	val, ok := acceleratorInResourceList(node.Status.Capacity)
 
 	if !ok {
 		return gpuInNodeDeprecated(node)
 	}
 
-	return val.Value()
+	return val
 }
 
-// The way to get allocatble GPU Count of Node: nvidia.com/gpu
+// The way to get allocatble GPU Count of Node: nvidia.com/gpu and the resources of registered accelerators
 func allocatableGpuInNode(node v1.Node) int64 {
-	val, ok := node.Status.Allocatable[NVIDIAGPUResourceName]
+	val, ok := acceleratorInResourceList(node.Status.Allocatable)
 
 	if !ok {
 		return gpuInNodeDeprecated(node)
 	}
 
-	return val.Value()
+	return val
+}
+
+// acceleratorInResourceList returns the sum of the resources of registered accelerators,
+// false is returned if none of them is found
+func acceleratorInResourceList(resources v1.ResourceList) (int64, bool) {
+	total := int64(0)
+	found := false
+	for _, accelerator := range config.GetAccelerators() {
+		if val, ok := resources[v1.ResourceName(accelerator.ResourceName)]; ok {
+			total += val.Value()
+			found = true
+		}
+	}
+	return total, found
 }
 
 // The way to get GPU Count of Node: alpha.kubernetes.io/nvidia-gpu
@@ -111,13 +126,13 @@ func gpuInActivePod(pod v1.Pod) (gpuCount int64) {
 }
 
 func gpuInContainer(container v1.Container) int64 {
-	val, ok := container.Resources.Limits[NVIDIAGPUResourceName]
+	val, ok := acceleratorInResourceList(container.Resources.Limits)
 
 	if !ok {
 		return gpuInContainerDeprecated(container)
 	}
 
-	return val.Value()
+	return val
 }
 
 func gpuInContainerDeprecated(container v1.Container) int64 {
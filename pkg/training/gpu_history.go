@@ -0,0 +1,248 @@
+package training
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/kubeflow/arena/pkg/apis/config"
+	"github.com/kubeflow/arena/pkg/apis/types"
+	etv1alpha1 "github.com/kubeflow/arena/pkg/operators/et-operator/api/v1alpha1"
+	mpiv1alpha1 "github.com/kubeflow/arena/pkg/operators/mpi-operator/apis/kubeflow/v1alpha1"
+	pytorchv1 "github.com/kubeflow/arena/pkg/operators/pytorch-operator/apis/pytorch/v1"
+	tfv1 "github.com/kubeflow/arena/pkg/operators/tf-operator/apis/tensorflow/v1"
+	"github.com/kubeflow/arena/pkg/prometheus"
+	"github.com/kubeflow/arena/pkg/util"
+	yaml "gopkg.in/yaml.v2"
+)
+
+const (
+	// gpuHistoryMaxSamples limits the samples of each metric returned by the range query
+	gpuHistoryMaxSamples = 200
+	gpuHistoryMinStep    = 15 * time.Second
+	// gpuHistoryTrendWidth is the width of sparkline
+	gpuHistoryTrendWidth = 40
+	// gpuUnderutilizedThreshold is the average gpu utilization under which the job is regarded as underutilized
+	gpuUnderutilizedThreshold = 30
+)
+
+var gpuHistoryTemplate = `
+Name:         %v
+Namespace:    %v
+Trainer:      %v
+Time Range:   %v ~ %v (%v)
+Step:         %v
+%v
+`
+
+// GetTrainingJobGPUHistory returns the gpu utilization and memory of job instances in the time window
+// which ends at now, the whole run of job is used if since is 0
+func GetTrainingJobGPUHistory(job TrainingJob, since time.Duration) (*types.TrainingJobGPUHistory, error) {
+	end := time.Now()
+	var start time.Time
+	if since > 0 {
+		start = end.Add(-since)
+	} else {
+		startTime := job.StartTime()
+		if startTime == nil || startTime.IsZero() {
+			return nil, fmt.Errorf("the training job %s has not started", job.Name())
+		}
+		start = startTime.Time
+		if finished := start.Add(job.Duration()); finished.Before(end) {
+			end = finished
+		}
+	}
+	if !end.After(start) {
+		return nil, fmt.Errorf("invalid time range [%v, %v] of training job %s", start, end, job.Name())
+	}
+	step := (end.Sub(start) / gpuHistoryMaxSamples).Round(time.Second)
+	if step < gpuHistoryMinStep {
+		step = gpuHistoryMinStep
+	}
+
+	nodes := map[string]string{}
+	podNames := []string{}
+	for _, pod := range job.AllPods() {
+		nodes[pod.Name] = pod.Spec.NodeName
+		podNames = append(podNames, pod.Name)
+	}
+	// the pods of finished job may have been cleaned, so the pods are matched by the replica names of job
+	if len(podNames) == 0 {
+		podNames = append(podNames, replicaPodNamePattern(job.Name(), jobReplicaTypes(job)))
+	}
+	client := config.GetArenaConfiger().GetClientSet()
+	samples, err := prometheus.GetPodsGpuHistory(client, []string{job.Namespace()}, podNames, start, end, step)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the gpu history of training job %s: %v", job.Name(), err)
+	}
+
+	history := &types.TrainingJobGPUHistory{
+		Name:      job.Name(),
+		Namespace: job.Namespace(),
+		Trainer:   job.Trainer(),
+		Start:     start.Unix(),
+		End:       end.Unix(),
+		Step:      int64(step.Seconds()),
+		Instances: []types.InstanceGPUHistory{},
+	}
+	instanceNames := []string{}
+	instanceSamples := map[string]map[string]*types.GpuMetricSamples{}
+	for key, gpuSamples := range samples {
+		name := strings.TrimPrefix(key, prometheus.PodKey(job.Namespace(), ""))
+		instanceNames = append(instanceNames, name)
+		instanceSamples[name] = gpuSamples
+	}
+	sort.Strings(instanceNames)
+	totalDutyCycle := float64(0)
+	devices := 0
+	for _, name := range instanceNames {
+		instance := types.InstanceGPUHistory{
+			Name: name,
+			Node: nodes[name],
+			GPUs: []types.GPUDeviceHistory{},
+		}
+		gpuIds := []string{}
+		for id := range instanceSamples[name] {
+			gpuIds = append(gpuIds, id)
+		}
+		sort.Strings(gpuIds)
+		for _, id := range gpuIds {
+			s := instanceSamples[name][id]
+			device := types.GPUDeviceHistory{
+				Id:             id,
+				DutyCycle:      prometheus.SummarizeGPUMetric(s.DutyCycle),
+				MemoryUsed:     prometheus.SummarizeGPUMetric(s.MemoryUsed),
+				MemoryTotal:    prometheus.SummarizeGPUMetric(s.MemoryTotal).Max,
+				Samples:        len(s.DutyCycle),
+				DutyCycleTrend: prometheus.DownsampleGPUMetric(s.DutyCycle, gpuHistoryTrendWidth),
+			}
+			totalDutyCycle += device.DutyCycle.Avg
+			devices++
+			instance.GPUs = append(instance.GPUs, device)
+		}
+		history.Instances = append(history.Instances, instance)
+	}
+	if devices > 0 {
+		history.AvgDutyCycle = totalDutyCycle / float64(devices)
+	}
+	return history, nil
+}
+
+// jobReplicaTypes returns the replica types of training job in lower case, which are used in the pod names
+func jobReplicaTypes(job TrainingJob) []string {
+	replicaTypes := []string{}
+	switch trainJob := job.GetTrainJob().(type) {
+	case *tfv1.TFJob:
+		for replicaType := range trainJob.Spec.TFReplicaSpecs {
+			replicaTypes = append(replicaTypes, string(replicaType))
+		}
+	case *pytorchv1.PyTorchJob:
+		for replicaType := range trainJob.Spec.PyTorchReplicaSpecs {
+			replicaTypes = append(replicaTypes, string(replicaType))
+		}
+	case *mpiv1alpha1.MPIJob:
+		replicaTypes = append(replicaTypes, "launcher", "worker")
+	case *etv1alpha1.TrainingJob:
+		if trainJob.Spec.ETReplicaSpecs.Launcher != nil {
+			replicaTypes = append(replicaTypes, "launcher")
+		}
+		if trainJob.Spec.ETReplicaSpecs.Worker != nil {
+			replicaTypes = append(replicaTypes, "worker")
+		}
+	}
+	for i := range replicaTypes {
+		replicaTypes[i] = strings.ToLower(replicaTypes[i])
+	}
+	sort.Strings(replicaTypes)
+	return replicaTypes
+}
+
+// replicaPodNamePattern returns the regex which matches the pod names of the replicas, the pods are named
+// as <job>-<replica type>-<index> and the launcher is created by a Job with a random suffix.
+// All pods prefixed by the job name are matched if the replica types are unknown
+func replicaPodNamePattern(jobName string, replicaTypes []string) string {
+	if len(replicaTypes) == 0 {
+		return fmt.Sprintf("%s-.+", jobName)
+	}
+	patterns := []string{}
+	for _, replicaType := range replicaTypes {
+		if replicaType == "launcher" {
+			patterns = append(patterns, "launcher(-[a-z0-9]+)?")
+			continue
+		}
+		patterns = append(patterns, replicaType+"-[0-9]+")
+	}
+	return fmt.Sprintf("%s-(%s)", jobName, strings.Join(patterns, "|"))
+}
+
+// DisplayTrainingJobGPUHistory prints the gpu history of training job
+func DisplayTrainingJobGPUHistory(history *types.TrainingJobGPUHistory, format types.FormatStyle) {
+	switch format {
+	case types.JsonFormat:
+		data, _ := json.MarshalIndent(history, "", "    ")
+		fmt.Printf("%v", string(data))
+		return
+	case types.YamlFormat:
+		data, _ := yaml.Marshal(history)
+		fmt.Printf("%v", string(data))
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	PrintLine(w, fmt.Sprintf(strings.Trim(gpuHistoryTemplate, "\n"),
+		history.Name,
+		history.Namespace,
+		strings.ToUpper(string(history.Trainer)),
+		util.GetFormatTime(history.Start),
+		util.GetFormatTime(history.End),
+		util.ShortHumanDuration(time.Duration(history.End-history.Start)*time.Second),
+		time.Duration(history.Step)*time.Second,
+		strings.Join(gpuHistoryLines(history), "\n"),
+	))
+	_ = w.Flush()
+}
+
+// gpuHistoryLines returns the lines of gpu history which can be embedded in other outputs
+func gpuHistoryLines(history *types.TrainingJobGPUHistory) []string {
+	lines := []string{"", "GPU History:"}
+	if len(history.Instances) == 0 {
+		lines = append(lines, "  no gpu metrics are found in prometheus")
+		return lines
+	}
+	lines = append(lines, "  INSTANCE\tNODE\tGPU\tUTIL(AVG/P95/MAX)\tMEMORY(AVG/P95/MAX MiB)\tTOTAL_MEMORY(MiB)\tTREND")
+	lines = append(lines, "  --------\t----\t---\t-----------------\t-----------------------\t-----------------\t-----")
+	for _, instance := range history.Instances {
+		for index, gpu := range instance.GPUs {
+			name := instance.Name
+			node := instance.Node
+			if node == "" {
+				node = "N/A"
+			}
+			if index != 0 {
+				name = ""
+				node = ""
+			}
+			lines = append(lines, fmt.Sprintf("  %v\t%v\t%v\t%.1f%%/%.1f%%/%.1f%%\t%.0f/%.0f/%.0f\t%.0f\t%v",
+				name,
+				node,
+				gpu.Id,
+				gpu.DutyCycle.Avg,
+				gpu.DutyCycle.P95,
+				gpu.DutyCycle.Max,
+				fromByteToMiB(gpu.MemoryUsed.Avg),
+				fromByteToMiB(gpu.MemoryUsed.P95),
+				fromByteToMiB(gpu.MemoryUsed.Max),
+				fromByteToMiB(gpu.MemoryTotal),
+				prometheus.Sparkline(gpu.DutyCycleTrend, 100),
+			))
+		}
+	}
+	lines = append(lines, "", fmt.Sprintf("  Average GPU Utilization: %.1f%%", history.AvgDutyCycle))
+	if history.AvgDutyCycle < gpuUnderutilizedThreshold {
+		lines = append(lines, "  The gpus are underutilized, the job may be bound by data loading or cpu preprocessing.")
+	}
+	return lines
+}
@@ -0,0 +1,32 @@
+package training
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestReplicaPodNamePattern(t *testing.T) {
+	tc := []struct {
+		ReplicaTypes []string
+		PodName      string
+		Expected     bool
+	}{
+		{ReplicaTypes: []string{"ps", "worker"}, PodName: "tf-worker-0", Expected: true},
+		{ReplicaTypes: []string{"ps", "worker"}, PodName: "tf-ps-12", Expected: true},
+		{ReplicaTypes: []string{"ps", "worker"}, PodName: "tf-chief-0", Expected: false},
+		{ReplicaTypes: []string{"ps", "worker"}, PodName: "tf-worker-0-tensorboard", Expected: false},
+		{ReplicaTypes: []string{"launcher", "worker"}, PodName: "tf-launcher-x7k2p", Expected: true},
+		{ReplicaTypes: []string{"launcher", "worker"}, PodName: "tf-launcher", Expected: true},
+		{ReplicaTypes: []string{"master", "worker"}, PodName: "tf-master-0", Expected: true},
+		{ReplicaTypes: []string{}, PodName: "tf-driver", Expected: true},
+		{ReplicaTypes: []string{}, PodName: "tf2-worker-0", Expected: false},
+	}
+	for _, c := range tc {
+		// the pod names are fully matched by prometheus
+		pattern := "^(?:" + replicaPodNamePattern("tf", c.ReplicaTypes) + ")$"
+		actual := regexp.MustCompile(pattern).MatchString(c.PodName)
+		if actual != c.Expected {
+			t.Errorf("pod %s with replica types %v: Expected %v; Got %v", c.PodName, c.ReplicaTypes, c.Expected, actual)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package training
+
+import (
+	"fmt"
+
+	"github.com/kubeflow/arena/pkg/apis/types"
+	"github.com/kubeflow/arena/pkg/util/kubeclient"
+	yaml "gopkg.in/yaml.v2"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// trainingJobValues is the part of helm values of training job which can be reused by other jobs
+type trainingJobValues struct {
+	DataSet          map[string]string `yaml:"dataset"`
+	ImagePullSecrets []string          `yaml:"imagePullSecrets"`
+	NodeSelectors    map[string]string `yaml:"nodeSelectors"`
+}
+
+// GetTrainingJobSource returns the settings of training job, they are read from
+// the helm values which are stored in the configmap when the job is submitted
+func GetTrainingJobSource(jobName, namespace string, jobType types.TrainingJobType) (*types.TrainingJobSource, error) {
+	job, err := SearchTrainingJob(jobName, namespace, jobType)
+	if err != nil {
+		return nil, err
+	}
+	configmapName := fmt.Sprintf("%v-%v", job.Name(), job.Trainer())
+	configmap, err := kubeclient.GetConfigMap(namespace, configmapName)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, fmt.Errorf("not found the submitted values of training job %s, it may not be submitted by arena", jobName)
+		}
+		return nil, err
+	}
+	values := &trainingJobValues{}
+	if err := yaml.Unmarshal([]byte(configmap.Data["values"]), values); err != nil {
+		return nil, fmt.Errorf("failed to parse the submitted values of training job %s: %v", jobName, err)
+	}
+	return &types.TrainingJobSource{
+		Name:             job.Name(),
+		Namespace:        job.Namespace(),
+		Type:             job.Trainer(),
+		UID:              job.Uid(),
+		Status:           job.GetStatus(),
+		DataSet:          values.DataSet,
+		ImagePullSecrets: values.ImagePullSecrets,
+		NodeSelectors:    values.NodeSelectors,
+	}, nil
+}
@@ -0,0 +1,40 @@
+package training
+
+import (
+	"sort"
+	"time"
+
+	"github.com/kubeflow/arena/pkg/apis/config"
+	"github.com/kubeflow/arena/pkg/apis/types"
+	"github.com/kubeflow/arena/pkg/prometheus"
+)
+
+// GetTrainingJobXIDErrors returns the gpu xid errors reported by the nodes which the pods of job
+// ran on during the lifetime of job
+func GetTrainingJobXIDErrors(job TrainingJob) ([]types.GPUXIDError, error) {
+	startTime := job.StartTime()
+	if startTime == nil || startTime.IsZero() {
+		return []types.GPUXIDError{}, nil
+	}
+	start := startTime.Time
+	end := time.Now()
+	if finished := start.Add(job.Duration()); finished.Before(end) {
+		end = finished
+	}
+	nodes := map[string]bool{}
+	for _, pod := range job.AllPods() {
+		if pod.Spec.NodeName != "" {
+			nodes[pod.Spec.NodeName] = true
+		}
+	}
+	nodeNames := []string{}
+	for name := range nodes {
+		nodeNames = append(nodeNames, name)
+	}
+	sort.Strings(nodeNames)
+	if len(nodeNames) == 0 || !end.After(start) {
+		return []types.GPUXIDError{}, nil
+	}
+	client := config.GetArenaConfiger().GetClientSet()
+	return prometheus.GetNodeXIDErrors(client, nodeNames, start, end)
+}
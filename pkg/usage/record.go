@@ -0,0 +1,336 @@
+package usage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/kubeflow/arena/pkg/apis/config"
+	"github.com/kubeflow/arena/pkg/apis/types"
+	"github.com/kubeflow/arena/pkg/apis/utils"
+	"github.com/kubeflow/arena/pkg/serving"
+	"github.com/kubeflow/arena/pkg/training"
+	"github.com/kubeflow/arena/pkg/util"
+	log "github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// usageRecordUpdatePeriod is the period of updating the end time of running jobs
+	usageRecordUpdatePeriod = 10 * time.Minute
+	// usageRecordShardMaxBytes limits the size of records in one configmap, the size of configmap is limited
+	// to 1MiB, the room is left for the updates of running jobs whose records are not moved to other shards
+	usageRecordShardMaxBytes = 512 * 1024
+)
+
+// RunUsageRecorder records the usage of jobs into the configmaps of arena namespace every interval,
+// so that the usage of jobs which have been pruned can be reported. It never returns
+func RunUsageRecorder(interval time.Duration) {
+	for {
+		if err := recordUsage(); err != nil {
+			log.Errorf("failed to record the usage of jobs: %v", err)
+		}
+		time.Sleep(interval)
+	}
+}
+
+// recordUsage saves the usage of jobs, the records of finished jobs are not changed any more
+func recordUsage() error {
+	namespace := config.GetArenaConfiger().GetArenaNamespace()
+	records, err := listLiveUsageRecords("", true)
+	if err != nil {
+		return err
+	}
+	configmaps, err := listUsageRecordConfigMaps(namespace)
+	if err != nil {
+		return err
+	}
+	existed := map[string]*types.JobUsageRecord{}
+	located := map[string]string{}
+	shards := map[string]*v1.ConfigMap{}
+	for i := range configmaps {
+		shards[configmaps[i].Name] = &configmaps[i]
+		for _, record := range parseUsageRecords(configmaps[i]) {
+			existed[record.Uid] = record
+			located[record.Uid] = configmaps[i].Name
+		}
+	}
+	changed := map[string]bool{}
+	for _, record := range records {
+		if old, ok := existed[record.Uid]; ok {
+			if !needUpdateUsageRecord(old, record) {
+				continue
+			}
+			mergeUsageRecord(old, record)
+		}
+		content, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		shard, ok := shards[located[record.Uid]]
+		if !ok {
+			shard = findUsageRecordShard(shards, namespace, record, len(record.Uid)+len(content))
+			shards[shard.Name] = shard
+			located[record.Uid] = shard.Name
+		}
+		if shard.Data == nil {
+			shard.Data = map[string]string{}
+		}
+		shard.Data[record.Uid] = string(content)
+		changed[shard.Name] = true
+	}
+	client := config.GetArenaConfiger().GetClientSet()
+	for name := range changed {
+		shard := shards[name]
+		if shard.ResourceVersion == "" {
+			_, err = client.CoreV1().ConfigMaps(namespace).Create(context.TODO(), shard, metav1.CreateOptions{})
+		} else {
+			_, err = client.CoreV1().ConfigMaps(namespace).Update(context.TODO(), shard, metav1.UpdateOptions{})
+		}
+		if err != nil {
+			return fmt.Errorf("failed to save the usage records into configmap %s: %v", name, err)
+		}
+		log.Debugf("the usage records in configmap %v are updated", name)
+	}
+	return nil
+}
+
+// needUpdateUsageRecord checks the record is changed, the end time of running jobs is only updated
+// every usageRecordUpdatePeriod to reduce the writes of configmaps
+func needUpdateUsageRecord(old, record *types.JobUsageRecord) bool {
+	if old.Finished {
+		return false
+	}
+	copied := *old
+	copied.EndTime = record.EndTime
+	if copied != *record {
+		return true
+	}
+	return record.Finished || record.EndTime-old.EndTime >= int64(usageRecordUpdatePeriod.Seconds())
+}
+
+// mergeUsageRecord keeps the requested resources of old record if the pods of job have been cleaned
+func mergeUsageRecord(old, record *types.JobUsageRecord) {
+	if record.GPUs == 0 && record.CPUs == 0 && record.MemoryGB == 0 {
+		record.GPUs = old.GPUs
+		record.CPUs = old.CPUs
+		record.MemoryGB = old.MemoryGB
+	}
+	if record.Owner == "" {
+		record.Owner = old.Owner
+	}
+}
+
+// findUsageRecordShard returns the configmap which the new record is saved into, the records are sharded by
+// the date when the jobs started, and a new shard of the date is created if the shards of the date are full
+func findUsageRecordShard(shards map[string]*v1.ConfigMap, namespace string, record *types.JobUsageRecord, size int) *v1.ConfigMap {
+	start := time.Unix(record.StartTime, 0).UTC()
+	date := start.Format("2006-01-02")
+	names := []string{}
+	for name, shard := range shards {
+		if shard.Labels[types.UsageRecordDateLabel] == date {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if usageRecordShardSize(shards[name])+size <= usageRecordShardMaxBytes {
+			return shards[name]
+		}
+	}
+	name := ""
+	for index := len(names); ; index++ {
+		name = fmt.Sprintf("%s-%s-%d", types.UsageRecordConfigMapPrefix, start.Format("20060102"), index)
+		if _, ok := shards[name]; !ok {
+			break
+		}
+	}
+	return &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels: map[string]string{
+				types.UsageRecordLabel:     "true",
+				types.UsageRecordDateLabel: date,
+			},
+		},
+		Data: map[string]string{},
+	}
+}
+
+// usageRecordShardSize returns the size of the records in the configmap
+func usageRecordShardSize(configmap *v1.ConfigMap) int {
+	size := 0
+	for key, content := range configmap.Data {
+		size += len(key) + len(content)
+	}
+	return size
+}
+
+func listUsageRecordConfigMaps(namespace string) ([]v1.ConfigMap, error) {
+	client := config.GetArenaConfiger().GetClientSet()
+	configmaps, err := client.CoreV1().ConfigMaps(namespace).List(context.TODO(), metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=true", types.UsageRecordLabel),
+	})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to list the usage records: %v", err)
+	}
+	return configmaps.Items, nil
+}
+
+func parseUsageRecords(configmap v1.ConfigMap) []*types.JobUsageRecord {
+	records := []*types.JobUsageRecord{}
+	for key, content := range configmap.Data {
+		record := &types.JobUsageRecord{}
+		if err := json.Unmarshal([]byte(content), record); err != nil {
+			log.Debugf("skip the invalid usage record %v in configmap %v: %v", key, configmap.Name, err)
+			continue
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+// listLiveUsageRecords builds the usage records of the training jobs and serving jobs in the cluster,
+// the jobs which have not started are skipped
+func listLiveUsageRecords(namespace string, allNamespaces bool) ([]*types.JobUsageRecord, error) {
+	records := []*types.JobUsageRecord{}
+	trainingJobs, err := training.ListTrainingJobs(namespace, allNamespaces, types.AllTrainingJob)
+	if err != nil {
+		return nil, err
+	}
+	for _, job := range trainingJobs {
+		if record := buildTrainingJobUsageRecord(job); record != nil {
+			records = append(records, record)
+		}
+	}
+	servingJobs, err := serving.ListServingJobs(namespace, allNamespaces, types.AllServingJob)
+	if err != nil {
+		return nil, err
+	}
+	for _, job := range servingJobs {
+		if record := buildServingJobUsageRecord(job); record != nil {
+			records = append(records, record)
+		}
+	}
+	return records, nil
+}
+
+func buildTrainingJobUsageRecord(job training.TrainingJob) *types.JobUsageRecord {
+	startTime := job.StartTime()
+	if startTime == nil || startTime.IsZero() {
+		return nil
+	}
+	status := job.GetStatus()
+	record := &types.JobUsageRecord{
+		Name:      job.Name(),
+		Namespace: job.Namespace(),
+		Uid:       job.Uid(),
+		Owner:     util.GetPodsOwner(job.AllPods()),
+		Kind:      "training",
+		Type:      string(job.Trainer()),
+		Status:    status,
+		StartTime: startTime.Unix(),
+		EndTime:   startTime.Add(job.Duration()).Unix(),
+		Finished:  status == string(types.TrainingJobSucceeded) || status == string(types.TrainingJobFailed),
+		GPUs:      float64(job.RequestedGPU()),
+	}
+	record.CPUs, record.MemoryGB = podsRequests(currentReplicaPods(job.AllPods()))
+	return record
+}
+
+func buildServingJobUsageRecord(job serving.ServingJob) *types.JobUsageRecord {
+	startTime := job.StartTime()
+	if startTime == nil || startTime.IsZero() {
+		return nil
+	}
+	record := &types.JobUsageRecord{
+		Name:      job.Name(),
+		Namespace: job.Namespace(),
+		Uid:       job.Uid(),
+		Owner:     util.GetPodsOwner(job.Pods()),
+		Kind:      "serving",
+		Type:      string(job.Type()),
+		Status:    fmt.Sprintf("%d/%d", job.AvailableInstances(), job.DesiredInstances()),
+		StartTime: startTime.Unix(),
+		EndTime:   time.Now().Unix(),
+		GPUs:      job.RequestGPUs(),
+	}
+	record.CPUs, record.MemoryGB = podsRequests(currentReplicaPods(job.Pods()))
+	return record
+}
+
+// currentReplicaPods returns the pods of current replicas, the failed and retried pods are skipped.
+// Only the pods which are not terminated are returned if the job is running, otherwise the latest
+// pod of each replica is returned
+func currentReplicaPods(pods []*v1.Pod) []*v1.Pod {
+	active := []*v1.Pod{}
+	for _, pod := range pods {
+		if !utils.IsCompletedPod(pod) {
+			active = append(active, pod)
+		}
+	}
+	if len(active) != 0 {
+		return active
+	}
+	keys := []string{}
+	replicas := map[string]*v1.Pod{}
+	for _, pod := range pods {
+		key := replicaKeyOfPod(pod)
+		latest, ok := replicas[key]
+		if !ok {
+			keys = append(keys, key)
+		}
+		if !ok || latest.CreationTimestamp.Before(&pod.CreationTimestamp) {
+			replicas[key] = pod
+		}
+	}
+	result := []*v1.Pod{}
+	for _, key := range keys {
+		result = append(result, replicas[key])
+	}
+	return result
+}
+
+// replicaKeyOfPod returns the key of replica which the pod belongs to, the pods retried by a Job
+// are named by the generate name with random suffixes, the other pods keep the name of replica
+func replicaKeyOfPod(pod *v1.Pod) string {
+	if pod.GenerateName == "" {
+		return pod.Name
+	}
+	for _, owner := range pod.OwnerReferences {
+		if owner.Kind == "Job" {
+			return pod.GenerateName
+		}
+	}
+	return pod.Name
+}
+
+// podsRequests returns the cpu cores and memory(GiB) requested by the containers of pods,
+// the limits are used if the requests are not set
+func podsRequests(pods []*v1.Pod) (float64, float64) {
+	cpus := float64(0)
+	memory := float64(0)
+	for _, pod := range pods {
+		for _, c := range pod.Spec.Containers {
+			if val, ok := c.Resources.Requests[v1.ResourceCPU]; ok {
+				cpus += float64(val.MilliValue()) / 1000
+			} else if val, ok := c.Resources.Limits[v1.ResourceCPU]; ok {
+				cpus += float64(val.MilliValue()) / 1000
+			}
+			if val, ok := c.Resources.Requests[v1.ResourceMemory]; ok {
+				memory += float64(val.Value()) / (1024 * 1024 * 1024)
+			} else if val, ok := c.Resources.Limits[v1.ResourceMemory]; ok {
+				memory += float64(val.Value()) / (1024 * 1024 * 1024)
+			}
+		}
+	}
+	return cpus, memory
+}
@@ -0,0 +1,134 @@
+package usage
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kubeflow/arena/pkg/apis/types"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newReplicaPod(name, generateName string, phase v1.PodPhase, created time.Time) *v1.Pod {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			GenerateName:      generateName,
+			CreationTimestamp: metav1.NewTime(created),
+		},
+		Status: v1.PodStatus{Phase: phase},
+	}
+	if generateName != "" {
+		pod.OwnerReferences = []metav1.OwnerReference{{Kind: "Job", Name: "mpi-launcher"}}
+	}
+	return pod
+}
+
+func TestCurrentReplicaPods(t *testing.T) {
+	now := time.Now()
+	tc := []struct {
+		Name     string
+		Pods     []*v1.Pod
+		Expected []string
+	}{
+		{
+			Name: "running job skips the failed launcher",
+			Pods: []*v1.Pod{
+				newReplicaPod("mpi-launcher-abcde", "mpi-launcher-", v1.PodFailed, now.Add(-time.Hour)),
+				newReplicaPod("mpi-launcher-fghij", "mpi-launcher-", v1.PodRunning, now),
+				newReplicaPod("mpi-worker-0", "", v1.PodRunning, now.Add(-time.Hour)),
+			},
+			Expected: []string{"mpi-launcher-fghij", "mpi-worker-0"},
+		},
+		{
+			Name: "finished job keeps the latest pod of each replica",
+			Pods: []*v1.Pod{
+				newReplicaPod("mpi-launcher-abcde", "mpi-launcher-", v1.PodFailed, now.Add(-time.Hour)),
+				newReplicaPod("mpi-launcher-fghij", "mpi-launcher-", v1.PodSucceeded, now),
+				newReplicaPod("mpi-worker-0", "", v1.PodSucceeded, now.Add(-time.Hour)),
+			},
+			Expected: []string{"mpi-launcher-fghij", "mpi-worker-0"},
+		},
+		{
+			Name: "pods without generate name are different replicas",
+			Pods: []*v1.Pod{
+				newReplicaPod("tf-worker-0", "", v1.PodSucceeded, now),
+				newReplicaPod("tf-worker-1", "", v1.PodFailed, now),
+			},
+			Expected: []string{"tf-worker-0", "tf-worker-1"},
+		},
+	}
+	for _, c := range tc {
+		actual := currentReplicaPods(c.Pods)
+		names := []string{}
+		for _, pod := range actual {
+			names = append(names, pod.Name)
+		}
+		if len(names) != len(c.Expected) {
+			t.Errorf("%s: Expected %v; Got %v", c.Name, c.Expected, names)
+			continue
+		}
+		for i := range names {
+			if names[i] != c.Expected[i] {
+				t.Errorf("%s: Expected %v; Got %v", c.Name, c.Expected, names)
+				break
+			}
+		}
+	}
+}
+
+func newUsageRecordShard(name, date string, size int) *v1.ConfigMap {
+	return &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{types.UsageRecordDateLabel: date},
+		},
+		Data: map[string]string{"uid": strings.Repeat("x", size)},
+	}
+}
+
+func TestFindUsageRecordShard(t *testing.T) {
+	start := time.Date(2021, 8, 20, 12, 0, 0, 0, time.UTC)
+	record := &types.JobUsageRecord{Uid: "uid", StartTime: start.Unix()}
+	tc := []struct {
+		Name     string
+		Shards   []*v1.ConfigMap
+		Expected string
+	}{
+		{
+			Name:     "the first shard of the date is created",
+			Shards:   []*v1.ConfigMap{newUsageRecordShard("arena-usage-20210819-0", "2021-08-19", 0)},
+			Expected: "arena-usage-20210820-0",
+		},
+		{
+			Name: "the shard which is not full is used",
+			Shards: []*v1.ConfigMap{
+				newUsageRecordShard("arena-usage-20210820-0", "2021-08-20", usageRecordShardMaxBytes),
+				newUsageRecordShard("arena-usage-20210820-1", "2021-08-20", 1024),
+			},
+			Expected: "arena-usage-20210820-1",
+		},
+		{
+			Name: "a new shard is created when the shards of the date are full",
+			Shards: []*v1.ConfigMap{
+				newUsageRecordShard("arena-usage-20210820", "2021-08-20", usageRecordShardMaxBytes),
+				newUsageRecordShard("arena-usage-20210820-1", "2021-08-20", usageRecordShardMaxBytes-64),
+			},
+			Expected: "arena-usage-20210820-2",
+		},
+	}
+	for _, c := range tc {
+		shards := map[string]*v1.ConfigMap{}
+		for _, shard := range c.Shards {
+			shards[shard.Name] = shard
+		}
+		actual := findUsageRecordShard(shards, "arena-system", record, 128)
+		if actual.Name != c.Expected {
+			t.Errorf("%s: Expected %v; Got %v", c.Name, c.Expected, actual.Name)
+		}
+		if actual.Labels[types.UsageRecordDateLabel] != "2021-08-20" {
+			t.Errorf("%s: Expected the date label 2021-08-20; Got %v", c.Name, actual.Labels)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package usage
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/kubeflow/arena/pkg/apis/config"
+	"github.com/kubeflow/arena/pkg/apis/types"
+	"github.com/kubeflow/arena/pkg/util"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+)
+
+// GetUsageReport computes the gpu hours, cpu core hours and memory GiB hours of jobs in the time range,
+// which are aggregated by the groupBy of args. The usage records saved by the recorder are merged with
+// the jobs in the cluster, so the jobs which have been pruned are also counted
+func GetUsageReport(args *types.UsageReportArgs) (*types.UsageReport, error) {
+	records := map[string]*types.JobUsageRecord{}
+	configmaps, err := listUsageRecordConfigMaps(config.GetArenaConfiger().GetArenaNamespace())
+	if err != nil {
+		log.Warnf("%v, only the jobs in the cluster are counted", err)
+	}
+	for _, configmap := range configmaps {
+		for _, record := range parseUsageRecords(configmap) {
+			records[record.Uid] = record
+		}
+	}
+	liveRecords, err := listLiveUsageRecords(args.Namespace, args.AllNamespaces)
+	if err != nil {
+		return nil, err
+	}
+	for _, record := range liveRecords {
+		if old, ok := records[record.Uid]; ok {
+			if old.Finished {
+				continue
+			}
+			mergeUsageRecord(old, record)
+		}
+		records[record.Uid] = record
+	}
+	from := args.From.Unix()
+	to := args.To.Unix()
+	items := map[string]*types.UsageReportItem{}
+	report := &types.UsageReport{
+		From:    from,
+		To:      to,
+		GroupBy: args.GroupBy,
+		Items:   []types.UsageReportItem{},
+		Total:   types.UsageReportItem{Group: "total"},
+	}
+	for _, record := range records {
+		if !args.AllNamespaces && record.Namespace != args.Namespace {
+			continue
+		}
+		start := record.StartTime
+		if start < from {
+			start = from
+		}
+		end := record.EndTime
+		if end > to {
+			end = to
+		}
+		if end <= start {
+			continue
+		}
+		duration := time.Duration(end-start) * time.Second
+		group := usageRecordGroup(record, args.GroupBy)
+		item, ok := items[group]
+		if !ok {
+			item = &types.UsageReportItem{Group: group}
+			items[group] = item
+		}
+		for _, i := range []*types.UsageReportItem{item, &report.Total} {
+			i.Jobs++
+			i.GPUHours += record.GPUs * duration.Hours()
+			i.CPUCoreHours += record.CPUs * duration.Hours()
+			i.MemoryGBHours += record.MemoryGB * duration.Hours()
+		}
+	}
+	for _, item := range items {
+		report.Items = append(report.Items, *item)
+	}
+	sort.Slice(report.Items, func(i, j int) bool {
+		if report.Items[i].GPUHours != report.Items[j].GPUHours {
+			return report.Items[i].GPUHours > report.Items[j].GPUHours
+		}
+		return report.Items[i].Group < report.Items[j].Group
+	})
+	return report, nil
+}
+
+func usageRecordGroup(record *types.JobUsageRecord, groupBy types.UsageGroupBy) string {
+	switch groupBy {
+	case types.UsageGroupByUser:
+		if record.Owner == "" {
+			return "N/A"
+		}
+		return record.Owner
+	case types.UsageGroupByJobType:
+		return fmt.Sprintf("%s/%s", record.Kind, record.Type)
+	}
+	return record.Namespace
+}
+
+// DisplayUsageReport prints the usage report
+func DisplayUsageReport(report *types.UsageReport, format types.FormatStyle) {
+	switch format {
+	case types.JsonFormat:
+		data, _ := json.MarshalIndent(report, "", "    ")
+		fmt.Printf("%v", string(data))
+		return
+	case types.YamlFormat:
+		data, _ := yaml.Marshal(report)
+		fmt.Printf("%v", string(data))
+		return
+	case types.CSVFormat:
+		w := csv.NewWriter(os.Stdout)
+		_ = w.Write([]string{strings.ToUpper(string(report.GroupBy)), "JOBS", "GPU_HOURS", "CPU_CORE_HOURS", "MEMORY_GB_HOURS"})
+		for _, item := range append(report.Items, report.Total) {
+			_ = w.Write(usageReportItemFields(item))
+		}
+		w.Flush()
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	printLine(w, fmt.Sprintf("Usage From %v To %v", util.GetFormatTime(report.From), util.GetFormatTime(report.To)))
+	printLine(w, "")
+	printLine(w, strings.ToUpper(string(report.GroupBy)), "JOBS", "GPU_HOURS", "CPU_CORE_HOURS", "MEMORY_GB_HOURS")
+	for _, item := range report.Items {
+		printLine(w, usageReportItemFields(item)...)
+	}
+	printLine(w, "")
+	printLine(w, usageReportItemFields(report.Total)...)
+	_ = w.Flush()
+}
+
+func usageReportItemFields(item types.UsageReportItem) []string {
+	return []string{
+		item.Group,
+		fmt.Sprintf("%v", item.Jobs),
+		fmt.Sprintf("%.2f", item.GPUHours),
+		fmt.Sprintf("%.2f", item.CPUCoreHours),
+		fmt.Sprintf("%.2f", item.MemoryGBHours),
+	}
+}
+
+func printLine(w io.Writer, fields ...string) {
+	buffer := strings.Join(fields, "\t")
+	fmt.Fprintln(w, buffer)
+}
@@ -0,0 +1,86 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubectl
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/kubeflow/arena/pkg/apis/config"
+	log "github.com/sirupsen/logrus"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+)
+
+// PortForwarder forwards a random local port to a pod port through the api server
+type PortForwarder struct {
+	LocalPort int
+	stopCh    chan struct{}
+}
+
+// Close stops the port forwarding
+func (p *PortForwarder) Close() {
+	close(p.stopCh)
+}
+
+// ForwardPodPort forwards a random local port to the given port of the pod,
+// the caller should invoke Close() of the returned forwarder when finished
+func ForwardPodPort(namespace, podName string, port int) (*PortForwarder, error) {
+	arenaConfiger := config.GetArenaConfiger()
+	restConfig := arenaConfiger.GetRestConfig()
+	transport, upgrader, err := spdy.RoundTripperFor(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	url := arenaConfiger.GetClientSet().CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(podName).
+		SubResource("portforward").
+		URL()
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, url)
+	stopCh := make(chan struct{})
+	readyCh := make(chan struct{})
+	errOut := &bytes.Buffer{}
+	fw, err := portforward.New(dialer, []string{fmt.Sprintf("0:%v", port)}, stopCh, readyCh, io.Discard, errOut)
+	if err != nil {
+		return nil, err
+	}
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- fw.ForwardPorts()
+	}()
+	select {
+	case <-readyCh:
+	case err := <-errCh:
+		return nil, fmt.Errorf("failed to forward port %v of pod %v/%v: %v %v", port, namespace, podName, err, errOut.String())
+	case <-time.After(30 * time.Second):
+		close(stopCh)
+		return nil, fmt.Errorf("timeout to forward port %v of pod %v/%v", port, namespace, podName)
+	}
+	ports, err := fw.GetPorts()
+	if err != nil || len(ports) == 0 {
+		close(stopCh)
+		return nil, fmt.Errorf("failed to get the local port which is forwarded to pod %v/%v: %v", namespace, podName, err)
+	}
+	log.Debugf("forward local port %v to port %v of pod %v/%v", ports[0].Local, port, namespace, podName)
+	return &PortForwarder{
+		LocalPort: int(ports[0].Local),
+		stopCh:    stopCh,
+	}, nil
+}
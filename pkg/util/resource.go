@@ -3,6 +3,7 @@ package util
 import (
 	"context"
 
+	"github.com/kubeflow/arena/pkg/apis/types"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/kubernetes"
 
@@ -47,3 +48,19 @@ func AcquireServingServices(namespace string, client *kubernetes.Clientset) ([]v
 	log.Debugf("Services in %s: %++v", namespace, allServices[namespace])
 	return allServices[namespace], nil
 }
+
+// GetPodsOwner returns the user who submits the pods, it is recorded in the labels by arena
+func GetPodsOwner(pods []*v1.Pod) string {
+	for _, pod := range pods {
+		if owner := pod.Labels[types.UserNameLabel]; owner != "" {
+			return owner
+		}
+		if owner := pod.Annotations[types.UserNameLabel]; owner != "" {
+			return owner
+		}
+		if owner := pod.Labels[types.UserNameIdLabel]; owner != "" {
+			return owner
+		}
+	}
+	return ""
+}